@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// courseFormat identifie le format d'export ; à incrémenter si sa structure change.
+const courseFormat = "olp-course/1"
+
+// courseFile est le contenu publié d'un cours, sans identifiants : l'import recrée les leçons.
+type courseFile struct {
+	Format      string             `json:"format"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Lessons     []courseFileLesson `json:"lessons"`
+}
+
+type courseFileLesson struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// revisionLesson a la forme des leçons stockées dans course_revisions.lessons (voir handlers.LessonContent).
+type revisionLesson struct {
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func (f courseFile) validate() error {
+	if f.Format != courseFormat {
+		return fmt.Errorf("format %q non pris en charge (attendu %q)", f.Format, courseFormat)
+	}
+	if f.Title == "" {
+		return errors.New("titre du cours manquant")
+	}
+	for i, lesson := range f.Lessons {
+		if lesson.Title == "" {
+			return fmt.Errorf("leçon %d : titre manquant", i+1)
+		}
+	}
+	return nil
+}
+
+// exportCourse écrit la version publiée d'un cours ; le brouillon en cours n'est pas exporté.
+func exportCourse(args []string) (action, error) {
+	flags := newFlags("export-course")
+	id := flags.Int("id", 0, "")
+	output := flags.String("output", "", "")
+	if err := parseFlags(flags, args); err != nil || *id <= 0 {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		course, err := e.store.GetCourse(ctx, int32(*id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("cours %d introuvable", *id)
+		}
+		if err != nil {
+			return err
+		}
+		file := courseFile{Format: courseFormat, Title: course.Title, Description: course.Description.String, Lessons: []courseFileLesson{}}
+		if course.PublishedRevisionID.Valid {
+			rev, err := e.store.GetCourseRevision(ctx, course.PublishedRevisionID.Int32)
+			if err != nil {
+				return err
+			}
+			file.Title, file.Description = rev.Title, rev.Description.String
+			if err := json.Unmarshal(rev.Lessons, &file.Lessons); err != nil {
+				return err
+			}
+		} else {
+			slog.Warn("Cours jamais publié : seuls le titre et la description sont exportés", "course_id", course.ID)
+		}
+
+		out := e.out
+		if *output != "" {
+			f, err := os.Create(*output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(file)
+	}, nil
+}
+
+// importCourse crée un nouveau cours publié à partir d'un export, dans l'établissement choisi
+// (par défaut celui créé par la migration) ; le propriétaire doit pouvoir créer des cours et
+// devient membre de l'établissement s'il ne l'était pas.
+func importCourse(args []string) (action, error) {
+	flags := newFlags("import-course")
+	ownerEmail := flags.String("owner", "", "")
+	orgSlug := flags.String("organization", defaultOrganization, "")
+	input := flags.String("input", "", "")
+	if err := parseFlags(flags, args); err != nil || *ownerEmail == "" {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		in := e.in
+		if *input != "" {
+			f, err := os.Open(*input)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		var file courseFile
+		if err := json.NewDecoder(in).Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("export illisible : %w", err)
+		}
+		if err := file.validate(); err != nil {
+			return err
+		}
+		owner, err := e.store.GetUserByEmail(ctx, *ownerEmail)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("aucun compte avec l'email %s", *ownerEmail)
+		}
+		if err != nil {
+			return err
+		}
+		if owner.Role != "teacher" && owner.Role != "admin" {
+			return fmt.Errorf("%s n'est ni enseignant ni admin", *ownerEmail)
+		}
+		org, err := e.store.GetOrganizationBySlug(ctx, *orgSlug)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("établissement %q introuvable", *orgSlug)
+		}
+		if err != nil {
+			return err
+		}
+
+		var course db.Course
+		err = e.store.InTx(ctx, func(qtx db.Querier) error {
+			err := qtx.EnsureOrganizationMember(ctx, db.EnsureOrganizationMemberParams{OrganizationID: org.ID, UserID: owner.ID})
+			if err != nil {
+				return err
+			}
+			course, err = createPublishedCourse(ctx, qtx, org.ID, owner.ID, file)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.out, "Cours importé : id %d, %d leçon(s), propriétaire %s, établissement %s\n", course.ID, len(file.Lessons), owner.Email, org.Slug)
+		return nil
+	}, nil
+}
+
+// createPublishedCourse reproduit la création d'un cours puis la publication de sa première
+// révision, comme le font CreateCourseHandler et PublishCourseDraftHandler.
+func createPublishedCourse(ctx context.Context, qtx db.Querier, orgID, ownerID int32, file courseFile) (db.Course, error) {
+	description := sql.NullString{String: file.Description, Valid: file.Description != ""}
+	course, err := qtx.CreateCourse(ctx, db.CreateCourseParams{
+		Title:          file.Title,
+		Description:    description,
+		AuthorID:       sql.NullInt32{Int32: ownerID, Valid: true},
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return course, err
+	}
+	if _, err := qtx.AddCourseStaff(ctx, db.AddCourseStaffParams{CourseID: course.ID, UserID: ownerID, Role: "owner"}); err != nil {
+		return course, err
+	}
+	lessons := make([]revisionLesson, 0, len(file.Lessons))
+	for _, l := range file.Lessons {
+		lesson, err := qtx.CreateLesson(ctx, course.ID)
+		if err != nil {
+			return course, err
+		}
+		lessons = append(lessons, revisionLesson{ID: lesson.ID, Title: l.Title, Body: l.Body})
+	}
+	raw, err := json.Marshal(lessons)
+	if err != nil {
+		return course, err
+	}
+	rev, err := qtx.CreateCourseRevision(ctx, db.CreateCourseRevisionParams{
+		CourseID:    course.ID,
+		Title:       file.Title,
+		Description: description,
+		Lessons:     raw,
+		CreatedBy:   sql.NullInt32{Int32: ownerID, Valid: true},
+	})
+	if err != nil {
+		return course, err
+	}
+	return qtx.PublishCourseRevision(ctx, db.PublishCourseRevisionParams{
+		ID:                  course.ID,
+		Title:               file.Title,
+		Description:         description,
+		PublishedRevisionID: sql.NullInt32{Int32: rev.ID, Valid: true},
+	})
+}
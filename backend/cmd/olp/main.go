@@ -0,0 +1,176 @@
+// Command olp regroupe les opérations d'exploitation de la plateforme : comptes administrateurs,
+// migrations, données de démonstration, import/export de cours et maintenance de la base.
+// Il lit la même configuration que le serveur (DATABASE_URL ou DB_*, voir internal/database).
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"online-learning-platform-backend/internal/database"
+	"online-learning-platform-backend/internal/logging"
+	"online-learning-platform-backend/internal/migrate"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/migrations"
+)
+
+// errUsage : arguments invalides, olp affiche l'aide de la commande et sort avec le code 2.
+var errUsage = errors.New("arguments invalides")
+
+// env est ce que reçoit chaque commande : le repository pour les requêtes sqlc, le pool pour
+// la maintenance, et les flux d'entrée/sortie (remplacés dans les tests).
+type env struct {
+	store repository.Store
+	db    *sql.DB
+	in    io.Reader
+	out   io.Writer
+}
+
+// action est une commande dont les arguments ont été vérifiés, avant toute connexion à la base.
+type action func(ctx context.Context, e env) error
+
+type command struct {
+	name  string
+	usage string
+	parse func(args []string) (action, error)
+}
+
+var commands = []command{
+	{"create-admin", "--email <email> [--name <nom>] [--password-stdin]", createAdmin},
+	{"reset-password", "--email <email> [--password-stdin]", resetPassword},
+	{"set-role", "--email <email> --role student|teacher|admin", setRole},
+	{"seed", "[--password-stdin]", seedDemo},
+	{"export-course", "--id <cours> [--output <fichier>]", exportCourse},
+	{"import-course", "--owner <email> [--organization <slug>] [--input <fichier>]", importCourse},
+	{"reindex", "", reindex},
+	{"purge-tokens", "", purgeTokens},
+}
+
+func usage(w io.Writer) {
+	fmt.Fprint(w, "usage : olp <commande> [options]\n\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s\n", strings.TrimSpace(cmd.name+" "+cmd.usage))
+	}
+	fmt.Fprint(w, "  migrate <commande>\n")
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(migrate.Usage, "\n"), "\n") {
+		fmt.Fprint(w, "  "+line)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprint(w, "\nSans --password-stdin, un mot de passe aléatoire est généré et affiché une seule fois.\n")
+}
+
+func main() {
+	logging.Setup()
+	os.Exit(run(os.Args[1:]))
+}
+
+// run renvoie le code de sortie : 0, 1 en cas d'échec, 2 pour une erreur d'utilisation.
+func run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	if args[0] == "migrate" {
+		return runMigrate(args[1:])
+	}
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "commande inconnue : %s\n\n", args[0])
+		usage(os.Stderr)
+		return 2
+	}
+
+	act, err := cmd.parse(args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "usage : olp %s\n", strings.TrimSpace(cmd.name+" "+cmd.usage))
+		return 2
+	}
+
+	dbConn, m, err := connect()
+	if err != nil {
+		slog.Error("Base de données inutilisable", "error", err)
+		return 1
+	}
+	defer dbConn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	// Les commandes écrivent avec les requêtes du binaire : le schéma doit être à jour.
+	if err := m.Check(ctx); err != nil {
+		slog.Error("Schéma de base de données inutilisable", "error", err, "hint", "olp migrate up")
+		return 1
+	}
+	e := env{store: repository.NewSystem(dbConn), db: dbConn, in: os.Stdin, out: os.Stdout}
+	if err := act(ctx, e); err != nil {
+		slog.Error("Échec de la commande", "command", cmd.name, "error", err)
+		return 1
+	}
+	return 0
+}
+
+func runMigrate(args []string) int {
+	cmd, err := migrate.ParseCommand(args)
+	if err != nil {
+		fmt.Fprint(os.Stderr, "usage : olp migrate <commande>\n\n"+migrate.Usage)
+		return 2
+	}
+	dbConn, m, err := connect()
+	if err != nil {
+		slog.Error("Base de données inutilisable", "error", err)
+		return 1
+	}
+	defer dbConn.Close()
+	if err := cmd.Run(context.Background(), m, os.Stdout); err != nil {
+		slog.Error("Échec de la migration", "error", err)
+		return 1
+	}
+	return 0
+}
+
+// connect ouvre le pool du rôle système, comme le worker du serveur, et charge le plan de
+// migrations embarqué.
+func connect() (*sql.DB, *migrate.Migrator, error) {
+	cfg, err := database.SystemConfigFromEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	dbConn, err := database.Open(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := migrate.New(dbConn, migrations.FS)
+	if err != nil {
+		dbConn.Close()
+		return nil, nil, err
+	}
+	return dbConn, m, nil
+}
+
+// newFlags crée le jeu d'options d'une commande ; les erreurs d'analyse deviennent errUsage.
+func newFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// reindex reconstruit les index des tables de l'application puis rafraîchit les statistiques
+// du planificateur. La plateforme n'a pas de moteur de recherche séparé : le catalogue et les
+// listes passent par les index Postgres, que cette commande remet en état après un import
+// massif ou une restauration. CONCURRENTLY n'empêche ni les lectures ni les écritures.
+func reindex(args []string) (action, error) {
+	if err := parseFlags(newFlags("reindex"), args); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, e env) error {
+		rows, err := e.db.QueryContext(ctx, `SELECT tablename FROM pg_tables WHERE schemaname = current_schema() ORDER BY tablename`)
+		if err != nil {
+			return err
+		}
+		var tables []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				rows.Close()
+				return err
+			}
+			tables = append(tables, name)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, table := range tables {
+			ident := pgx.Identifier{table}.Sanitize()
+			if _, err := e.db.ExecContext(ctx, "REINDEX TABLE CONCURRENTLY "+ident); err != nil {
+				return fmt.Errorf("%s : %w", table, err)
+			}
+			if _, err := e.db.ExecContext(ctx, "ANALYZE "+ident); err != nil {
+				return fmt.Errorf("%s : %w", table, err)
+			}
+		}
+		fmt.Fprintf(e.out, "%d table(s) réindexée(s)\n", len(tables))
+		return nil
+	}, nil
+}
+
+// purgeTokens supprime les invitations (plateforme et équipes de cours) expirées sans avoir été
+// acceptées. Les jetons de session (JWT) ne sont pas stockés : ils expirent d'eux-mêmes.
+func purgeTokens(args []string) (action, error) {
+	if err := parseFlags(newFlags("purge-tokens"), args); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, e env) error {
+		users, err := e.store.DeleteExpiredUserInvitations(ctx)
+		if err != nil {
+			return err
+		}
+		staff, err := e.store.DeleteExpiredStaffInvitations(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.out, "%d invitation(s) expirée(s) supprimée(s), dont %d d'équipe pédagogique\n", users+staff, staff)
+		return nil
+	}, nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository/memory"
+)
+
+// runCommand analyse args puis exécute la commande sur store, stdin servant d'entrée standard.
+func runCommand(t *testing.T, store *memory.Store, parse func([]string) (action, error), stdin string, args ...string) (string, error) {
+	t.Helper()
+	act, err := parse(args)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	err = act(context.Background(), env{store: store, in: strings.NewReader(stdin), out: &out})
+	return out.String(), err
+}
+
+func checkPassword(t *testing.T, store *memory.Store, email, password string) db.User {
+	t.Helper()
+	user, err := store.GetUserByEmail(context.Background(), email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		t.Fatalf("mot de passe de %s non enregistré : %v", email, err)
+	}
+	return user
+}
+
+func TestCreateAdmin(t *testing.T) {
+	store := memory.New()
+	out, err := runCommand(t, store, createAdmin, "", "--email", "root@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Le mot de passe généré n'est affiché qu'une fois : il doit être celui enregistré.
+	i := strings.Index(out, "Mot de passe : ")
+	if i < 0 {
+		t.Fatalf("mot de passe généré absent de la sortie : %q", out)
+	}
+	password := strings.TrimSpace(out[i+len("Mot de passe : "):])
+	if user := checkPassword(t, store, "root@example.com", password); user.Role != "admin" {
+		t.Fatalf("rôle %q, attendu admin", user.Role)
+	}
+
+	if _, err := runCommand(t, store, createAdmin, "", "--email", "root@example.com"); err == nil {
+		t.Fatal("un second compte avec le même email doit être refusé")
+	}
+}
+
+func TestPasswordFromStdin(t *testing.T) {
+	store := memory.New()
+	if _, err := runCommand(t, store, createAdmin, "court\n", "--email", "a@example.com", "--password-stdin"); err == nil {
+		t.Fatal("un mot de passe de moins de 6 caractères doit être refusé")
+	}
+	out, err := runCommand(t, store, createAdmin, "secret123\n", "--email", "a@example.com", "--password-stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "secret123") {
+		t.Fatal("un mot de passe fourni ne doit pas être réaffiché")
+	}
+	checkPassword(t, store, "a@example.com", "secret123")
+
+	if _, err := runCommand(t, store, resetPassword, "nouveau-secret\n", "--email", "a@example.com", "--password-stdin"); err != nil {
+		t.Fatal(err)
+	}
+	checkPassword(t, store, "a@example.com", "nouveau-secret")
+	if _, err := runCommand(t, store, resetPassword, "nouveau-secret\n", "--email", "absent@example.com", "--password-stdin"); err == nil {
+		t.Fatal("réinitialiser un compte inexistant doit échouer")
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	store := memory.New()
+	if _, err := store.CreateUser(context.Background(), db.CreateUserParams{Name: "Ana", Email: "ana@example.com", Password: "x", Role: "student"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCommand(t, store, setRole, "", "--email", "ana@example.com", "--role", "teacher"); err != nil {
+		t.Fatal(err)
+	}
+	user, _ := store.GetUserByEmail(context.Background(), "ana@example.com")
+	if user.Role != "teacher" {
+		t.Fatalf("rôle %q, attendu teacher", user.Role)
+	}
+	if _, err := runCommand(t, store, setRole, "", "--email", "absent@example.com", "--role", "admin"); err == nil {
+		t.Fatal("changer le rôle d'un compte inexistant doit échouer")
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		parse func([]string) (action, error)
+		args  []string
+	}{
+		{"rôle inconnu", setRole, []string{"--email", "a@example.com", "--role", "root"}},
+		{"email manquant", createAdmin, nil},
+		{"argument en trop", reindex, []string{"public"}},
+		{"identifiant de cours manquant", exportCourse, nil},
+		{"option inconnue", importCourse, []string{"--owner", "a@example.com", "--force"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.parse(tt.args); !errors.Is(err, errUsage) {
+				t.Fatalf("erreur %v, attendu errUsage", err)
+			}
+		})
+	}
+}
+
+func TestCourseExportImportRoundTrip(t *testing.T) {
+	store := memory.New()
+	ctx := context.Background()
+	for _, u := range []db.CreateUserParams{
+		{Name: "Prof", Email: "prof@example.com", Password: "x", Role: "teacher"},
+		{Name: "Élève", Email: "eleve@example.com", Password: "x", Role: "student"},
+	} {
+		if _, err := store.CreateUser(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	export := `{"format": "olp-course/1", "title": "Go", "description": "Bases", "lessons": [{"title": "Installer", "body": "go.dev"}, {"title": "Types", "body": ""}]}`
+
+	if _, err := runCommand(t, store, importCourse, export, "--owner", "eleve@example.com"); err == nil {
+		t.Fatal("un étudiant ne peut pas être propriétaire d'un cours importé")
+	}
+	if _, err := runCommand(t, store, importCourse, `{"format": "autre/1", "title": "Go"}`, "--owner", "prof@example.com"); err == nil {
+		t.Fatal("un format inconnu doit être refusé")
+	}
+	if _, err := runCommand(t, store, importCourse, export, "--owner", "prof@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	course, err := store.GetCourse(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !course.PublishedRevisionID.Valid {
+		t.Fatal("le cours importé doit être publié")
+	}
+	role, err := store.GetCourseStaffRole(ctx, db.GetCourseStaffRoleParams{CourseID: course.ID, UserID: 1})
+	if err != nil || role != "owner" {
+		t.Fatalf("rôle du propriétaire %q (%v), attendu owner", role, err)
+	}
+
+	out, err := runCommand(t, store, exportCourse, "", "--id", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want courseFile
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(export), &want); err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Fatalf("export %s, attendu %s", gotJSON, wantJSON)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// defaultOrganization est l'établissement créé par la migration organizations.
+const defaultOrganization = "default"
+
+var demoUsers = []struct{ name, email, role, orgRole string }{
+	{"Admin Démo", "admin@demo.local", "admin", "admin"},
+	{"Enseignante Démo", "teacher@demo.local", "teacher", "instructor"},
+	{"Étudiant Démo", "student@demo.local", "student", "member"},
+}
+
+var demoCourses = []courseFile{
+	{
+		Format:      courseFormat,
+		Title:       "Introduction à Go",
+		Description: "Les bases du langage, de l'installation aux goroutines.",
+		Lessons: []courseFileLesson{
+			{"Installer Go", "Téléchargez Go sur go.dev et vérifiez l'installation avec `go version`."},
+			{"Types et fonctions", "Variables, types de base, fonctions à plusieurs retours."},
+			{"Goroutines et canaux", "Lancer une goroutine avec `go f()` et communiquer par canaux."},
+		},
+	},
+	{
+		Format:      courseFormat,
+		Title:       "SQL pour débutants",
+		Description: "Interroger une base PostgreSQL.",
+		Lessons: []courseFileLesson{
+			{"SELECT", "Lire des lignes, filtrer avec WHERE, trier avec ORDER BY."},
+			{"Jointures", "Combiner plusieurs tables avec JOIN."},
+		},
+	},
+}
+
+// seedDemo crée trois comptes de démonstration (admin, enseignante, étudiant) partageant un
+// mot de passe, membres de l'établissement par défaut, deux cours publiés par l'enseignante et
+// une inscription de l'étudiant.
+// Refuse de tourner deux fois : les comptes de démonstration doivent être absents.
+func seedDemo(args []string) (action, error) {
+	flags := newFlags("seed")
+	fromStdin := flags.Bool("password-stdin", false, "")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		for _, u := range demoUsers {
+			_, err := e.store.GetUserByEmail(ctx, u.email)
+			if err == nil {
+				return fmt.Errorf("données de démonstration déjà présentes (%s)", u.email)
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		password, generated, err := passwordFor(e, *fromStdin)
+		if err != nil {
+			return err
+		}
+		hash, err := hashPassword(password)
+		if err != nil {
+			return err
+		}
+
+		org, err := e.store.GetOrganizationBySlug(ctx, defaultOrganization)
+		if err != nil {
+			return fmt.Errorf("établissement par défaut : %w", err)
+		}
+
+		var courses []db.Course
+		err = e.store.InTx(ctx, func(qtx db.Querier) error {
+			ids := map[string]int32{}
+			for _, u := range demoUsers {
+				user, err := qtx.CreateUser(ctx, db.CreateUserParams{Name: u.name, Email: u.email, Password: hash, Role: u.role})
+				if err != nil {
+					return err
+				}
+				member := db.AddOrganizationMemberParams{OrganizationID: org.ID, UserID: user.ID, Role: u.orgRole}
+				if _, err := qtx.AddOrganizationMember(ctx, member); err != nil {
+					return err
+				}
+				ids[u.role] = user.ID
+			}
+			for _, file := range demoCourses {
+				course, err := createPublishedCourse(ctx, qtx, org.ID, ids["teacher"], file)
+				if err != nil {
+					return err
+				}
+				courses = append(courses, course)
+			}
+			_, err := qtx.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: ids["student"], CourseID: courses[0].ID})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, u := range demoUsers {
+			fmt.Fprintf(e.out, "Compte %-7s %s\n", u.role, u.email)
+		}
+		for _, course := range courses {
+			fmt.Fprintf(e.out, "Cours %d : %s\n", course.ID, course.Title)
+		}
+		if generated {
+			fmt.Fprintf(e.out, "Mot de passe des comptes : %s\n", password)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Mêmes bornes que l'inscription : bcrypt ignore tout au-delà de 72 octets.
+const (
+	minPasswordLen = 6
+	maxPasswordLen = 72
+)
+
+var userRoles = map[string]bool{"student": true, "teacher": true, "admin": true}
+
+// passwordFor lit le mot de passe sur la première ligne de l'entrée standard, pour qu'il
+// n'apparaisse ni dans l'historique du shell ni dans la liste des processus. Sans
+// --password-stdin, il est généré et affiché une seule fois.
+func passwordFor(e env, fromStdin bool) (password string, generated bool, err error) {
+	if !fromStdin {
+		buf := make([]byte, 12)
+		if _, err := rand.Read(buf); err != nil {
+			return "", false, err
+		}
+		return base64.RawURLEncoding.EncodeToString(buf), true, nil
+	}
+	line, err := bufio.NewReader(e.in).ReadString('\n')
+	if err != nil && line == "" {
+		return "", false, fmt.Errorf("lecture du mot de passe : %w", err)
+	}
+	password = strings.TrimRight(line, "\r\n")
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return "", false, fmt.Errorf("le mot de passe doit contenir entre %d et %d caractères", minPasswordLen, maxPasswordLen)
+	}
+	return password, false, nil
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// createAdmin crée le premier administrateur, sans passer par l'API publique.
+func createAdmin(args []string) (action, error) {
+	flags := newFlags("create-admin")
+	email := flags.String("email", "", "")
+	name := flags.String("name", "Administrateur", "")
+	fromStdin := flags.Bool("password-stdin", false, "")
+	if err := parseFlags(flags, args); err != nil || *email == "" || *name == "" {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		password, generated, err := passwordFor(e, *fromStdin)
+		if err != nil {
+			return err
+		}
+		hash, err := hashPassword(password)
+		if err != nil {
+			return err
+		}
+		user, err := e.store.CreateUser(ctx, db.CreateUserParams{Name: *name, Email: *email, Password: hash, Role: "admin"})
+		if repository.IsUniqueViolation(err) {
+			return fmt.Errorf("un compte existe déjà avec l'email %s (olp set-role pour le promouvoir)", *email)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.out, "Administrateur créé : id %d, %s\n", user.ID, user.Email)
+		if generated {
+			fmt.Fprintf(e.out, "Mot de passe : %s\n", password)
+		}
+		return nil
+	}, nil
+}
+
+func resetPassword(args []string) (action, error) {
+	flags := newFlags("reset-password")
+	email := flags.String("email", "", "")
+	fromStdin := flags.Bool("password-stdin", false, "")
+	if err := parseFlags(flags, args); err != nil || *email == "" {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		password, generated, err := passwordFor(e, *fromStdin)
+		if err != nil {
+			return err
+		}
+		hash, err := hashPassword(password)
+		if err != nil {
+			return err
+		}
+		rows, err := e.store.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{Email: *email, Password: hash})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("aucun compte avec l'email %s", *email)
+		}
+		fmt.Fprintf(e.out, "Mot de passe de %s réinitialisé\n", *email)
+		if generated {
+			fmt.Fprintf(e.out, "Mot de passe : %s\n", password)
+		}
+		return nil
+	}, nil
+}
+
+// setRole change le rôle global d'un compte. Les jetons déjà émis gardent l'ancien rôle
+// jusqu'à leur expiration.
+func setRole(args []string) (action, error) {
+	flags := newFlags("set-role")
+	email := flags.String("email", "", "")
+	role := flags.String("role", "", "")
+	if err := parseFlags(flags, args); err != nil || *email == "" || !userRoles[*role] {
+		return nil, errUsage
+	}
+	return func(ctx context.Context, e env) error {
+		user, err := e.store.GetUserByEmail(ctx, *email)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("aucun compte avec l'email %s", *email)
+		}
+		if err != nil {
+			return err
+		}
+		if user.Role == *role {
+			fmt.Fprintf(e.out, "%s a déjà le rôle %s\n", *email, *role)
+			return nil
+		}
+		if _, err := e.store.UpdateUserRole(ctx, db.UpdateUserRoleParams{Email: *email, Role: *role}); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.out, "Rôle de %s : %s -> %s\n", *email, user.Role, *role)
+		return nil
+	}, nil
+}
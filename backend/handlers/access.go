@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+)
+
+// Rôles de l'équipe pédagogique d'un cours (table course_staff).
+const (
+	StaffOwner        = "owner"
+	StaffCoInstructor = "co_instructor"
+	StaffTA           = "ta"
+	StaffGrader       = "grader"
+)
+
+type coursePermission int
+
+const (
+	permEditContent coursePermission = iota
+	permManageCohorts
+	permViewRoster
+	permManageStaff
+	permGrade
+	permViewSubmissions
+	permModerateForum
+	permPostAnnouncements
+)
+
+// staffPermissions : les TA corrigent sans toucher au contenu, les correcteurs ne voient que les copies.
+var staffPermissions = map[string][]coursePermission{
+	StaffOwner:        {permEditContent, permManageCohorts, permViewRoster, permManageStaff, permGrade, permViewSubmissions, permModerateForum, permPostAnnouncements},
+	StaffCoInstructor: {permEditContent, permManageCohorts, permViewRoster, permGrade, permViewSubmissions, permModerateForum},
+	StaffTA:           {permViewRoster, permGrade, permViewSubmissions, permModerateForum},
+	StaffGrader:       {permViewSubmissions},
+}
+
+func staffCan(role string, perm coursePermission) bool {
+	for _, p := range staffPermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// currentUserID renvoie l'identifiant posé dans le contexte par middleware.AuthRequired.
+func currentUserID(c *gin.Context) int32 {
+	userIDRaw, _ := c.Get("user_id")
+	if f, ok := userIDRaw.(float64); ok {
+		return int32(f)
+	}
+	return 0
+}
+
+// currentRole renvoie le rôle du token, normalisé en minuscules.
+func currentRole(c *gin.Context) string {
+	return strings.TrimSpace(strings.ToLower(c.GetString("role")))
+}
+
+// canAuthorCourses : seuls les enseignants et les admins créent des cours. Une fois le cours
+// créé, les droits passent par l'équipe pédagogique (permissions ci-dessus).
+func canAuthorCourses(role string) bool {
+	return role == "teacher" || role == "admin"
+}
+
+// courseMember décrit la place de l'utilisateur courant dans un cours.
+type courseMember struct {
+	admin     bool
+	staff     bool
+	staffRole string
+	// cohortID : 0 pour l'équipe (toutes les cohortes), -1 pour un inscrit sans cohorte.
+	cohortID int32
+}
+
+// loadCourseMember réserve l'accès aux inscrits, à l'équipe du cours et aux admins (403 sinon) ;
+// 404 pour un cours d'un autre établissement.
+func loadCourseMember(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32) (courseMember, bool) {
+	if _, ok := loadCourse(c, ctx, queries, courseID); !ok {
+		return courseMember{}, false
+	}
+	if currentRole(c) == "admin" {
+		return courseMember{admin: true, staff: true}, true
+	}
+	userID := currentUserID(c)
+	role, err := courseStaffRole(ctx, queries, courseID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return courseMember{}, false
+	}
+	if role != "" {
+		return courseMember{staff: true, staffRole: role}, true
+	}
+	enrollment, err := queries.GetEnrollment(ctx, db.GetEnrollmentParams{UserID: userID, CourseID: courseID})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Réservé aux inscrits et à l'équipe du cours"})
+		return courseMember{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return courseMember{}, false
+	}
+	member := courseMember{cohortID: -1}
+	if enrollment.CohortID.Valid {
+		member.cohortID = enrollment.CohortID.Int32
+	}
+	return member, true
+}
+
+// courseStaffRole renvoie le rôle de l'utilisateur dans le cours, ou "" s'il n'en fait pas partie.
+func courseStaffRole(ctx context.Context, queries db.Querier, courseID, userID int32) (string, error) {
+	role, err := queries.GetCourseStaffRole(ctx, db.GetCourseStaffRoleParams{CourseID: courseID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// canOnCourse vérifie une permission pour l'utilisateur courant ; les admins ont tous les droits.
+func canOnCourse(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32, perm coursePermission) (bool, error) {
+	if currentRole(c) == "admin" {
+		return true, nil
+	}
+	role, err := courseStaffRole(ctx, queries, courseID, currentUserID(c))
+	if err != nil {
+		return false, err
+	}
+	return staffCan(role, perm), nil
+}
+
+// loadCourse charge un cours de l'établissement de la requête ; 404 s'il n'existe pas ou
+// appartient à un autre établissement.
+func loadCourse(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32) (db.Course, bool) {
+	course, err := queries.GetCourse(ctx, courseID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Cours introuvable"})
+		return course, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return course, false
+	}
+	return course, true
+}
+
+// loadCourseWithPermission charge le cours de l'URL et répond 404/403 si l'utilisateur n'y a pas droit.
+func loadCourseWithPermission(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32, perm coursePermission) (db.Course, bool) {
+	course, ok := loadCourse(c, ctx, queries, courseID)
+	if !ok {
+		return course, false
+	}
+	allowed, err := canOnCourse(c, ctx, queries, courseID, perm)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return course, false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Vous n'avez pas les droits nécessaires sur ce cours"})
+		return course, false
+	}
+	return course, true
+}
+
+// inCurrentOrganization double, côté application, les politiques RLS de courses et cohorts : une
+// ressource d'un autre établissement est traitée comme inexistante.
+func inCurrentOrganization(c *gin.Context, orgID int32) bool {
+	return orgID == currentOrganization(c).ID
+}
+
+// parseIDParam lit un identifiant numérique dans l'URL.
+func parseIDParam(c *gin.Context, name string) (int32, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 32)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id), true
+}
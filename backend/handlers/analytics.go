@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/analytics"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	maxAnalyticsDays  = 365
+	maxAtRiskLearners = 200
+)
+
+// Toutes les réponses viennent des agrégats de internal/analytics : computed_at indique leur
+// fraîcheur (zéro tant que le premier recalcul n'a pas eu lieu).
+
+func formatComputedAt(at time.Time) *string {
+	if at.IsZero() {
+		return nil
+	}
+	formatted := at.Format(time.RFC3339)
+	return &formatted
+}
+
+func ratio(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// loadAnalyticsCourse réserve les statistiques d'un cours à ceux qui en voient les inscrits.
+func loadAnalyticsCourse(c *gin.Context, ctx context.Context, queries db.Querier) (db.Course, db.GetCourseAnalyticsSummaryRow, *string, bool) {
+	var summary db.GetCourseAnalyticsSummaryRow
+	courseID, ok := parseIDParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+		return db.Course{}, summary, nil, false
+	}
+	course, ok := loadCourseWithPermission(c, ctx, queries, courseID, permViewRoster)
+	if !ok {
+		return course, summary, nil, false
+	}
+	computedAt, err := analytics.ComputedAt(ctx, queries)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return course, summary, nil, false
+	}
+	now := time.Now()
+	summary, err = queries.GetCourseAnalyticsSummary(ctx, db.GetCourseAnalyticsSummaryParams{
+		CourseID:   courseID,
+		WeekStart:  now.AddDate(0, 0, -7),
+		MonthStart: now.AddDate(0, 0, -30),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return course, summary, nil, false
+	}
+	return course, summary, formatComputedAt(computedAt), true
+}
+
+// CourseAnalyticsHandler : chiffres clés du cours et série quotidienne des inscriptions, achèvements
+// et apprenants actifs sur les days derniers jours (30 par défaut), jours sans activité compris.
+func CourseAnalyticsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		days, ok := boundedQueryInt(c, "days", 30, maxAnalyticsDays)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre days invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, summary, computedAt, ok := loadAnalyticsCourse(c, ctx, queries)
+		if !ok {
+			return
+		}
+		y, m, d := time.Now().UTC().Date()
+		start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-days)
+		rows, err := queries.ListCourseDailyStats(ctx, db.ListCourseDailyStatsParams{CourseID: course.ID, Since: start})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		byDay := make(map[string]db.CourseDailyStat, len(rows))
+		for _, row := range rows {
+			byDay[row.Day.Format(time.DateOnly)] = row
+		}
+		series := make([]gin.H, 0, days)
+		for day := start; len(series) < days; day = day.AddDate(0, 0, 1) {
+			key := day.Format(time.DateOnly)
+			row := byDay[key]
+			series = append(series, gin.H{
+				"day":             key,
+				"enrollments":     row.Enrollments,
+				"completions":     row.Completions,
+				"active_learners": row.ActiveLearners,
+			})
+		}
+		var avgTime int64
+		if summary.Enrolled > 0 {
+			avgTime = summary.TimeSpentSeconds / summary.Enrolled
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"course_id":   course.ID,
+			"computed_at": computedAt,
+			"summary": gin.H{
+				"enrolled":            summary.Enrolled,
+				"completed":           summary.Completed,
+				"completion_rate":     ratio(summary.Completed, summary.Enrolled),
+				"active_learners_7d":  summary.ActiveWeek,
+				"active_learners_30d": summary.ActiveMonth,
+				"avg_time_seconds":    avgTime,
+			},
+			"daily": series,
+		})
+	}
+}
+
+// CourseLessonAnalyticsHandler : entonnoir de complétion des leçons de la révision publiée, avec
+// la perte d'une leçon à l'autre et le temps moyen passé sur chacune.
+func CourseLessonAnalyticsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, summary, computedAt, ok := loadAnalyticsCourse(c, ctx, queries)
+		if !ok {
+			return
+		}
+		var lessons []analytics.Lesson
+		if course.PublishedRevisionID.Valid {
+			rev, err := queries.GetCourseRevision(ctx, course.PublishedRevisionID.Int32)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			content, err := decodeLessons(rev.Lessons)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			for _, lesson := range content {
+				lessons = append(lessons, analytics.Lesson{ID: lesson.ID, Title: lesson.Title})
+			}
+		}
+		stats, err := queries.ListLessonStats(ctx, course.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"course_id":   course.ID,
+			"computed_at": computedAt,
+			"enrolled":    summary.Enrolled,
+			"lessons":     analytics.Funnel(lessons, stats, summary.Enrolled),
+		})
+	}
+}
+
+// AtRiskLearnersHandler liste les inscrits qui n'ont pas terminé le cours et n'y ont rien fait
+// depuis analytics.InactiveAfter, les plus anciennement actifs en premier.
+func AtRiskLearnersHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := boundedQueryInt(c, "limit", 50, maxAtRiskLearners)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre limit invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, summary, computedAt, ok := loadAnalyticsCourse(c, ctx, queries)
+		if !ok {
+			return
+		}
+		rows, err := queries.ListAtRiskLearners(ctx, db.ListAtRiskLearnersParams{
+			CourseID:      course.ID,
+			InactiveSince: time.Now().Add(-analytics.InactiveAfter),
+			Limit:         int32(limit),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		average := ratio(summary.LessonsCompleted, summary.Enrolled)
+		learners := []gin.H{}
+		for _, row := range rows {
+			var lastActive *string
+			if row.LastActiveAt.Valid {
+				formatted := row.LastActiveAt.Time.Format(time.RFC3339)
+				lastActive = &formatted
+			}
+			learners = append(learners, gin.H{
+				"user_id":            row.UserID,
+				"name":               row.Name,
+				"email":              row.Email,
+				"enrolled_at":        row.EnrolledAt.Format(time.RFC3339),
+				"lessons_completed":  row.LessonsCompleted,
+				"time_spent_seconds": row.TimeSpentSeconds,
+				"last_active_at":     lastActive,
+				"reasons":            analytics.RiskReasons(row, average),
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"course_id": course.ID, "computed_at": computedAt, "learners": learners})
+	}
+}
@@ -0,0 +1,161 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"online-learning-platform-backend/internal/analytics"
+	"online-learning-platform-backend/internal/db"
+)
+
+func TestCourseAnalytics(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+
+	var lessons []map[string]any
+	for i := 1; i <= 3; i++ {
+		lesson, err := store.CreateLesson(ctx, f.courseID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lessons = append(lessons, map[string]any{"id": lesson.ID, "title": fmt.Sprintf("Leçon %d", i), "body": ""})
+	}
+	raw, err := json.Marshal(lessons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev, err := store.CreateCourseRevision(ctx, db.CreateCourseRevisionParams{CourseID: f.courseID, Title: "Réseaux", Lessons: raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.PublishCourseRevision(ctx, db.PublishCourseRevisionParams{ID: f.courseID, Title: "Réseaux", PublishedRevisionID: sql.NullInt32{Int32: rev.ID, Valid: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lesson := func(i int) int32 { return lessons[i]["id"].(int32) }
+
+	// Il y a 20 jours : trois inscriptions ; Bob termine la première leçon puis décroche, Carol ne commence pas.
+	alice := f.outsider
+	bob := seedUser(t, store, "bob@example.com", "secret123", "student")
+	carol := seedUser(t, store, "carol@example.com", "secret123", "student")
+	past := time.Now().AddDate(0, 0, -20)
+	store.SetNow(func() time.Time { return past })
+	for _, id := range []int32{alice.ID, bob.ID, carol.ID} {
+		if _, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: id, CourseID: f.courseID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CompleteLesson(ctx, db.CompleteLessonParams{UserID: bob.ID, LessonID: lesson(0), CourseID: f.courseID}); err != nil {
+		t.Fatal(err)
+	}
+	// Dave termine deux leçons puis quitte sa cohorte : ses complétions ne comptent plus.
+	dave := seedUser(t, store, "dave@example.com", "secret123", "student")
+	cohort := sql.NullInt32{Int32: 7, Valid: true}
+	if _, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: dave.ID, CourseID: f.courseID, CohortID: cohort}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := store.CompleteLesson(ctx, db.CompleteLessonParams{UserID: dave.ID, LessonID: lesson(i), CourseID: f.courseID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.RemoveFromCohort(ctx, db.RemoveFromCohortParams{CohortID: cohort, UserID: dave.ID}); err != nil {
+		t.Fatal(err)
+	}
+	store.SetNow(time.Now)
+
+	// Aujourd'hui : Alice suit les deux premières leçons.
+	aliceToken := tokenFor(t, alice.ID, "student")
+	activity := func(i int) string { return fmt.Sprintf("/courses/%d/lessons/%d/activity", f.courseID, lesson(i)) }
+	expectStatus(t, do(t, r, http.MethodPost, activity(0), aliceToken, map[string]any{"seconds": 120}), http.StatusNoContent)
+	expectStatus(t, do(t, r, http.MethodPost, activity(1), aliceToken, map[string]any{"seconds": 400}), http.StatusNoContent)
+	expectStatus(t, do(t, r, http.MethodPost, activity(0), aliceToken, map[string]any{"seconds": 0}), http.StatusBadRequest)
+	expectStatus(t, do(t, r, http.MethodPost, activity(0), tokenFor(t, f.ta.ID, "student"), map[string]any{"seconds": 60}), http.StatusNotFound)
+	// Pas plus vite que le temps réel, ni sur une leçon hors de la révision publiée.
+	expectStatus(t, do(t, r, http.MethodPost, activity(0), aliceToken, map[string]any{"seconds": 60}), http.StatusTooManyRequests)
+	draft, err := store.CreateLesson(ctx, f.courseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectStatus(t, do(t, r, http.MethodPost, fmt.Sprintf("/courses/%d/lessons/%d/activity", f.courseID, draft.ID), aliceToken, map[string]any{"seconds": 60}), http.StatusNotFound)
+	for i := 0; i < 2; i++ {
+		if err := store.CompleteLesson(ctx, db.CompleteLessonParams{UserID: alice.ID, LessonID: lesson(i), CourseID: f.courseID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := analytics.NewRollup(store).Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	taToken := tokenFor(t, f.ta.ID, "student")
+	base := fmt.Sprintf("/courses/%d/analytics", f.courseID)
+	expectStatus(t, do(t, r, http.MethodGet, base, aliceToken, nil), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodGet, base+"?days=0", taToken, nil), http.StatusBadRequest)
+
+	w := do(t, r, http.MethodGet, base, taToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var overview struct {
+		ComputedAt *string `json:"computed_at"`
+		Summary    struct {
+			Enrolled    int64 `json:"enrolled"`
+			ActiveWeek  int64 `json:"active_learners_7d"`
+			ActiveMonth int64 `json:"active_learners_30d"`
+		} `json:"summary"`
+		Daily []struct {
+			Enrollments    int32 `json:"enrollments"`
+			ActiveLearners int32 `json:"active_learners"`
+		} `json:"daily"`
+	}
+	decode(t, w, &overview)
+	if overview.ComputedAt == nil || overview.Summary.Enrolled != 3 || overview.Summary.ActiveWeek != 1 || overview.Summary.ActiveMonth != 2 {
+		t.Fatalf("synthèse : %+v", overview)
+	}
+	var enrollments int32
+	for _, day := range overview.Daily {
+		enrollments += day.Enrollments
+	}
+	if len(overview.Daily) != 30 || enrollments != 3 || overview.Daily[29].ActiveLearners != 1 {
+		t.Fatalf("série quotidienne : %+v", overview.Daily)
+	}
+
+	w = do(t, r, http.MethodGet, base+"/lessons", taToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var funnel struct {
+		Lessons []analytics.FunnelStep `json:"lessons"`
+	}
+	decode(t, w, &funnel)
+	if len(funnel.Lessons) != 3 {
+		t.Fatalf("entonnoir : %+v", funnel.Lessons)
+	}
+	first, second := funnel.Lessons[0], funnel.Lessons[1]
+	if first.Completions != 2 || math.Abs(first.DropOff-1.0/3) > 1e-9 || first.AvgTimeSeconds != 120 {
+		t.Fatalf("première leçon : %+v", first)
+	}
+	if second.Completions != 1 || math.Abs(second.DropOff-1.0/3) > 1e-9 || funnel.Lessons[2].CompletionRate != 0 {
+		t.Fatalf("leçons suivantes : %+v", funnel.Lessons)
+	}
+
+	w = do(t, r, http.MethodGet, base+"/at-risk", taToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var atRisk struct {
+		Learners []struct {
+			UserID  int32    `json:"user_id"`
+			Reasons []string `json:"reasons"`
+		} `json:"learners"`
+	}
+	decode(t, w, &atRisk)
+	reasons := map[int32][]string{}
+	for _, l := range atRisk.Learners {
+		reasons[l.UserID] = l.Reasons
+	}
+	if len(reasons) != 2 || fmt.Sprint(reasons[bob.ID]) != "[inactive]" || fmt.Sprint(reasons[carol.ID]) != "[not_started]" {
+		t.Fatalf("apprenants en difficulté : %+v", atRisk.Learners)
+	}
+}
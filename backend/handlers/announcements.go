@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+type AnnouncementResponse struct {
+	ID             int32   `json:"id"`
+	CourseID       int32   `json:"course_id"`
+	CohortID       *int32  `json:"cohort_id"`
+	AuthorID       *int32  `json:"author_id"`
+	Title          string  `json:"title"`
+	Body           string  `json:"body"`
+	BodyHTML       string  `json:"body_html"`
+	SendEmail      bool    `json:"send_email"`
+	PublishAt      string  `json:"publish_at"`
+	Published      bool    `json:"published"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	ReadAt         *string `json:"read_at,omitempty"`
+	ReadCount      *int64  `json:"read_count,omitempty"`
+	RecipientCount *int64  `json:"recipient_count,omitempty"`
+}
+
+type announcementRequest struct {
+	Title     string     `json:"title" binding:"required,max=200"`
+	Body      string     `json:"body" binding:"required,max=20000"`
+	CohortID  *int32     `json:"cohort_id"`
+	PublishAt *time.Time `json:"publish_at"`
+	SendEmail bool       `json:"send_email"`
+}
+
+func toAnnouncementResponse(a db.Announcement) AnnouncementResponse {
+	resp := AnnouncementResponse{
+		ID:        a.ID,
+		CourseID:  a.CourseID,
+		Title:     a.Title,
+		Body:      a.Body,
+		BodyHTML:  a.BodyHtml,
+		SendEmail: a.SendEmail,
+		PublishAt: a.PublishAt.Format(time.RFC3339),
+		Published: a.PublishedAt.Valid,
+		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: a.UpdatedAt.Format(time.RFC3339),
+	}
+	if a.CohortID.Valid {
+		resp.CohortID = &a.CohortID.Int32
+	}
+	if a.AuthorID.Valid {
+		resp.AuthorID = &a.AuthorID.Int32
+	}
+	return resp
+}
+
+// loadAnnouncement charge l'annonce de l'URL en vérifiant qu'elle appartient bien au cours.
+func loadAnnouncement(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32) (db.Announcement, bool) {
+	announcementID, ok := parseIDParam(c, "announcementId")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'annonce invalide"})
+		return db.Announcement{}, false
+	}
+	announcement, err := queries.GetAnnouncement(ctx, announcementID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && announcement.CourseID != courseID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Annonce introuvable"})
+		return announcement, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return announcement, false
+	}
+	return announcement, true
+}
+
+// validateAnnouncementCohort vérifie que la cohorte visée appartient au cours.
+func validateAnnouncementCohort(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32, cohortID *int32) bool {
+	if cohortID == nil {
+		return true
+	}
+	cohort, err := queries.GetCohort(ctx, *cohortID)
+	if err != nil || cohort.CourseID != courseID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cohorte inconnue pour ce cours"})
+		return false
+	}
+	return true
+}
+
+func ListCourseAnnouncementsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		member, ok := loadCourseMember(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		response := []AnnouncementResponse{}
+		// L'équipe voit aussi les annonces programmées et leur taux de lecture.
+		if member.staff {
+			rows, err := queries.ListCourseAnnouncementsForStaff(ctx, courseID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			for _, row := range rows {
+				resp := toAnnouncementResponse(db.Announcement{
+					ID: row.ID, CourseID: row.CourseID, CohortID: row.CohortID, AuthorID: row.AuthorID,
+					Title: row.Title, Body: row.Body, BodyHtml: row.BodyHtml, SendEmail: row.SendEmail,
+					PublishAt: row.PublishAt, PublishedAt: row.PublishedAt, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+				})
+				resp.ReadCount = &row.ReadCount
+				resp.RecipientCount = &row.RecipientCount
+				response = append(response, resp)
+			}
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		rows, err := queries.ListCourseAnnouncementsForStudent(ctx, db.ListCourseAnnouncementsForStudentParams{
+			CourseID: courseID,
+			UserID:   currentUserID(c),
+			CohortID: member.cohortID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, row := range rows {
+			resp := toAnnouncementResponse(db.Announcement{
+				ID: row.ID, CourseID: row.CourseID, CohortID: row.CohortID, AuthorID: row.AuthorID,
+				Title: row.Title, Body: row.Body, BodyHtml: row.BodyHtml, SendEmail: row.SendEmail,
+				PublishAt: row.PublishAt, PublishedAt: row.PublishedAt, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+			})
+			if row.ReadAt.Valid {
+				readAt := row.ReadAt.Time.Format(time.RFC3339)
+				resp.ReadAt = &readAt
+			}
+			response = append(response, resp)
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateAnnouncementHandler publie immédiatement, ou à publish_at si la date est dans le futur.
+func CreateAnnouncementHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req announcementRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		html, err := renderMarkdown(req.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Markdown invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permPostAnnouncements); !ok {
+			return
+		}
+		if !validateAnnouncementCohort(c, ctx, queries, courseID, req.CohortID) {
+			return
+		}
+		publishAt := time.Now()
+		if req.PublishAt != nil && req.PublishAt.After(publishAt) {
+			publishAt = *req.PublishAt
+		}
+		userID := currentUserID(c)
+
+		var announcement db.Announcement
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			announcement, err = qtx.CreateAnnouncement(ctx, db.CreateAnnouncementParams{
+				CourseID:  courseID,
+				CohortID:  nullInt32(req.CohortID),
+				AuthorID:  sql.NullInt32{Int32: userID, Valid: userID > 0},
+				Title:     req.Title,
+				Body:      req.Body,
+				BodyHtml:  html,
+				SendEmail: req.SendEmail,
+				PublishAt: publishAt,
+			})
+			// Les annonces programmées sont publiées par le dispatcher de notifications.
+			if err != nil || publishAt.After(time.Now()) {
+				return err
+			}
+			announcement, err = qtx.MarkAnnouncementPublished(ctx, announcement.ID)
+			if err != nil {
+				return err
+			}
+			return notifications.QueueAnnouncement(ctx, qtx, announcement)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, toAnnouncementResponse(announcement))
+	}
+}
+
+// UpdateAnnouncementHandler : une fois l'annonce parue, seuls le titre et le texte restent modifiables.
+func UpdateAnnouncementHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req announcementRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		html, err := renderMarkdown(req.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Markdown invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permPostAnnouncements); !ok {
+			return
+		}
+		announcement, ok := loadAnnouncement(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		cohortID := nullInt32(req.CohortID)
+		publishAt := announcement.PublishAt
+		if announcement.PublishedAt.Valid {
+			if req.PublishAt != nil || cohortID != announcement.CohortID || req.SendEmail != announcement.SendEmail {
+				c.JSON(http.StatusConflict, gin.H{"error": "Annonce déjà publiée : seuls le titre et le texte sont modifiables"})
+				return
+			}
+		} else {
+			if !validateAnnouncementCohort(c, ctx, queries, courseID, req.CohortID) {
+				return
+			}
+			if req.PublishAt != nil {
+				publishAt = *req.PublishAt
+			}
+		}
+
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			// Chaque requête vérifie l'état de publication qu'elle suppose : une annonce publiée
+			// entre-temps par le dispatcher ne voit pas changer sa cohorte ni sa date.
+			if announcement.PublishedAt.Valid {
+				announcement, err = qtx.UpdatePublishedAnnouncement(ctx, db.UpdatePublishedAnnouncementParams{
+					ID:       announcement.ID,
+					Title:    req.Title,
+					Body:     req.Body,
+					BodyHtml: html,
+				})
+				return err
+			}
+			announcement, err = qtx.UpdateAnnouncement(ctx, db.UpdateAnnouncementParams{
+				ID:        announcement.ID,
+				CohortID:  cohortID,
+				Title:     req.Title,
+				Body:      req.Body,
+				BodyHtml:  html,
+				SendEmail: req.SendEmail,
+				PublishAt: publishAt,
+			})
+			// Avancer la date d'une annonce programmée à maintenant la publie tout de suite.
+			if err != nil || publishAt.After(time.Now()) {
+				return err
+			}
+			announcement, err = qtx.MarkAnnouncementPublished(ctx, announcement.ID)
+			if err != nil {
+				return err
+			}
+			return notifications.QueueAnnouncement(ctx, qtx, announcement)
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// Publiée (ou supprimée) entre-temps.
+			c.JSON(http.StatusConflict, gin.H{"error": staleResourceMessage})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toAnnouncementResponse(announcement))
+	}
+}
+
+func DeleteAnnouncementHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permPostAnnouncements); !ok {
+			return
+		}
+		announcement, ok := loadAnnouncement(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		if err := queries.DeleteAnnouncement(ctx, announcement.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func MarkAnnouncementReadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		member, ok := loadCourseMember(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		announcement, ok := loadAnnouncement(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		// Même règle que la liste des étudiants ; l'équipe n'y figure pas et ne compte pas parmi les lecteurs.
+		visible := !member.staff && announcement.PublishedAt.Valid &&
+			(!announcement.CohortID.Valid || announcement.CohortID.Int32 == member.cohortID)
+		if !visible {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Annonce introuvable"})
+			return
+		}
+		if err := queries.MarkAnnouncementRead(ctx, db.MarkAnnouncementReadParams{AnnouncementID: announcement.ID, UserID: currentUserID(c)}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func ListAnnouncementReadsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permPostAnnouncements); !ok {
+			return
+		}
+		announcement, ok := loadAnnouncement(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		reads, err := queries.ListAnnouncementReads(ctx, announcement.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if reads == nil {
+			reads = []db.ListAnnouncementReadsRow{}
+		}
+		c.JSON(http.StatusOK, reads)
+	}
+}
@@ -2,19 +2,19 @@ package handlers
 
 import (
 	"context"
-	"database/sql"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 	"github.com/golang-jwt/jwt/v5"
-	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/internal/metrics"
 )
 
 var jwtSecret = []byte("dev-secret-key-change-me")
 
-func LoginHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
+func LoginHandler(queries repository.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
 			Email    string `json:"email" binding:"required,email"`
@@ -24,14 +24,16 @@ func LoginHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 		defer cancel()
 		user, err := queries.GetUserByEmail(ctx, req.Email)
 		if err != nil {
+			metrics.FailedLogins.Inc()
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Utilisateur ou mot de passe invalide"})
 			return
 		}
 		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+			metrics.FailedLogins.Inc()
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Utilisateur ou mot de passe invalide"})
 			return
 		}
@@ -46,6 +48,7 @@ func LoginHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors de la génération du token"})
 			return
 		}
+		metrics.Logins.Inc()
 		c.JSON(http.StatusOK, gin.H{"token": tokenString})
 	}
 }
@@ -0,0 +1,49 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestLogin(t *testing.T) {
+	r, store := newTestRouter(t)
+	user := seedUser(t, store, "carol@example.com", "secret123", "teacher")
+
+	w := do(t, r, http.MethodPost, "/login", "", map[string]string{"email": "carol@example.com", "password": "secret123"})
+	expectStatus(t, w, http.StatusOK)
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	decode(t, w, &body)
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(body.Token, claims, func(*jwt.Token) (any, error) { return testSecret, nil }); err != nil {
+		t.Fatalf("token invalide: %v", err)
+	}
+	if claims["user_id"] != float64(user.ID) || claims["role"] != "teacher" {
+		t.Fatalf("claims inattendus: %v", claims)
+	}
+}
+
+func TestLoginRejected(t *testing.T) {
+	r, store := newTestRouter(t)
+	seedUser(t, store, "dave@example.com", "secret123", "student")
+
+	tests := []struct {
+		name string
+		body map[string]string
+		want int
+	}{
+		{"mauvais mot de passe", map[string]string{"email": "dave@example.com", "password": "wrong"}, http.StatusUnauthorized},
+		{"email inconnu", map[string]string{"email": "nobody@example.com", "password": "secret123"}, http.StatusUnauthorized},
+		{"mot de passe manquant", map[string]string{"email": "dave@example.com"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, r, http.MethodPost, "/login", "", tt.body)
+			expectStatus(t, w, tt.want)
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Les documents Open Badges sont publics et servis en JSON-LD : leurs identifiants sont ces URL.
+const jsonLDContentType = "application/ld+json"
+
+func toBadgeClassResponse(class db.BadgeClass) gin.H {
+	return gin.H{
+		"id":              class.ID,
+		"course_id":       class.CourseID,
+		"name":            class.Name,
+		"description":     class.Description,
+		"criteria":        class.Criteria,
+		"image_url":       nullableString(class.ImageUrl),
+		"active":          class.Active,
+		"achievement_url": badges.AchievementURL(class.ID),
+		"created_at":      class.CreatedAt.Format(time.RFC3339),
+		"updated_at":      class.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type badgeClassRequest struct {
+	Name        string `json:"name" binding:"required,max=200"`
+	Description string `json:"description" binding:"required,max=2000"`
+	Criteria    string `json:"criteria" binding:"max=2000"`
+	ImageURL    string `json:"image_url" binding:"max=500"`
+}
+
+func (req *badgeClassRequest) validate() error {
+	req.Name, req.Description, req.Criteria = strings.TrimSpace(req.Name), strings.TrimSpace(req.Description), strings.TrimSpace(req.Criteria)
+	if req.Name == "" || req.Description == "" {
+		return errors.New("le nom et la description sont obligatoires")
+	}
+	if req.ImageURL != "" {
+		u, err := url.Parse(req.ImageURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.New("image_url doit être une URL http(s)")
+		}
+	}
+	return nil
+}
+
+// loadBadgeClass charge la classe de l'URL ; celles des autres établissements sont introuvables.
+func loadBadgeClass(c *gin.Context, ctx context.Context, queries db.Querier) (db.BadgeClass, bool) {
+	classID, ok := parseIDParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de badge invalide"})
+		return db.BadgeClass{}, false
+	}
+	class, err := queries.GetBadgeClass(ctx, classID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, class.OrganizationID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Badge introuvable"})
+		return class, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return class, false
+	}
+	return class, true
+}
+
+func ListBadgeClassesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		classes, err := queries.ListBadgeClasses(ctx, currentOrganization(c).ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, class := range classes {
+			response = append(response, toBadgeClassResponse(class))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateBadgeClassHandler (admin de l'établissement) crée le badge d'un cours. Les étudiants qui
+// ont déjà terminé le cours le reçoivent aussi, en tâche de fond.
+func CreateBadgeClassHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			CourseID int32 `json:"course_id" binding:"required"`
+			badgeClassRequest
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := req.validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		course, err := queries.GetCourse(ctx, req.CourseID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cours introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if req.Criteria == "" {
+			req.Criteria = fmt.Sprintf("Terminer toutes les leçons du cours « %s ».", course.Title)
+		}
+		var class db.BadgeClass
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			class, err = qtx.CreateBadgeClass(ctx, db.CreateBadgeClassParams{
+				OrganizationID: course.OrganizationID,
+				CourseID:       course.ID,
+				Name:           req.Name,
+				Description:    req.Description,
+				Criteria:       req.Criteria,
+				ImageUrl:       optionalString(req.ImageURL),
+				CreatedBy:      sql.NullInt32{Int32: currentUserID(c), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			return badges.EnqueueClassAward(ctx, qtx, class.ID)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, toBadgeClassResponse(class))
+	}
+}
+
+// UpdateBadgeClassHandler modifie un badge. Les credentials déjà délivrés gardent la description
+// signée ; une classe désactivée n'est plus décernée, et sa réactivation rattrape les absents.
+func UpdateBadgeClassHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			badgeClassRequest
+			Active *bool `json:"active"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := req.validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		class, ok := loadBadgeClass(c, ctx, queries)
+		if !ok {
+			return
+		}
+		active := class.Active
+		if req.Active != nil {
+			active = *req.Active
+		}
+		if req.Criteria == "" {
+			req.Criteria = class.Criteria
+		}
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			updated, err := qtx.UpdateBadgeClass(ctx, db.UpdateBadgeClassParams{
+				ID:          class.ID,
+				Name:        req.Name,
+				Description: req.Description,
+				Criteria:    req.Criteria,
+				ImageUrl:    optionalString(req.ImageURL),
+				Active:      active,
+			})
+			if err != nil {
+				return err
+			}
+			reactivated := active && !class.Active
+			class = updated
+			if reactivated {
+				return badges.EnqueueClassAward(ctx, qtx, class.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toBadgeClassResponse(class))
+	}
+}
+
+// GetBadgeIssuerHandler (public) : Profile Open Badges de l'établissement émetteur.
+func GetBadgeIssuerHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orgID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'émetteur invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		org, err := queries.GetOrganization(ctx, orgID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Émetteur introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		profile := badges.IssuerProfile(org)
+		profile.Context = badges.Contexts
+		c.Header("Content-Type", jsonLDContentType)
+		c.JSON(http.StatusOK, profile)
+	}
+}
+
+// GetAchievementHandler (public) : Achievement Open Badges d'une classe de badge, y compris
+// désactivée puisque des credentials y renvoient encore.
+func GetAchievementHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		classID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de badge invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		class, err := queries.GetBadgeClass(ctx, classID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Badge introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		org, err := queries.GetOrganization(ctx, class.OrganizationID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		achievement := badges.AchievementOf(class, org)
+		achievement.Context = badges.Contexts
+		c.Header("Content-Type", jsonLDContentType)
+		c.JSON(http.StatusOK, achievement)
+	}
+}
+
+// GetBadgeKeyHandler (public) publie la clé de vérification désignée par le kid des VC-JWT,
+// y compris une clé retirée.
+func GetBadgeKeyHandler(keys *badges.Keyring) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, jwk := range keys.JWKS() {
+			if jwk["kid"] == c.Param("kid") {
+				c.JSON(http.StatusOK, jwk)
+				return
+			}
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Clé inconnue"})
+	}
+}
+
+func ListBadgeKeysHandler(keys *badges.Keyring) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"keys": keys.JWKS()})
+	}
+}
+
+func loadBadgeCredential(c *gin.Context, ctx context.Context, queries db.Querier) (db.BadgeCredential, bool) {
+	cred, err := queries.GetBadgeCredential(ctx, c.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Badge introuvable"})
+		return cred, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return cred, false
+	}
+	return cred, true
+}
+
+// GetBadgeCredentialHandler (public) sert le credential hébergé : le document JSON-LD, ou sa
+// forme signée VC-JWT si le client demande application/jwt.
+func GetBadgeCredentialHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cred, ok := loadBadgeCredential(c, ctx, queries)
+		if !ok {
+			return
+		}
+		if strings.Contains(c.GetHeader("Accept"), "application/jwt") {
+			c.Data(http.StatusOK, "application/jwt", []byte(cred.Jwt))
+			return
+		}
+		c.Data(http.StatusOK, jsonLDContentType, cred.Credential)
+	}
+}
+
+// VerifyBadgeCredentialHandler (public) : vérification hébergée. Contrôle la signature du VC-JWT
+// avec la clé de la plateforme qui l'a signé et la révocation ; le credential renvoyé est celui qui
+// est signé.
+func VerifyBadgeCredentialHandler(queries repository.Store, keys *badges.Keyring) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cred, ok := loadBadgeCredential(c, ctx, queries)
+		if !ok {
+			return
+		}
+		signature := gin.H{"check": "signature", "passed": true}
+		document, err := badges.Verify(keys, cred.KeyID, cred.Jwt)
+		if err != nil {
+			signature["passed"], signature["detail"] = false, err.Error()
+			document = cred.Credential
+		}
+		revocation := gin.H{"check": "revocation", "passed": !cred.RevokedAt.Valid}
+		status := "valid"
+		switch {
+		case err != nil:
+			status = "invalid"
+		case cred.RevokedAt.Valid:
+			status = "revoked"
+			revocation["detail"] = cred.RevocationReason.String
+		}
+		response := gin.H{
+			"id":         cred.ID,
+			"status":     status,
+			"valid":      status == "valid",
+			"checks":     []gin.H{signature, revocation},
+			"issued_at":  cred.IssuedAt.Format(time.RFC3339),
+			"credential": json.RawMessage(document),
+		}
+		if cred.RevokedAt.Valid {
+			response["revoked_at"] = cred.RevokedAt.Time.Format(time.RFC3339)
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// RevokeBadgeCredentialHandler (admin de l'établissement émetteur) : définitif, visible à la
+// vérification avec son motif.
+func RevokeBadgeCredentialHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Reason string `json:"reason" binding:"required,max=500"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cred, ok := loadBadgeCredential(c, ctx, queries)
+		if !ok {
+			return
+		}
+		class, err := queries.GetBadgeClass(ctx, cred.BadgeClassID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !inCurrentOrganization(c, class.OrganizationID) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Badge introuvable"})
+			return
+		}
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		revoked, err := queries.RevokeBadgeCredential(ctx, db.RevokeBadgeCredentialParams{
+			ID:               cred.ID,
+			RevokedBy:        sql.NullInt32{Int32: currentUserID(c), Valid: true},
+			RevocationReason: sql.NullString{String: strings.TrimSpace(req.Reason), Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Ce badge est déjà révoqué"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": revoked.ID, "revoked_at": revoked.RevokedAt.Time.Format(time.RFC3339)})
+	}
+}
+
+// ListMyBadgesHandler : le backpack de l'utilisateur. Chaque badge y figure avec sa forme
+// VC-JWT, à importer dans un portefeuille tiers.
+func ListMyBadgesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		items, err := queries.ListUserBadgeCredentials(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, item := range items {
+			status := "valid"
+			if item.RevokedAt.Valid {
+				status = "revoked"
+			}
+			response = append(response, gin.H{
+				"id":             item.ID,
+				"badge_class_id": item.BadgeClassID,
+				"course_id":      item.CourseID,
+				"name":           item.BadgeName,
+				"description":    item.BadgeDescription,
+				"image_url":      nullableString(item.BadgeImageUrl),
+				"status":         status,
+				"issued_at":      item.IssuedAt.Format(time.RFC3339),
+				"credential_url": badges.CredentialURL(item.ID),
+				"verify_url":     badges.CredentialURL(item.ID) + "/verify",
+				"jwt":            item.Jwt,
+			})
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
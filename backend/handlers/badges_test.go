@@ -0,0 +1,216 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+)
+
+func TestBadgeAwardAndVerification(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	superToken := tokenFor(t, f.admin.ID, "admin")
+
+	// L'étudiant a terminé le cours avant la création du badge : le rattrapage le lui décerne.
+	student := f.outsider
+	enrollment, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: student.ID, CourseID: f.courseID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.MarkEnrollmentCompleted(ctx, enrollment.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	class := map[string]any{"course_id": f.courseID, "name": "Réseaux — niveau 1", "description": "Bases des réseaux IP."}
+	expectStatus(t, do(t, r, http.MethodPost, "/organization/badge-classes", tokenFor(t, f.owner.ID, "teacher"), class), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodPost, "/organization/badge-classes", superToken, map[string]any{"course_id": 999, "name": "x", "description": "y"}), http.StatusNotFound)
+	w := do(t, r, http.MethodPost, "/organization/badge-classes", superToken, class)
+	expectStatus(t, w, http.StatusCreated)
+	var created struct {
+		ID       int32  `json:"id"`
+		Criteria string `json:"criteria"`
+	}
+	decode(t, w, &created)
+	if created.Criteria == "" {
+		t.Fatal("critères par défaut absents")
+	}
+	if jobs := store.Jobs(badges.JobAwardClass); len(jobs) != 1 {
+		t.Fatalf("%d rattrapages enfilés, attendu 1", len(jobs))
+	}
+
+	awarder := badges.NewAwarder(store, testBadgeKey(t))
+	for i := 0; i < 2; i++ {
+		if err := awarder.AwardClass(ctx, created.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := store.Notifications(student.ID); len(got) != 1 || got[0].Type != notifications.BadgeAwarded {
+		t.Fatalf("notifications de l'étudiant : %+v", got)
+	}
+
+	w = do(t, r, http.MethodGet, "/me/badges", tokenFor(t, student.ID, "student"), nil)
+	expectStatus(t, w, http.StatusOK)
+	var backpack []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Status string `json:"status"`
+		Jwt    string `json:"jwt"`
+	}
+	decode(t, w, &backpack)
+	if len(backpack) != 1 || backpack[0].Name != "Réseaux — niveau 1" || backpack[0].Jwt == "" {
+		t.Fatalf("backpack inattendu : %+v", backpack)
+	}
+	credPath := "/badges/credentials/" + backpack[0].ID
+
+	// Credential hébergé : JSON-LD, titulaire désigné par l'empreinte salée de son email.
+	w = do(t, r, http.MethodGet, credPath, "", nil)
+	expectStatus(t, w, http.StatusOK)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/ld+json") {
+		t.Fatalf("Content-Type %q", ct)
+	}
+	var cred badges.Credential
+	decode(t, w, &cred)
+	identity := cred.CredentialSubject.Identifier[0]
+	if cred.Type[1] != "OpenBadgeCredential" || identity.IdentityHash != badges.HashIdentity(student.Email, identity.Salt) {
+		t.Fatalf("credential inattendu : %+v", cred)
+	}
+	if strings.Contains(w.Body.String(), student.Email) {
+		t.Fatal("l'email du titulaire apparaît en clair")
+	}
+	expectStatus(t, do(t, r, http.MethodGet, fmt.Sprintf("/badges/classes/%d", created.ID), "", nil), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodGet, "/badges/keys/"+testBadgeKey(t).ID, "", nil), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodGet, fmt.Sprintf("/badges/issuers/%d", defaultOrgID), "", nil), http.StatusOK)
+
+	var verified struct {
+		Status string `json:"status"`
+		Valid  bool   `json:"valid"`
+	}
+	w = do(t, r, http.MethodGet, credPath+"/verify", "", nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &verified)
+	if !verified.Valid {
+		t.Fatalf("badge non valide : %s", w.Body.String())
+	}
+	// Une signature altérée est refusée.
+	keys := testBadgeKeys(t)
+	if _, err := badges.Verify(keys, testBadgeKey(t).ID, backpack[0].Jwt[:len(backpack[0].Jwt)-4]+"AAAA"); err == nil {
+		t.Fatal("signature altérée acceptée")
+	}
+	// Seule la clé enregistrée avec le credential le vérifie.
+	if _, err := badges.Verify(keys, testRetiredBadgeKey(t).ID, backpack[0].Jwt); err == nil {
+		t.Fatal("signature vérifiée avec une autre clé")
+	}
+
+	// Révocation par l'administration de l'établissement uniquement.
+	reason := map[string]string{"reason": "Délivré par erreur"}
+	expectStatus(t, do(t, r, http.MethodPost, credPath+"/revoke", tokenFor(t, f.owner.ID, "teacher"), reason), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodPost, credPath+"/revoke", superToken, reason), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodPost, credPath+"/revoke", superToken, reason), http.StatusConflict)
+	w = do(t, r, http.MethodGet, credPath+"/verify", "", nil)
+	decode(t, w, &verified)
+	if verified.Valid || verified.Status != "revoked" {
+		t.Fatalf("badge révoqué encore valide : %+v", verified)
+	}
+}
+
+func TestBadgeClassDeactivation(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	superToken := tokenFor(t, f.admin.ID, "admin")
+
+	w := do(t, r, http.MethodPost, "/organization/badge-classes", superToken, map[string]any{"course_id": f.courseID, "name": "Réseaux", "description": "Bases."})
+	expectStatus(t, w, http.StatusCreated)
+	var class struct {
+		ID int32 `json:"id"`
+	}
+	decode(t, w, &class)
+	path := fmt.Sprintf("/organization/badge-classes/%d", class.ID)
+	expectStatus(t, do(t, r, http.MethodPut, path, superToken, map[string]any{"name": "Réseaux", "description": "Bases.", "active": false}), http.StatusOK)
+
+	enrollment, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: f.outsider.ID, CourseID: f.courseID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.MarkEnrollmentCompleted(ctx, enrollment.ID); err != nil {
+		t.Fatal(err)
+	}
+	awarder := badges.NewAwarder(store, testBadgeKey(t))
+	if err := awarder.AwardClass(ctx, class.ID); err != nil {
+		t.Fatal(err)
+	}
+	if items, _ := store.ListUserBadgeCredentials(ctx, f.outsider.ID); len(items) != 0 {
+		t.Fatalf("badge désactivé décerné : %+v", items)
+	}
+
+	// Réactivé, le badge rattrape ceux qui ont terminé le cours entre-temps.
+	expectStatus(t, do(t, r, http.MethodPut, path, superToken, map[string]any{"name": "Réseaux", "description": "Bases.", "active": true}), http.StatusOK)
+	if err := awarder.AwardClass(ctx, class.ID); err != nil {
+		t.Fatal(err)
+	}
+	if items, _ := store.ListUserBadgeCredentials(ctx, f.outsider.ID); len(items) != 1 {
+		t.Fatalf("badge réactivé non décerné : %+v", items)
+	}
+	w = do(t, r, http.MethodGet, "/organization/badge-classes", superToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	expectStatus(t, doIn(t, r, "inconnue", http.MethodGet, "/organization/badge-classes", superToken, nil), http.StatusNotFound)
+}
+
+func TestBadgeVerificationAfterKeyRotation(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	superToken := tokenFor(t, f.admin.ID, "admin")
+
+	// Badge délivré avant la rotation, signé par la clé désormais retirée.
+	enrollment, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: f.outsider.ID, CourseID: f.courseID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.MarkEnrollmentCompleted(ctx, enrollment.ID); err != nil {
+		t.Fatal(err)
+	}
+	w := do(t, r, http.MethodPost, "/organization/badge-classes", superToken, map[string]any{"course_id": f.courseID, "name": "Réseaux", "description": "Bases."})
+	expectStatus(t, w, http.StatusCreated)
+	var class struct {
+		ID int32 `json:"id"`
+	}
+	decode(t, w, &class)
+	retired := testRetiredBadgeKey(t)
+	if err := badges.NewAwarder(store, retired).AwardClass(ctx, class.ID); err != nil {
+		t.Fatal(err)
+	}
+	items, err := store.ListUserBadgeCredentials(ctx, f.outsider.ID)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("badges : %+v, %v", items, err)
+	}
+
+	var verified struct {
+		Valid bool `json:"valid"`
+	}
+	w = do(t, r, http.MethodGet, "/badges/credentials/"+items[0].ID+"/verify", "", nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &verified)
+	if !verified.Valid {
+		t.Fatalf("badge signé par une clé retirée refusé : %s", w.Body.String())
+	}
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	w = do(t, r, http.MethodGet, "/badges/keys", "", nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &jwks)
+	if len(jwks.Keys) != 2 || jwks.Keys[0]["kid"] != testBadgeKey(t).ID || jwks.Keys[1]["kid"] != retired.ID {
+		t.Fatalf("JWKS : %+v", jwks.Keys)
+	}
+	expectStatus(t, do(t, r, http.MethodGet, "/badges/keys/"+retired.ID, "", nil), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodGet, "/badges/keys/inconnue", "", nil), http.StatusNotFound)
+}
@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/certificates"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+func certificateStatus(cert db.Certificate) string {
+	if cert.RevokedAt.Valid {
+		return "revoked"
+	}
+	if cert.Status == certificates.StatusIssued {
+		return "valid"
+	}
+	return "pending"
+}
+
+func toCertificateResponse(cert db.Certificate) gin.H {
+	response := gin.H{
+		"id":              cert.ID,
+		"user_id":         cert.UserID,
+		"course_id":       cert.CourseID,
+		"recipient_name":  cert.RecipientName,
+		"course_title":    cert.CourseTitle,
+		"instructor_name": cert.InstructorName,
+		"completed_at":    cert.CompletedAt.Format(time.RFC3339),
+		"status":          certificateStatus(cert),
+		"verify_url":      certificates.VerifyURL(cert.ID),
+		"issued_at":       nil,
+		"revoked_at":      nil,
+	}
+	if cert.IssuedAt.Valid {
+		response["issued_at"] = cert.IssuedAt.Time.Format(time.RFC3339)
+	}
+	if cert.RevokedAt.Valid {
+		response["revoked_at"] = cert.RevokedAt.Time.Format(time.RFC3339)
+		response["revocation_reason"] = cert.RevocationReason.String
+	}
+	return response
+}
+
+func loadCertificate(c *gin.Context, ctx context.Context, queries db.Querier) (db.Certificate, bool) {
+	cert, err := queries.GetCertificate(ctx, certificates.NormalizeID(c.Param("id")))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Certificat introuvable"})
+		return cert, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return cert, false
+	}
+	return cert, true
+}
+
+// VerifyCertificateHandler : vérification publique, cible du QR code imprimé sur le certificat.
+// L'empreinte permet de contrôler qu'un PDF reçu n'a pas été retouché ; le manifeste signé qui
+// l'atteste se vérifie aussi hors ligne, avec les clés publiées sous /badges/keys.
+func VerifyCertificateHandler(queries repository.Store, keys *badges.Keyring) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cert, ok := loadCertificate(c, ctx, queries)
+		if !ok {
+			return
+		}
+		response := gin.H{
+			"id":              cert.ID,
+			"status":          certificateStatus(cert),
+			"valid":           certificateStatus(cert) == "valid",
+			"recipient_name":  cert.RecipientName,
+			"course_title":    cert.CourseTitle,
+			"instructor_name": cert.InstructorName,
+			"completed_at":    cert.CompletedAt.Format("2006-01-02"),
+			"pdf_sha256":      nil,
+			"manifest":        nil,
+		}
+		if cert.PdfSha256.Valid {
+			response["pdf_sha256"] = cert.PdfSha256.String
+		}
+		// Les certificats délivrés avant les manifestes n'en ont pas et restent valides.
+		if cert.Manifest.Valid {
+			response["manifest"], response["key_id"] = cert.Manifest.String, cert.KeyID.String
+			if err := certificates.VerifyManifest(keys, cert); err != nil {
+				response["status"], response["valid"] = "invalid", false
+				response["manifest_error"] = err.Error()
+			}
+		}
+		if cert.RevokedAt.Valid {
+			response["revoked_at"] = cert.RevokedAt.Time.Format(time.RFC3339)
+			response["revocation_reason"] = cert.RevocationReason.String
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DownloadCertificateHandler sert le PDF : public, comme la vérification, pour que le lien puisse
+// être partagé. Un certificat révoqué n'est plus téléchargeable.
+func DownloadCertificateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		cert, ok := loadCertificate(c, ctx, queries)
+		if !ok {
+			return
+		}
+		switch certificateStatus(cert) {
+		case "revoked":
+			c.JSON(http.StatusGone, gin.H{"error": "Ce certificat a été révoqué"})
+			return
+		case "pending":
+			c.Header("Retry-After", "5")
+			c.JSON(http.StatusAccepted, gin.H{"status": "pending", "message": "Le certificat est en cours de génération"})
+			return
+		}
+		content, err := queries.GetCertificateFile(ctx, cert.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="certificat-%s.pdf"`, cert.ID))
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "application/pdf", content)
+	}
+}
+
+func ListMyCertificatesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		items, err := queries.ListUserCertificates(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, cert := range items {
+			response = append(response, toCertificateResponse(cert))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func ListCourseCertificatesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permViewRoster); !ok {
+			return
+		}
+		items, err := queries.ListCourseCertificates(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, cert := range items {
+			response = append(response, toCertificateResponse(cert))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// RevokeCertificateHandler : réservé à l'équipe qui édite le cours et aux admins. La révocation
+// est définitive et visible sur la page de vérification, avec son motif.
+func RevokeCertificateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Reason string `json:"reason" binding:"required,max=500"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cert, ok := loadCertificate(c, ctx, queries)
+		if !ok {
+			return
+		}
+		if _, ok := loadCourseWithPermission(c, ctx, queries, cert.CourseID, permEditContent); !ok {
+			return
+		}
+		if cert.RevokedAt.Valid {
+			c.JSON(http.StatusConflict, gin.H{"error": "Ce certificat est déjà révoqué"})
+			return
+		}
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			revoked, err := qtx.RevokeCertificate(ctx, db.RevokeCertificateParams{
+				ID:               cert.ID,
+				RevokedBy:        sql.NullInt32{Int32: currentUserID(c), Valid: true},
+				RevocationReason: sql.NullString{String: strings.TrimSpace(req.Reason), Valid: true},
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Ce certificat est déjà révoqué"})
+				return errResponded
+			}
+			if err != nil {
+				return err
+			}
+			cert = revoked
+			return notifications.Notify(ctx, qtx, []int32{cert.UserID}, notifications.Notification{
+				Type:  notifications.CertificateRevoked,
+				Title: fmt.Sprintf("Votre certificat pour « %s » a été révoqué", cert.CourseTitle),
+				Body:  cert.RevocationReason.String,
+				Link:  "/certificates/" + cert.ID,
+				Data:  map[string]any{"certificate_id": cert.ID, "course_id": cert.CourseID},
+			})
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toCertificateResponse(cert))
+	}
+}
+
+type certificateTemplateRequest struct {
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	SignatoryName  string `json:"signatory_name" binding:"max=120"`
+	SignatoryTitle string `json:"signatory_title" binding:"max=120"`
+	AccentColor    string `json:"accent_color"`
+}
+
+func (r certificateTemplateRequest) template() certificates.Template {
+	return certificates.Template{
+		Title:          strings.TrimSpace(r.Title),
+		Body:           r.Body,
+		SignatoryName:  strings.TrimSpace(r.SignatoryName),
+		SignatoryTitle: strings.TrimSpace(r.SignatoryTitle),
+		AccentColor:    r.AccentColor,
+	}
+}
+
+func toCertificateTemplateResponse(tpl certificates.Template, custom bool) gin.H {
+	return gin.H{
+		"title":           tpl.Title,
+		"body":            tpl.Body,
+		"signatory_name":  tpl.SignatoryName,
+		"signatory_title": tpl.SignatoryTitle,
+		"accent_color":    tpl.AccentColor,
+		"custom":          custom,
+		"fields":          []string{"{{.Name}}", "{{.Course}}", "{{.Date}}", "{{.Instructor}}", "{{.ID}}"},
+	}
+}
+
+func GetCertificateTemplateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		row, err := queries.GetCertificateTemplate(ctx, courseID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusOK, toCertificateTemplateResponse(certificates.DefaultTemplate, false))
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toCertificateTemplateResponse(certificates.TemplateFromDB(row), true))
+	}
+}
+
+// SaveCertificateTemplateHandler enregistre le modèle du cours. Il s'applique aux certificats
+// générés ensuite ; ceux déjà délivrés gardent leur PDF et leur empreinte.
+func SaveCertificateTemplateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req certificateTemplateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		tpl := req.template()
+		if err := tpl.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		row, err := queries.UpsertCertificateTemplate(ctx, db.UpsertCertificateTemplateParams{
+			CourseID:       courseID,
+			Title:          tpl.Title,
+			Body:           tpl.Body,
+			SignatoryName:  sql.NullString{String: tpl.SignatoryName, Valid: tpl.SignatoryName != ""},
+			SignatoryTitle: sql.NullString{String: tpl.SignatoryTitle, Valid: tpl.SignatoryTitle != ""},
+			AccentColor:    sql.NullString{String: tpl.AccentColor, Valid: tpl.AccentColor != ""},
+			UpdatedBy:      sql.NullInt32{Int32: currentUserID(c), Valid: true},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toCertificateTemplateResponse(certificates.TemplateFromDB(row), true))
+	}
+}
+
+// ResetCertificateTemplateHandler revient au modèle par défaut.
+func ResetCertificateTemplateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		if _, err := queries.DeleteCertificateTemplate(ctx, courseID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// PreviewCertificateTemplateHandler rend, sans l'enregistrer, le modèle envoyé avec des données
+// d'exemple ; c'est le seul rendu PDF fait pendant une requête.
+func PreviewCertificateTemplateHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req certificateTemplateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		tpl := req.template()
+		if err := tpl.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent)
+		if !ok {
+			return
+		}
+		data := certificates.SampleData
+		data.Course = course.Title
+		content, err := certificates.Render(tpl, data, certificates.VerifyURL(data.ID))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", `inline; filename="apercu-certificat.pdf"`)
+		c.Data(http.StatusOK, "application/pdf", content)
+	}
+}
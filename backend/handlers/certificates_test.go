@@ -0,0 +1,181 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/certificates"
+	"online-learning-platform-backend/internal/notifications"
+)
+
+func TestCertificateIssueVerifyAndRevoke(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	student := f.outsider
+
+	cert, created, err := certificates.Issue(ctx, store, student.ID, f.courseID, time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC))
+	if err != nil || !created {
+		t.Fatalf("Issue : created=%v, %v", created, err)
+	}
+	if cert.InstructorName != f.owner.Name || cert.CourseTitle != "Réseaux" {
+		t.Fatalf("certificat inattendu : %+v", cert)
+	}
+	if _, created, _ := certificates.Issue(ctx, store, student.ID, f.courseID, time.Now()); created {
+		t.Fatal("un deuxième certificat a été créé pour le même cours")
+	}
+	if jobs := store.Jobs(certificates.JobGenerate); len(jobs) != 1 {
+		t.Fatalf("%d générations enfilées, attendu 1", len(jobs))
+	}
+
+	verifyPath := fmt.Sprintf("/certificates/%s/verify", cert.ID)
+	pdfPath := fmt.Sprintf("/certificates/%s/pdf", cert.ID)
+	expectStatus(t, do(t, r, http.MethodGet, pdfPath, "", nil), http.StatusAccepted)
+
+	if err := certificates.NewGenerator(store, testBadgeKey(t)).Generate(ctx, cert.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.Notifications(student.ID); len(got) != 1 || got[0].Type != notifications.CertificateIssued {
+		t.Fatalf("notifications de l'étudiant : %+v", got)
+	}
+	w := do(t, r, http.MethodGet, pdfPath, "", nil)
+	expectStatus(t, w, http.StatusOK)
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF")) {
+		t.Fatalf("le téléchargement n'est pas un PDF : %q", w.Body.String()[:20])
+	}
+	sum := sha256.Sum256(w.Body.Bytes())
+
+	// Vérification publique, identifiant saisi en minuscules.
+	w = do(t, r, http.MethodGet, fmt.Sprintf("/certificates/%s/verify", bytes.ToLower([]byte(cert.ID))), "", nil)
+	expectStatus(t, w, http.StatusOK)
+	var verified struct {
+		Status    string `json:"status"`
+		Valid     bool   `json:"valid"`
+		Recipient string `json:"recipient_name"`
+		PdfSha256 string `json:"pdf_sha256"`
+		Manifest  string `json:"manifest"`
+		KeyID     string `json:"key_id"`
+	}
+	decode(t, w, &verified)
+	if !verified.Valid || verified.Recipient != student.Name || verified.PdfSha256 != hex.EncodeToString(sum[:]) {
+		t.Fatalf("vérification inattendue : %+v", verified)
+	}
+	// Le manifeste signé atteste l'empreinte du PDF, vérifiable avec les clés publiées.
+	claims, err := badges.VerifyClaims(testBadgeKeys(t), verified.KeyID, verified.Manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attested, _ := claims["certificate"].(map[string]any); attested["pdf_sha256"] != verified.PdfSha256 {
+		t.Fatalf("manifeste inattendu : %+v", claims)
+	}
+	issued, err := store.GetCertificate(ctx, cert.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued.PdfSha256.String = hex.EncodeToString(make([]byte, 32))
+	if err := certificates.VerifyManifest(testBadgeKeys(t), issued); !errors.Is(err, certificates.ErrManifestMismatch) {
+		t.Fatalf("PDF retouché accepté : %v", err)
+	}
+	expectStatus(t, do(t, r, http.MethodGet, "/certificates/AAAA-BBBB-CCCC/verify", "", nil), http.StatusNotFound)
+
+	// Révocation : pas par le TA, motif obligatoire, une seule fois.
+	revokePath := fmt.Sprintf("/certificates/%s/revoke", cert.ID)
+	reason := map[string]string{"reason": "Plagiat avéré"}
+	expectStatus(t, do(t, r, http.MethodPost, revokePath, tokenFor(t, f.ta.ID, "student"), reason), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodPost, revokePath, tokenFor(t, f.owner.ID, "teacher"), map[string]string{}), http.StatusBadRequest)
+	expectStatus(t, do(t, r, http.MethodPost, revokePath, tokenFor(t, f.owner.ID, "teacher"), reason), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodPost, revokePath, tokenFor(t, f.owner.ID, "teacher"), reason), http.StatusConflict)
+
+	w = do(t, r, http.MethodGet, verifyPath, "", nil)
+	decode(t, w, &verified)
+	if verified.Valid || verified.Status != "revoked" {
+		t.Fatalf("certificat révoqué encore valide : %+v", verified)
+	}
+	expectStatus(t, do(t, r, http.MethodGet, pdfPath, "", nil), http.StatusGone)
+
+	// Le TA voit la liste du cours, l'étudiant la sienne.
+	w = do(t, r, http.MethodGet, fmt.Sprintf("/courses/%d/certificates", f.courseID), tokenFor(t, f.ta.ID, "student"), nil)
+	expectStatus(t, w, http.StatusOK)
+	w = do(t, r, http.MethodGet, "/me/certificates", tokenFor(t, student.ID, "student"), nil)
+	expectStatus(t, w, http.StatusOK)
+	var mine []map[string]any
+	decode(t, w, &mine)
+	if len(mine) != 1 || mine[0]["status"] != "revoked" {
+		t.Fatalf("certificats de l'étudiant : %v", mine)
+	}
+}
+
+func TestCertificateTemplate(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	path := fmt.Sprintf("/courses/%d/certificate-template", f.courseID)
+	ownerToken := tokenFor(t, f.owner.ID, "teacher")
+
+	w := do(t, r, http.MethodGet, path, ownerToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var tpl struct {
+		Title  string `json:"title"`
+		Custom bool   `json:"custom"`
+	}
+	decode(t, w, &tpl)
+	if tpl.Custom || tpl.Title != certificates.DefaultTemplate.Title {
+		t.Fatalf("modèle par défaut inattendu : %+v", tpl)
+	}
+
+	custom := map[string]string{
+		"title":        "Attestation de formation",
+		"body":         "a terminé « {{.Course}} » le {{.Date}}.",
+		"accent_color": "#8b0000",
+	}
+	expectStatus(t, do(t, r, http.MethodPut, path, tokenFor(t, f.ta.ID, "student"), custom), http.StatusForbidden)
+	for _, bad := range []map[string]string{
+		{"title": "x", "body": "{{.Inconnu}}"},
+		{"title": "x", "body": "{{.Course"},
+		{"title": "x", "body": "ok", "accent_color": "rouge"},
+		{"title": "", "body": "ok"},
+	} {
+		expectStatus(t, do(t, r, http.MethodPut, path, ownerToken, bad), http.StatusBadRequest)
+	}
+	expectStatus(t, do(t, r, http.MethodPut, path, ownerToken, custom), http.StatusOK)
+
+	w = do(t, r, http.MethodPost, path+"/preview", ownerToken, custom)
+	expectStatus(t, w, http.StatusOK)
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF")) {
+		t.Fatal("l'aperçu n'est pas un PDF")
+	}
+
+	// Le modèle du cours s'applique aux certificats générés ensuite.
+	ctx := context.Background()
+	cert, _, err := certificates.Issue(ctx, store, f.outsider.ID, f.courseID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := certificates.NewGenerator(store, testBadgeKey(t)).Generate(ctx, cert.ID); err != nil {
+		t.Fatal(err)
+	}
+	content, err := store.GetCertificateFile(ctx, cert.ID)
+	// Le contenu des pages est compressé : le titre se lit dans les métadonnées (UTF-16BE).
+	var title []byte
+	for _, r := range utf16.Encode([]rune(custom["title"])) {
+		title = append(title, byte(r>>8), byte(r))
+	}
+	if err != nil || !bytes.Contains(content, title) {
+		t.Fatalf("le PDF n'utilise pas le modèle du cours (%v)", err)
+	}
+
+	expectStatus(t, do(t, r, http.MethodDelete, path, ownerToken, nil), http.StatusNoContent)
+	w = do(t, r, http.MethodGet, path, ownerToken, nil)
+	decode(t, w, &tpl)
+	if tpl.Custom {
+		t.Fatal("le modèle n'a pas été réinitialisé")
+	}
+}
@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/metrics"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const dateLayout = "2006-01-02"
+
+// Alphabet sans caractères ambigus (0/O, 1/I) pour les codes saisis à la main.
+const enrollmentCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type CohortResponse struct {
+	ID             int32  `json:"id"`
+	CourseID       int32  `json:"course_id"`
+	Name           string `json:"name"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	InstructorID   *int32 `json:"instructor_id"`
+	EnrollmentCode string `json:"enrollment_code"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type cohortRequest struct {
+	Name         string `json:"name" binding:"required"`
+	StartDate    string `json:"start_date" binding:"required"`
+	EndDate      string `json:"end_date" binding:"required"`
+	InstructorID *int32 `json:"instructor_id"`
+}
+
+func toCohortResponse(cohort db.Cohort) CohortResponse {
+	var instructorID *int32
+	if cohort.InstructorID.Valid {
+		instructorID = &cohort.InstructorID.Int32
+	}
+	return CohortResponse{
+		ID:             cohort.ID,
+		CourseID:       cohort.CourseID,
+		Name:           cohort.Name,
+		StartDate:      cohort.StartDate.Format(dateLayout),
+		EndDate:        cohort.EndDate.Format(dateLayout),
+		InstructorID:   instructorID,
+		EnrollmentCode: cohort.EnrollmentCode,
+		CreatedAt:      cohort.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseSchedule valide les dates de début et de fin d'une cohorte.
+func parseSchedule(req cohortRequest) (time.Time, time.Time, error) {
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("start_date doit être au format AAAA-MM-JJ")
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("end_date doit être au format AAAA-MM-JJ")
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("end_date doit être postérieure à start_date")
+	}
+	return start, end, nil
+}
+
+func generateEnrollmentCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = enrollmentCodeAlphabet[int(b)%len(enrollmentCodeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+func nullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
+
+func CreateCohortHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req cohortRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		start, end, err := parseSchedule(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageCohorts); !ok {
+			return
+		}
+		code, err := generateEnrollmentCode()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors de la génération du code d'inscription"})
+			return
+		}
+		cohort, err := queries.CreateCohort(ctx, db.CreateCohortParams{
+			CourseID:       courseID,
+			Name:           req.Name,
+			StartDate:      start,
+			EndDate:        end,
+			InstructorID:   nullInt32(req.InstructorID),
+			EnrollmentCode: code,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, toCohortResponse(cohort))
+	}
+}
+
+func ListCohortsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageCohorts); !ok {
+			return
+		}
+		cohorts, err := queries.ListCohortsByCourse(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []CohortResponse{}
+		for _, cohort := range cohorts {
+			response = append(response, toCohortResponse(cohort))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func UpdateCohortHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cohortID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cohorte invalide"})
+			return
+		}
+		var req cohortRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		start, end, err := parseSchedule(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cohort, ok := loadCohort(c, ctx, queries, cohortID)
+		if !ok {
+			return
+		}
+		if _, ok := loadCourseWithPermission(c, ctx, queries, cohort.CourseID, permManageCohorts); !ok {
+			return
+		}
+		cohort, err = queries.UpdateCohort(ctx, db.UpdateCohortParams{
+			ID:           cohortID,
+			Name:         req.Name,
+			StartDate:    start,
+			EndDate:      end,
+			InstructorID: nullInt32(req.InstructorID),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toCohortResponse(cohort))
+	}
+}
+
+func loadCohort(c *gin.Context, ctx context.Context, queries db.Querier, cohortID int32) (db.Cohort, bool) {
+	cohort, err := queries.GetCohort(ctx, cohortID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, cohort.OrganizationID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Cohorte introuvable"})
+		return cohort, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return cohort, false
+	}
+	return cohort, true
+}
+
+// GetCohortRosterHandler liste les inscrits ; accessible aussi à l'encadrant de la cohorte.
+func GetCohortRosterHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cohortID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cohorte invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cohort, ok := loadCohort(c, ctx, queries, cohortID)
+		if !ok {
+			return
+		}
+		isInstructor := cohort.InstructorID.Valid && cohort.InstructorID.Int32 == currentUserID(c)
+		if !isInstructor {
+			if _, ok := loadCourseWithPermission(c, ctx, queries, cohort.CourseID, permViewRoster); !ok {
+				return
+			}
+		}
+		roster, err := queries.ListCohortRoster(ctx, sql.NullInt32{Int32: cohortID, Valid: true})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if roster == nil {
+			roster = []db.ListCohortRosterRow{}
+		}
+		c.JSON(http.StatusOK, roster)
+	}
+}
+
+func RemoveCohortMemberHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cohortID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cohorte invalide"})
+			return
+		}
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cohort, ok := loadCohort(c, ctx, queries, cohortID)
+		if !ok {
+			return
+		}
+		if _, ok := loadCourseWithPermission(c, ctx, queries, cohort.CourseID, permManageCohorts); !ok {
+			return
+		}
+		removed, err := queries.RemoveFromCohort(ctx, db.RemoveFromCohortParams{
+			CohortID: sql.NullInt32{Int32: cohortID, Valid: true},
+			UserID:   userID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if removed == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cet utilisateur n'est pas inscrit dans la cohorte"})
+			return
+		}
+		publishEnrollmentChange(ctx, queries, cohort, userID, events.EnrollmentRemoved)
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// publishEnrollmentChange prévient l'étudiant et le formateur de la cohorte. L'inscription est déjà
+// enregistrée : un échec de diffusion est journalisé sans faire échouer la requête.
+func publishEnrollmentChange(ctx context.Context, queries db.Querier, cohort db.Cohort, userID int32, eventType string) {
+	recipients := []int32{userID}
+	if cohort.InstructorID.Valid && cohort.InstructorID.Int32 != userID {
+		recipients = append(recipients, cohort.InstructorID.Int32)
+	}
+	err := events.Publish(ctx, queries, recipients, eventType, gin.H{
+		"course_id": cohort.CourseID,
+		"cohort_id": cohort.ID,
+		"user_id":   userID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "events: inscription", "cohort_id", cohort.ID, "user_id", userID, "error", err)
+	}
+}
+
+// JoinCohortHandler inscrit l'utilisateur courant via le code de la cohorte.
+func JoinCohortHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Code string `json:"code" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		cohort, err := queries.GetCohortByCode(ctx, db.GetCohortByCodeParams{
+			EnrollmentCode: strings.ToUpper(strings.TrimSpace(req.Code)),
+			OrganizationID: currentOrganization(c).ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Code d'inscription invalide"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if cohort.EndDate.Before(time.Now().Truncate(24 * time.Hour)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Cette cohorte est terminée"})
+			return
+		}
+		// Le code d'inscription vaut invitation : l'inscrit devient membre de l'établissement.
+		var enrollment db.Enrollment
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			enrollment, err = qtx.EnrollInCohort(ctx, db.EnrollInCohortParams{
+				UserID:   currentUserID(c),
+				CourseID: cohort.CourseID,
+				CohortID: sql.NullInt32{Int32: cohort.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			return qtx.EnsureOrganizationMember(ctx, db.EnsureOrganizationMemberParams{OrganizationID: cohort.OrganizationID, UserID: enrollment.UserID})
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		metrics.Enrollments.Inc()
+		publishEnrollmentChange(ctx, queries, cohort, enrollment.UserID, events.EnrollmentCreated)
+		c.JSON(http.StatusCreated, enrollment)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
 	"database/sql"
 	"context"
-	"fmt"
-	"strings"
+	"errors"
+	"log/slog"
+	"math"
 	"time"
 )
 
@@ -18,77 +20,114 @@ type CourseResponse struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	AuthorID  *int32 `json:"author_id"`
+	Version   int32  `json:"version"`
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int32   `json:"rating_count"`
 }
 
-func ListCoursesHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
+func toCourseResponse(course db.Course) CourseResponse {
+	desc := ""
+	if course.Description.Valid {
+		desc = course.Description.String
+	}
+
+	var authorID *int32
+	if course.AuthorID.Valid {
+		authorID = &course.AuthorID.Int32
+	}
+
+	// rating_sum et rating_count sont tenus à jour par un trigger sur course_reviews.
+	var average float64
+	if course.RatingCount > 0 {
+		average = math.Round(float64(course.RatingSum)/float64(course.RatingCount)*100) / 100
+	}
+
+	return CourseResponse{
+		ID:        course.ID,
+		Title:     course.Title,
+		Description: desc,
+		CreatedAt: course.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: course.UpdatedAt.Format(time.RFC3339),
+		AuthorID:  authorID,
+		Version:   course.Version,
+		RatingAverage: average,
+		RatingCount:   course.RatingCount,
+	}
+}
+
+func ListCoursesHandler(queries repository.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		fmt.Println("[DEBUG] Début ListCoursesHandler")
-		
-		// Vérifier la connexion DB
-		ctx := context.Background()
-		fmt.Println("[DEBUG] Contexte:", ctx)
-		fmt.Println("[DEBUG] Queries:", queries)
-		fmt.Println("[DEBUG] DB Conn:", dbConn)
-		err := dbConn.PingContext(ctx)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+		// Le catalogue change rarement : on évite de le recharger si le client a déjà la bonne version.
+		// Catalogue de l'établissement de la requête uniquement.
+		org := currentOrganization(c)
+		catalog, err := queries.GetCatalogVersion(ctx, org.ID)
 		if err != nil {
-			fmt.Printf("[ERROR] Erreur connexion DB: %v\n", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Database connection failed"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if notModified(c, catalogETag(org.ID, catalog.Total, catalog.VersionSum, catalog.MaxID, catalog.RatingsStamp)) {
 			return
 		}
-		fmt.Println("[DEBUG] Connexion DB OK")
 		
-		// Exécuter la requête
-		fmt.Println("[DEBUG] Exécution de ListCourses")
-		courses, err := queries.ListCourses(ctx)
+		courses, err := queries.ListCourses(ctx, org.ID)
 		if err != nil {
-			fmt.Printf("[ERROR] Détails erreur ListCourses: %+v\n", err)
-			fmt.Printf("[DEBUG] Type erreur: %T\n", err)
-			fmt.Printf("[ERROR] Erreur ListCourses: %v\n", err)
+			slog.ErrorContext(ctx, "liste des cours", "error", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		
-		fmt.Printf("[DEBUG] %d cours récupérés\n", len(courses))
+		slog.DebugContext(ctx, "catalogue chargé", "courses", len(courses))
 		
 		// Transformer les cours pour le frontend
 		var response []CourseResponse
 		for _, course := range courses {
-			desc := ""
-			if course.Description.Valid {
-				desc = course.Description.String
-			}
+			response = append(response, toCourseResponse(course))
+		}
 
-			var authorID *int32
-			if course.AuthorID.Valid {
-				authorID = &course.AuthorID.Int32
-			}
+		c.JSON(http.StatusOK, response)
+	}
+}
 
-			response = append(response, CourseResponse{
-				ID:        course.ID,
-				Title:     course.Title,
-				Description: desc,
-				CreatedAt: course.CreatedAt.Format(time.RFC3339),
-				UpdatedAt: course.UpdatedAt.Format(time.RFC3339),
-				AuthorID:  authorID,
-			})
+func GetCourseHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
 		}
 
-		c.JSON(http.StatusOK, response)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, err := queries.GetCourse(ctx, courseID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cours introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if notModified(c, courseETag(course.ID, course.Version)) {
+			return
+		}
+		c.JSON(http.StatusOK, toCourseResponse(course))
 	}
 }
 
-func CreateCourseHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
+func CreateCourseHandler(queries repository.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role := c.GetString("role")
-		userIDRaw, _ := c.Get("user_id")
-		var userID int
-		if f, ok := userIDRaw.(float64); ok {
-			userID = int(f)
+		userID := currentUserID(c)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+		allowed, err := canAuthorInOrganization(c, ctx, queries)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
 		}
-		roleNormalized := strings.TrimSpace(strings.ToLower(role))
-		if roleNormalized != "formateur" && roleNormalized != "admin" {
-			fmt.Printf("[DEBUG] Refus création cours: userID=%v, role reçu='%v' (normalisé='%v')\n", userID, role, roleNormalized)
-			c.JSON(http.StatusForbidden, gin.H{"error": "Seuls les formateurs ou admins peuvent créer un cours. (role reçu: " + role + ")"})
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Seuls les enseignants ou admins de l'établissement peuvent créer un cours"})
 			return
 		}
 		var req struct {
@@ -99,18 +138,24 @@ func CreateCourseHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		ctx := context.Background()
-		fmt.Println("[DEBUG] Contexte:", ctx)
-		fmt.Println("[DEBUG] Queries:", queries)
-		fmt.Println("[DEBUG] DB Conn:", dbConn)
-		course, err := queries.CreateCourse(ctx, db.CreateCourseParams{
-			Title:       req.Title,
-			Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
-			AuthorID:    sql.NullInt32{Int32: int32(userID), Valid: true},
+		var course db.Course
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			course, err = qtx.CreateCourse(ctx, db.CreateCourseParams{
+				Title:       req.Title,
+				Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+				AuthorID:    sql.NullInt32{Int32: userID, Valid: true},
+				OrganizationID: currentOrganization(c).ID,
+			})
+			if err != nil {
+				return err
+			}
+			// L'auteur devient propriétaire dans l'équipe pédagogique du cours.
+			_, err = qtx.AddCourseStaff(ctx, db.AddCourseStaffParams{CourseID: course.ID, UserID: userID, Role: StaffOwner})
+			return err
 		})
 		if err != nil {
-			fmt.Printf("[ERROR] Détails erreur CreateCourse: %+v\n", err)
-			fmt.Printf("[DEBUG] Type erreur: %T\n", err)
+			slog.ErrorContext(ctx, "création du cours", "error", err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
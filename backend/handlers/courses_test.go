@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+func TestCreateCourse(t *testing.T) {
+	r, store := newTestRouter(t)
+	teacher := seedUser(t, store, "teacher@example.com", "secret123", "teacher")
+
+	w := do(t, r, http.MethodPost, "/courses", tokenFor(t, teacher.ID, "teacher"), map[string]string{
+		"title":       "Go avancé",
+		"description": "Concurrence et profilage",
+	})
+	expectStatus(t, w, http.StatusCreated)
+
+	var course db.Course
+	decode(t, w, &course)
+	if course.Title != "Go avancé" || !course.AuthorID.Valid || course.AuthorID.Int32 != teacher.ID {
+		t.Fatalf("cours inattendu: %+v", course)
+	}
+	// L'auteur devient propriétaire du cours.
+	role, err := store.GetCourseStaffRole(context.Background(), db.GetCourseStaffRoleParams{CourseID: course.ID, UserID: teacher.ID})
+	if err != nil || role != "owner" {
+		t.Fatalf("rôle de l'auteur %q (%v), attendu owner", role, err)
+	}
+}
+
+func TestCreateCourseAuthorization(t *testing.T) {
+	r, store := newTestRouter(t)
+	student := seedUser(t, store, "student@example.com", "secret123", "student")
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+	body := map[string]string{"title": "Algorithmique"}
+
+	tests := []struct {
+		name  string
+		token string
+		body  any
+		want  int
+	}{
+		{"sans token", "", body, http.StatusUnauthorized},
+		{"token invalide", "not-a-jwt", body, http.StatusUnauthorized},
+		{"étudiant", tokenFor(t, student.ID, "student"), body, http.StatusForbidden},
+		{"admin", tokenFor(t, admin.ID, "admin"), body, http.StatusCreated},
+		{"titre manquant", tokenFor(t, admin.ID, "admin"), map[string]string{}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, r, http.MethodPost, "/courses", tt.token, tt.body)
+			expectStatus(t, w, tt.want)
+		})
+	}
+
+	courses, err := store.ListCourses(context.Background(), defaultOrgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(courses) != 1 {
+		t.Fatalf("%d cours créés, attendu 1", len(courses))
+	}
+}
+
+func TestListAndGetCourses(t *testing.T) {
+	r, store := newTestRouter(t)
+	teacher := seedUser(t, store, "teacher@example.com", "secret123", "teacher")
+	token := tokenFor(t, teacher.ID, "teacher")
+	for _, title := range []string{"Premier", "Second"} {
+		expectStatus(t, do(t, r, http.MethodPost, "/courses", token, map[string]string{"title": title}), http.StatusCreated)
+	}
+
+	w := do(t, r, http.MethodGet, "/courses", "", nil)
+	expectStatus(t, w, http.StatusOK)
+	var list []struct {
+		ID    int32  `json:"id"`
+		Title string `json:"title"`
+	}
+	decode(t, w, &list)
+	if len(list) != 2 || list[0].Title != "Second" {
+		t.Fatalf("catalogue inattendu: %+v", list)
+	}
+
+	// Le catalogue n'a pas changé : le client garde sa copie.
+	req := httptest.NewRequest(http.MethodGet, "/courses", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	cached := httptest.NewRecorder()
+	r.ServeHTTP(cached, req)
+	expectStatus(t, cached, http.StatusNotModified)
+
+	expectStatus(t, do(t, r, http.MethodGet, "/courses/"+strconv.Itoa(int(list[1].ID)), "", nil), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodGet, "/courses/999", "", nil), http.StatusNotFound)
+	expectStatus(t, do(t, r, http.MethodGet, "/courses/abc", "", nil), http.StatusBadRequest)
+}
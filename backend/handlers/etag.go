@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const staleResourceMessage = "La ressource a été modifiée entre-temps, rechargez-la avant de la modifier"
+
+func courseETag(courseID, version int32) string {
+	return fmt.Sprintf(`"course-%d-v%d"`, courseID, version)
+}
+
+func draftETag(courseID, version int32) string {
+	return fmt.Sprintf(`"draft-%d-v%d"`, courseID, version)
+}
+
+func catalogETag(orgID int32, total, versionSum int64, maxID int32, ratingsStamp int64) string {
+	return fmt.Sprintf(`"catalog-%d-%d-%d-%d-%d"`, orgID, total, versionSum, maxID, ratingsStamp)
+}
+
+func revisionETag(revisionID int32) string {
+	return fmt.Sprintf(`"revision-%d"`, revisionID)
+}
+
+// etagListContains cherche tag dans un en-tête If-Match / If-None-Match. Comparaison faible
+// (If-None-Match) : le préfixe W/ est ignoré. Comparaison forte (If-Match, RFC 9110 §13.1.1) :
+// un validateur faible ne correspond jamais.
+func etagListContains(header, tag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified pose l'ETag et répond 304 si le client a déjà cette version.
+func notModified(c *gin.Context, tag string) bool {
+	c.Header("ETag", tag)
+	if header := c.GetHeader("If-None-Match"); header != "" && etagListContains(header, tag, true) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// requireIfMatch exige un If-Match correspondant à la version courante : 428 s'il manque, 412 s'il est périmé.
+func requireIfMatch(c *gin.Context, tag string) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "En-tête If-Match requis"})
+		return false
+	}
+	if !etagListContains(header, tag, false) {
+		c.Header("ETag", tag)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": staleResourceMessage})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	streamKeepAlive   = 25 * time.Second
+	streamReplayBatch = 500
+)
+
+func writeUserEvent(w io.Writer, event db.UserEvent) {
+	// payload vient de JSONB : il tient sur une ligne, comme l'exige le format SSE.
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Payload)
+}
+
+// StreamEventsHandler ouvre un flux Server-Sent Events. Un client qui se reconnecte envoie
+// Last-Event-ID (ou ?last_event_id=) et reçoit d'abord les événements manqués.
+func StreamEventsHandler(queries repository.Store, hub *events.Hub) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := currentUserID(c)
+		rawLastID := c.GetHeader("Last-Event-ID")
+		if rawLastID == "" {
+			rawLastID = c.Query("last_event_id")
+		}
+		var lastID int64
+		if rawLastID != "" {
+			parsed, err := strconv.ParseInt(rawLastID, 10, 64)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID invalide"})
+				return
+			}
+			lastID = parsed
+		}
+
+		// L'abonnement précède le rejeu pour ne rien perdre entre les deux.
+		sub := hub.Subscribe(userID)
+		defer hub.Unsubscribe(sub)
+
+		var backlog []db.UserEvent
+		if lastID > 0 {
+			// Hors établissement : la connexion réservée de la requête le resterait tout le flux.
+			ctx, cancel := context.WithTimeout(repository.WithoutOrganization(c.Request.Context()), 5*time.Second)
+			defer cancel()
+			for {
+				batch, err := queries.ListUserEventsAfter(ctx, db.ListUserEventsAfterParams{UserID: userID, AfterID: lastID, Limit: streamReplayBatch})
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+				backlog = append(backlog, batch...)
+				if len(batch) < streamReplayBatch {
+					break
+				}
+				lastID = batch[len(batch)-1].ID
+			}
+		}
+		replayed := make(map[int64]bool, len(backlog))
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		// Le flux dure bien plus que le WriteTimeout du serveur : on lève l'échéance pour cette réponse.
+		http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+		fmt.Fprint(c.Writer, "retry: 3000\n\n")
+		for _, event := range backlog {
+			writeUserEvent(c.Writer, event)
+			replayed[event.ID] = true
+		}
+		c.Writer.Flush()
+
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case event, ok := <-sub.C:
+				if !ok {
+					return false
+				}
+				if !replayed[event.ID] {
+					writeUserEvent(w, event)
+				}
+				return true
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": ping\n\n")
+				return true
+			}
+		})
+	}
+}
+
+// ListMyEventsHandler permet de rattraper les événements sans flux, par exemple au chargement d'une page.
+func ListMyEventsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var after int64
+		if raw := c.Query("after"); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre after invalide"})
+				return
+			}
+			after = parsed
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		list, err := queries.ListUserEventsAfter(ctx, db.ListUserEventsAfterParams{UserID: currentUserID(c), AfterID: after, Limit: streamReplayBatch})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if list == nil {
+			list = []db.UserEvent{}
+		}
+		c.JSON(http.StatusOK, list)
+	}
+}
@@ -0,0 +1,723 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// forumAccess décrit ce que l'utilisateur courant peut faire dans le forum d'un cours.
+type forumAccess struct {
+	courseID  int32
+	staff     bool
+	moderator bool
+	// cohortID : 0 pour l'équipe (toutes les cohortes), -1 pour un inscrit sans cohorte.
+	cohortID int32
+}
+
+func (a forumAccess) canSee(thread db.ForumThread) bool {
+	if thread.Hidden && !a.moderator {
+		return false
+	}
+	if thread.CohortID.Valid && a.cohortID != 0 && thread.CohortID.Int32 != a.cohortID {
+		return false
+	}
+	return true
+}
+
+type ForumPostNode struct {
+	ID         int32            `json:"id"`
+	ParentID   *int32           `json:"parent_id"`
+	AuthorID   int32            `json:"author_id"`
+	AuthorName string           `json:"author_name"`
+	Body       string           `json:"body"`
+	BodyHTML   string           `json:"body_html"`
+	Hidden     bool             `json:"hidden"`
+	Deleted    bool             `json:"deleted"`
+	Upvotes    int64            `json:"upvotes"`
+	Voted      bool             `json:"voted"`
+	IsAnswer   bool             `json:"is_answer"`
+	CreatedAt  string           `json:"created_at"`
+	UpdatedAt  string           `json:"updated_at"`
+	Replies    []*ForumPostNode `json:"replies"`
+}
+
+// loadForumAccess : seuls les inscrits, l'équipe du cours et les admins accèdent au forum.
+func loadForumAccess(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32) (forumAccess, bool) {
+	member, ok := loadCourseMember(c, ctx, queries, courseID)
+	if !ok {
+		return forumAccess{}, false
+	}
+	return forumAccess{
+		courseID:  courseID,
+		staff:     member.staff,
+		moderator: member.admin || staffCan(member.staffRole, permModerateForum),
+		cohortID:  member.cohortID,
+	}, true
+}
+
+// requireCanPost refuse les utilisateurs bannis du forum ; l'équipe ne peut pas l'être.
+func requireCanPost(c *gin.Context, ctx context.Context, queries db.Querier, access forumAccess) bool {
+	if access.staff {
+		return true
+	}
+	banned, err := queries.IsBannedFromForum(ctx, db.IsBannedFromForumParams{CourseID: access.courseID, UserID: currentUserID(c)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if banned {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Vous avez été banni du forum de ce cours"})
+		return false
+	}
+	return true
+}
+
+func requireModerator(c *gin.Context, access forumAccess) bool {
+	if !access.moderator {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Réservé aux modérateurs du forum"})
+		return false
+	}
+	return true
+}
+
+// loadVisibleThread charge le fil de l'URL ; un fil invisible pour l'utilisateur est traité comme inexistant.
+func loadVisibleThread(c *gin.Context, ctx context.Context, queries db.Querier, threadID int32) (db.ForumThread, forumAccess, bool) {
+	thread, err := queries.GetForumThread(ctx, threadID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Discussion introuvable"})
+		return thread, forumAccess{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return thread, forumAccess{}, false
+	}
+	access, ok := loadForumAccess(c, ctx, queries, thread.CourseID)
+	if !ok {
+		return thread, access, false
+	}
+	if !access.canSee(thread) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Discussion introuvable"})
+		return thread, access, false
+	}
+	return thread, access, true
+}
+
+func loadVisiblePost(c *gin.Context, ctx context.Context, queries db.Querier) (db.ForumPost, db.ForumThread, forumAccess, bool) {
+	postID, ok := parseIDParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de message invalide"})
+		return db.ForumPost{}, db.ForumThread{}, forumAccess{}, false
+	}
+	post, err := queries.GetForumPost(ctx, postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Message introuvable"})
+		return post, db.ForumThread{}, forumAccess{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return post, db.ForumThread{}, forumAccess{}, false
+	}
+	thread, access, ok := loadVisibleThread(c, ctx, queries, post.ThreadID)
+	if !ok {
+		return post, thread, access, false
+	}
+	if post.Hidden && !access.moderator {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Message introuvable"})
+		return post, thread, access, false
+	}
+	return post, thread, access, true
+}
+
+func optionalIDQuery(c *gin.Context, name string) (int32, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id), true
+}
+
+func ListForumThreadsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		lessonID, ok := optionalIDQuery(c, "lesson_id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre lesson_id invalide"})
+			return
+		}
+		cohortFilter, ok := optionalIDQuery(c, "cohort_id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre cohort_id invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		access, ok := loadForumAccess(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		// Un étudiant ne voit que sa cohorte ; l'équipe peut filtrer sur une cohorte donnée.
+		cohortID := access.cohortID
+		if access.staff {
+			cohortID = cohortFilter
+		}
+		threads, err := queries.ListForumThreads(ctx, db.ListForumThreadsParams{
+			CourseID:      courseID,
+			LessonID:      lessonID,
+			CohortID:      cohortID,
+			IncludeHidden: access.moderator,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if threads == nil {
+			threads = []db.ListForumThreadsRow{}
+		}
+		c.JSON(http.StatusOK, threads)
+	}
+}
+
+func CreateForumThreadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			Title    string `json:"title" binding:"required,max=200"`
+			Body     string `json:"body" binding:"required,max=20000"`
+			LessonID *int32 `json:"lesson_id"`
+			CohortID *int32 `json:"cohort_id"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		access, ok := loadForumAccess(c, ctx, queries, courseID)
+		if !ok || !requireCanPost(c, ctx, queries, access) {
+			return
+		}
+		if req.LessonID != nil {
+			lesson, err := queries.GetLesson(ctx, *req.LessonID)
+			if err != nil || lesson.CourseID != courseID {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Leçon inconnue pour ce cours"})
+				return
+			}
+		}
+		cohortID := nullInt32(req.CohortID)
+		if !access.staff {
+			// Un étudiant poste toujours dans sa propre cohorte.
+			cohortID = sql.NullInt32{Int32: access.cohortID, Valid: access.cohortID > 0}
+		} else if cohortID.Valid {
+			cohort, err := queries.GetCohort(ctx, cohortID.Int32)
+			if err != nil || cohort.CourseID != courseID {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Cohorte inconnue pour ce cours"})
+				return
+			}
+		}
+		html, err := renderMarkdown(req.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Markdown invalide"})
+			return
+		}
+		thread, err := queries.CreateForumThread(ctx, db.CreateForumThreadParams{
+			CourseID: courseID,
+			LessonID: nullInt32(req.LessonID),
+			CohortID: cohortID,
+			AuthorID: currentUserID(c),
+			Title:    req.Title,
+			Body:     req.Body,
+			BodyHtml: html,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, thread)
+	}
+}
+
+// buildPostTree assemble les réponses imbriquées ; le contenu masqué n'est montré qu'aux modérateurs.
+func buildPostTree(rows []db.ListForumPostsRow, thread db.ForumThread, moderator bool) []*ForumPostNode {
+	nodes := make(map[int32]*ForumPostNode, len(rows))
+	roots := []*ForumPostNode{}
+	for _, row := range rows {
+		node := &ForumPostNode{
+			ID:         row.ID,
+			AuthorID:   row.AuthorID,
+			AuthorName: row.AuthorName,
+			Body:       row.Body,
+			BodyHTML:   row.BodyHtml,
+			Hidden:     row.Hidden,
+			Deleted:    row.DeletedAt.Valid,
+			Upvotes:    row.Upvotes,
+			Voted:      row.Voted,
+			IsAnswer:   thread.AcceptedPostID.Valid && thread.AcceptedPostID.Int32 == row.ID,
+			CreatedAt:  row.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  row.UpdatedAt.Format(time.RFC3339),
+			Replies:    []*ForumPostNode{},
+		}
+		if row.Hidden && !moderator {
+			node.Body, node.BodyHTML = "", ""
+		}
+		if row.ParentID.Valid {
+			node.ParentID = &row.ParentID.Int32
+		}
+		nodes[row.ID] = node
+	}
+	// Les lignes sont triées par date : un parent est toujours vu avant ses réponses.
+	for _, row := range rows {
+		node := nodes[row.ID]
+		if parent, ok := nodes[row.ParentID.Int32]; row.ParentID.Valid && ok {
+			parent.Replies = append(parent.Replies, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+func GetForumThreadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		threadID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de discussion invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		thread, access, ok := loadVisibleThread(c, ctx, queries, threadID)
+		if !ok {
+			return
+		}
+		rows, err := queries.ListForumPosts(ctx, db.ListForumPostsParams{ThreadID: threadID, UserID: currentUserID(c)})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"thread":    thread,
+			"posts":     buildPostTree(rows, thread, access.moderator),
+			"moderator": access.moderator,
+		})
+	}
+}
+
+func CreateForumPostHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		threadID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de discussion invalide"})
+			return
+		}
+		var req struct {
+			Body     string `json:"body" binding:"required,max=20000"`
+			ParentID *int32 `json:"parent_id"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		thread, access, ok := loadVisibleThread(c, ctx, queries, threadID)
+		if !ok || !requireCanPost(c, ctx, queries, access) {
+			return
+		}
+		if thread.Locked && !access.moderator {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Cette discussion est verrouillée"})
+			return
+		}
+		var parent db.ForumPost
+		if req.ParentID != nil {
+			var err error
+			parent, err = queries.GetForumPost(ctx, *req.ParentID)
+			if err != nil || parent.ThreadID != threadID {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Message parent inconnu dans cette discussion"})
+				return
+			}
+		}
+		html, err := renderMarkdown(req.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Markdown invalide"})
+			return
+		}
+		post, err := queries.CreateForumPost(ctx, db.CreateForumPostParams{
+			ThreadID: threadID,
+			ParentID: nullInt32(req.ParentID),
+			AuthorID: currentUserID(c),
+			Body:     req.Body,
+			BodyHtml: html,
+		})
+		if err == nil {
+			err = queries.TouchForumThread(ctx, threadID)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		notifyForumReply(ctx, queries, thread, parent, post)
+		c.JSON(http.StatusCreated, post)
+	}
+}
+
+// notifyForumReply prévient l'auteur du fil et celui du message auquel on répond. La réponse est
+// déjà publiée : un échec est journalisé sans faire échouer la requête.
+func notifyForumReply(ctx context.Context, queries db.Querier, thread db.ForumThread, parent db.ForumPost, post db.ForumPost) {
+	recipients := []int32{}
+	for _, id := range []int32{thread.AuthorID, parent.AuthorID} {
+		if id != 0 && id != post.AuthorID && (len(recipients) == 0 || recipients[0] != id) {
+			recipients = append(recipients, id)
+		}
+	}
+	err := notifications.Notify(ctx, queries, recipients, notifications.Notification{
+		Type:  notifications.ForumReply,
+		Title: fmt.Sprintf("Nouvelle réponse dans « %s »", thread.Title),
+		Body:  notifications.Excerpt(post.Body, 200),
+		Link:  fmt.Sprintf("/forum/threads/%d", thread.ID),
+		Data:  gin.H{"course_id": thread.CourseID, "thread_id": thread.ID, "post_id": post.ID},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "notifications: réponse au forum", "post_id", post.ID, "thread_id", thread.ID, "error", err)
+	}
+}
+
+// MarkForumAnswerHandler : l'équipe désigne la réponse de référence (post_id nul pour l'annuler).
+func MarkForumAnswerHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		threadID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de discussion invalide"})
+			return
+		}
+		var req struct {
+			PostID *int32 `json:"post_id"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		_, access, ok := loadVisibleThread(c, ctx, queries, threadID)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		var post db.ForumPost
+		if req.PostID != nil {
+			var err error
+			post, err = queries.GetForumPost(ctx, *req.PostID)
+			if err != nil || post.ThreadID != threadID || post.DeletedAt.Valid {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Message inconnu dans cette discussion"})
+				return
+			}
+		}
+		var thread db.ForumThread
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			thread, err = qtx.SetForumThreadAnswer(ctx, db.SetForumThreadAnswerParams{ID: threadID, AcceptedPostID: nullInt32(req.PostID)})
+			// Pas de points pour sa propre réponse ; une réponse acceptée à nouveau ne compte qu'une fois.
+			if err != nil || req.PostID == nil || post.AuthorID == currentUserID(c) {
+				return err
+			}
+			return gamification.Record(ctx, qtx, gamification.Event{
+				UserID:    post.AuthorID,
+				CourseID:  thread.CourseID,
+				Type:      gamification.EventForumAnswerAccepted,
+				SourceKey: fmt.Sprintf("%s:%d", gamification.EventForumAnswerAccepted, post.ID),
+			})
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, thread)
+	}
+}
+
+// ModerateForumThreadHandler épingle, verrouille ou masque un fil ; les champs absents sont conservés.
+func ModerateForumThreadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		threadID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de discussion invalide"})
+			return
+		}
+		var req struct {
+			Pinned *bool `json:"pinned"`
+			Locked *bool `json:"locked"`
+			Hidden *bool `json:"hidden"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		thread, access, ok := loadVisibleThread(c, ctx, queries, threadID)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		params := db.UpdateForumThreadFlagsParams{ID: threadID, Pinned: thread.Pinned, Locked: thread.Locked, Hidden: thread.Hidden}
+		if req.Pinned != nil {
+			params.Pinned = *req.Pinned
+		}
+		if req.Locked != nil {
+			params.Locked = *req.Locked
+		}
+		if req.Hidden != nil {
+			params.Hidden = *req.Hidden
+		}
+		thread, err := queries.UpdateForumThreadFlags(ctx, params)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, thread)
+	}
+}
+
+func DeleteForumThreadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		threadID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de discussion invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		thread, access, ok := loadVisibleThread(c, ctx, queries, threadID)
+		if !ok {
+			return
+		}
+		if !access.moderator && thread.AuthorID != currentUserID(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Seul l'auteur ou un modérateur peut supprimer cette discussion"})
+			return
+		}
+		if err := queries.DeleteForumThread(ctx, threadID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteForumPostHandler efface le contenu mais garde le message pour ne pas casser l'arborescence.
+func DeleteForumPostHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		post, _, access, ok := loadVisiblePost(c, ctx, queries)
+		if !ok {
+			return
+		}
+		if !access.moderator && post.AuthorID != currentUserID(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Seul l'auteur ou un modérateur peut supprimer ce message"})
+			return
+		}
+		if err := queries.SoftDeleteForumPost(ctx, post.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func ModerateForumPostHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Hidden *bool `json:"hidden" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		post, _, access, ok := loadVisiblePost(c, ctx, queries)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		post, err := queries.SetForumPostHidden(ctx, db.SetForumPostHiddenParams{ID: post.ID, Hidden: *req.Hidden})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, post)
+	}
+}
+
+func UpvoteForumPostHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		post, _, access, ok := loadVisiblePost(c, ctx, queries)
+		if !ok || !requireCanPost(c, ctx, queries, access) {
+			return
+		}
+		if post.DeletedAt.Valid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ce message a été supprimé"})
+			return
+		}
+		params := db.UpvoteForumPostParams{PostID: post.ID, UserID: currentUserID(c)}
+		var err error
+		if c.Request.Method == http.MethodDelete {
+			err = queries.RemoveForumPostUpvote(ctx, db.RemoveForumPostUpvoteParams(params))
+		} else {
+			err = queries.UpvoteForumPost(ctx, params)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func ListForumBansHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		access, ok := loadForumAccess(c, ctx, queries, courseID)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		bans, err := queries.ListForumBans(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if bans == nil {
+			bans = []db.ForumBan{}
+		}
+		c.JSON(http.StatusOK, bans)
+	}
+}
+
+func BanFromForumHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			UserID int32  `json:"user_id" binding:"required"`
+			Reason string `json:"reason" binding:"max=1000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		access, ok := loadForumAccess(c, ctx, queries, courseID)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		role, err := courseStaffRole(ctx, queries, courseID, req.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if role != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Un membre de l'équipe du cours ne peut pas être banni"})
+			return
+		}
+		userID := currentUserID(c)
+		ban, err := queries.BanFromForum(ctx, db.BanFromForumParams{
+			CourseID: courseID,
+			UserID:   req.UserID,
+			BannedBy: sql.NullInt32{Int32: userID, Valid: userID > 0},
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, ban)
+	}
+}
+
+func UnbanFromForumHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		access, ok := loadForumAccess(c, ctx, queries, courseID)
+		if !ok || !requireModerator(c, access) {
+			return
+		}
+		removed, err := queries.UnbanFromForum(ctx, db.UnbanFromForumParams{CourseID: courseID, UserID: userID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if removed == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cet utilisateur n'est pas banni"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
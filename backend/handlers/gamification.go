@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const maxLeaderboardSize = 100
+
+// leaderboardSince traduit le paramètre period (all, week ou month) en date de début.
+func leaderboardSince(c *gin.Context) (time.Time, bool) {
+	switch c.DefaultQuery("period", "all") {
+	case "all":
+		return time.Time{}, true
+	case "week":
+		return time.Now().AddDate(0, 0, -7), true
+	case "month":
+		return time.Now().AddDate(0, -1, 0), true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "Période invalide (all, week ou month)"})
+	return time.Time{}, false
+}
+
+type leaderboardEntry struct {
+	Rank   int    `json:"rank"`
+	UserID int32  `json:"user_id"`
+	Name   string `json:"name"`
+	Points int64  `json:"points"`
+}
+
+// rankLeaderboard numérote les lignes ; les ex aequo partagent le même rang.
+func rankLeaderboard(userIDs []int32, names []string, points []int64) []leaderboardEntry {
+	entries := make([]leaderboardEntry, len(userIDs))
+	for i := range userIDs {
+		rank := i + 1
+		if i > 0 && points[i] == points[i-1] {
+			rank = entries[i-1].Rank
+		}
+		entries[i] = leaderboardEntry{Rank: rank, UserID: userIDs[i], Name: names[i], Points: points[i]}
+	}
+	return entries
+}
+
+func courseLeaderboard(rows []db.CourseLeaderboardRow) []leaderboardEntry {
+	ids, names, points := make([]int32, len(rows)), make([]string, len(rows)), make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i], names[i], points[i] = row.UserID, row.Name, row.Points
+	}
+	return rankLeaderboard(ids, names, points)
+}
+
+func organizationLeaderboard(rows []db.OrganizationLeaderboardRow) []leaderboardEntry {
+	ids, names, points := make([]int32, len(rows)), make([]string, len(rows)), make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i], names[i], points[i] = row.UserID, row.Name, row.Points
+	}
+	return rankLeaderboard(ids, names, points)
+}
+
+// loadGamificationProfile renvoie le profil de l'utilisateur, ou les valeurs par défaut s'il
+// n'a encore rien gagné.
+func loadGamificationProfile(ctx context.Context, queries db.Querier, userID int32) (db.GamificationProfile, error) {
+	profile, err := queries.GetGamificationProfile(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.GamificationProfile{UserID: userID, Timezone: "UTC"}, nil
+	}
+	return profile, err
+}
+
+func toGamificationResponse(profile db.GamificationProfile, earned []db.UserAchievement, rules *gamification.Rules) gin.H {
+	loc := gamification.Location(profile.Timezone)
+	streak := gamification.Streak{Current: profile.CurrentStreak, Longest: profile.LongestStreak, LastActiveOn: profile.LastActiveOn}
+	earnedAt := map[string]time.Time{}
+	for _, a := range earned {
+		earnedAt[a.AchievementKey] = a.EarnedAt
+	}
+	achievements := []gin.H{}
+	for _, a := range rules.Achievements {
+		item := gin.H{"key": a.Key, "name": a.Name, "description": a.Description, "earned_at": nil}
+		if at, ok := earnedAt[a.Key]; ok {
+			item["earned_at"] = at.Format(time.RFC3339)
+		}
+		achievements = append(achievements, item)
+	}
+	var lastActive any
+	if profile.LastActiveOn.Valid {
+		lastActive = profile.LastActiveOn.Time.Format(time.DateOnly)
+	}
+	return gin.H{
+		"points":              profile.Points,
+		"current_streak":      streak.At(gamification.LocalDay(time.Now(), loc)),
+		"longest_streak":      profile.LongestStreak,
+		"last_active_on":      lastActive,
+		"timezone":            profile.Timezone,
+		"leaderboard_opt_out": profile.LeaderboardOptOut,
+		"achievements":        achievements,
+	}
+}
+
+// GetMyGamificationHandler : points, série en cours (rompue si la veille a été manquée, dans le
+// fuseau de l'utilisateur) et succès, obtenus ou non.
+func GetMyGamificationHandler(queries repository.Store, rules *gamification.Rules) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		profile, err := loadGamificationProfile(ctx, queries, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		earned, err := queries.ListUserAchievements(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toGamificationResponse(profile, earned, rules))
+	}
+}
+
+// UpdateMyGamificationHandler règle le fuseau des séries et le retrait des classements.
+func UpdateMyGamificationHandler(queries repository.Store, rules *gamification.Rules) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Timezone          string `json:"timezone" binding:"required,max=64"`
+			LeaderboardOptOut *bool  `json:"leaderboard_opt_out" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Fuseau horaire inconnu"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		profile, err := queries.UpdateGamificationSettings(ctx, db.UpdateGamificationSettingsParams{
+			UserID:            userID,
+			Timezone:          req.Timezone,
+			LeaderboardOptOut: *req.LeaderboardOptOut,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		earned, err := queries.ListUserAchievements(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toGamificationResponse(profile, earned, rules))
+	}
+}
+
+// CourseLeaderboardHandler classe les inscrits d'un cours par points gagnés dans ce cours ; réservé
+// aux inscrits et à l'équipe. Les utilisateurs qui s'en sont retirés n'apparaissent pas.
+func CourseLeaderboardHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		since, ok := leaderboardSince(c)
+		if !ok {
+			return
+		}
+		limit, ok := boundedQueryInt(c, "limit", 10, maxLeaderboardSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre limit invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, err := queries.GetCourse(ctx, courseID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cours introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if _, ok := loadCourseMember(c, ctx, queries, courseID); !ok {
+			return
+		}
+		rows, err := queries.CourseLeaderboard(ctx, db.CourseLeaderboardParams{CourseID: courseID, Since: since, Limit: int32(limit)})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, courseLeaderboard(rows))
+	}
+}
+
+// OrganizationLeaderboardHandler : classement général, sur les cours de l'établissement courant.
+func OrganizationLeaderboardHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		since, ok := leaderboardSince(c)
+		if !ok {
+			return
+		}
+		limit, ok := boundedQueryInt(c, "limit", 10, maxLeaderboardSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre limit invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		rows, err := queries.OrganizationLeaderboard(ctx, db.OrganizationLeaderboardParams{
+			OrganizationID: currentOrganization(c).ID,
+			Since:          since,
+			Limit:          int32(limit),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, organizationLeaderboard(rows))
+	}
+}
+
+// GamificationHighlightsHandler alimente les widgets du tableau de bord : meilleurs apprenants
+// de la semaine et derniers succès débloqués dans l'établissement.
+func GamificationHighlightsHandler(queries repository.Store, rules *gamification.Rules) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		orgID := currentOrganization(c).ID
+		top, err := queries.OrganizationLeaderboard(ctx, db.OrganizationLeaderboardParams{
+			OrganizationID: orgID,
+			Since:          time.Now().AddDate(0, 0, -7),
+			Limit:          5,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recent, err := queries.ListRecentAchievements(ctx, db.ListRecentAchievementsParams{OrganizationID: orgID, Limit: 5})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		achievements := []gin.H{}
+		for _, row := range recent {
+			name := row.AchievementKey
+			if a, ok := rules.Achievement(row.AchievementKey); ok {
+				name = a.Name
+			}
+			achievements = append(achievements, gin.H{
+				"user_id":     row.UserID,
+				"user_name":   row.Name,
+				"achievement": row.AchievementKey,
+				"name":        name,
+				"earned_at":   row.EarnedAt.Format(time.RFC3339),
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"top_learners": organizationLeaderboard(top), "recent_achievements": achievements})
+	}
+}
@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/notifications"
+)
+
+func TestGamificationPointsAndLeaderboards(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	alice := f.outsider
+	bob := seedUser(t, store, "bob@example.com", "secret123", "student")
+	aliceToken, bobToken := tokenFor(t, alice.ID, "student"), tokenFor(t, bob.ID, "student")
+	for _, id := range []int32{alice.ID, bob.ID} {
+		if _, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: id, CourseID: f.courseID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Alice termine deux leçons (la première deux fois), Bob une seule.
+	record := func(userID, lessonID int32) {
+		t.Helper()
+		err := gamification.Record(ctx, store, gamification.Event{
+			UserID:    userID,
+			CourseID:  f.courseID,
+			Type:      gamification.EventLessonCompleted,
+			SourceKey: fmt.Sprintf("test:%d:%d", userID, lessonID),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	record(alice.ID, 1)
+	record(alice.ID, 1)
+	record(alice.ID, 2)
+	record(bob.ID, 1)
+	queued := store.Jobs(gamification.JobApply)
+	if len(queued) != 3 {
+		t.Fatalf("%d événements à appliquer, attendu 3", len(queued))
+	}
+	engine := gamification.NewEngine(store, testGamificationRules(t))
+	for id := int64(1); id <= 3; id++ {
+		for i := 0; i < 2; i++ {
+			if err := engine.Apply(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if got := store.Notifications(alice.ID); len(got) != 1 || got[0].Type != notifications.AchievementUnlocked {
+		t.Fatalf("notifications d'Alice : %+v", got)
+	}
+
+	w := do(t, r, http.MethodGet, "/me/gamification", aliceToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var me struct {
+		Points        int32 `json:"points"`
+		CurrentStreak int32 `json:"current_streak"`
+		Achievements  []struct {
+			Key      string  `json:"key"`
+			EarnedAt *string `json:"earned_at"`
+		} `json:"achievements"`
+	}
+	decode(t, w, &me)
+	if me.Points != 20 || me.CurrentStreak != 1 {
+		t.Fatalf("profil d'Alice : %+v", me)
+	}
+	if me.Achievements[0].Key != "first_lesson" || me.Achievements[0].EarnedAt == nil || me.Achievements[1].EarnedAt != nil {
+		t.Fatalf("succès d'Alice : %+v", me.Achievements)
+	}
+
+	type entry struct {
+		Rank   int   `json:"rank"`
+		UserID int32 `json:"user_id"`
+		Points int64 `json:"points"`
+	}
+	path := fmt.Sprintf("/courses/%d/leaderboard", f.courseID)
+	w = do(t, r, http.MethodGet, path, bobToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var board []entry
+	decode(t, w, &board)
+	if len(board) != 2 || board[0].UserID != alice.ID || board[0].Points != 20 || board[1].Rank != 2 {
+		t.Fatalf("classement du cours : %+v", board)
+	}
+	carol := seedUser(t, store, "carol@example.com", "secret123", "student")
+	expectStatus(t, do(t, r, http.MethodGet, path, tokenFor(t, carol.ID, "student"), nil), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodGet, path+"?period=year", bobToken, nil), http.StatusBadRequest)
+
+	// Bob se retire des classements : il disparaît du classement général et des temps forts.
+	expectStatus(t, do(t, r, http.MethodPut, "/me/gamification", bobToken, map[string]any{"timezone": "Mars/Olympus", "leaderboard_opt_out": true}), http.StatusBadRequest)
+	expectStatus(t, do(t, r, http.MethodPut, "/me/gamification", bobToken, map[string]any{"timezone": "Europe/Paris", "leaderboard_opt_out": true}), http.StatusOK)
+	w = do(t, r, http.MethodGet, "/leaderboard?period=week", aliceToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	board = nil
+	decode(t, w, &board)
+	if len(board) != 1 || board[0].UserID != alice.ID {
+		t.Fatalf("classement général : %+v", board)
+	}
+	w = do(t, r, http.MethodGet, "/gamification/highlights", aliceToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	var highlights struct {
+		TopLearners        []entry `json:"top_learners"`
+		RecentAchievements []struct {
+			UserID int32  `json:"user_id"`
+			Name   string `json:"name"`
+		} `json:"recent_achievements"`
+	}
+	decode(t, w, &highlights)
+	if len(highlights.TopLearners) != 1 || len(highlights.RecentAchievements) != 1 || highlights.RecentAchievements[0].Name != "Premiers pas" {
+		t.Fatalf("temps forts : %+v", highlights)
+	}
+}
+
+func TestLessonCompletedOnlyForVisibleRevision(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+	lessons := publishLessons(t, store, f.courseID, 2)
+	draft, err := store.CreateLesson(ctx, f.courseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: f.outsider.ID, CourseID: f.courseID}); err != nil {
+		t.Fatal(err)
+	}
+	token := tokenFor(t, f.outsider.ID, "student")
+
+	expectStatus(t, do(t, r, http.MethodPost, fmt.Sprintf("/courses/%d/lessons/%d/complete", f.courseID, draft.ID), token, nil), http.StatusNotFound)
+	if queued := store.Jobs(gamification.JobApply); len(queued) != 0 {
+		t.Fatalf("leçon hors révision récompensée : %+v", queued)
+	}
+	expectStatus(t, do(t, r, http.MethodPost, fmt.Sprintf("/courses/%d/lessons/%d/complete", f.courseID, lessons[0]), token, nil), http.StatusOK)
+	queued := store.Jobs(gamification.JobApply)
+	if len(queued) != 1 {
+		t.Fatalf("%d événements à appliquer, attendu 1", len(queued))
+	}
+	event, err := store.GetPointEvent(ctx, 1)
+	if err != nil || event.Event != gamification.EventLessonCompleted {
+		t.Fatalf("événement : %+v, %v", event, err)
+	}
+}
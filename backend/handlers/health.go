@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/health"
+	"online-learning-platform-backend/internal/migrate"
+)
+
+// HealthzHandler : sonde de vivacité, ne touche pas la base pour qu'une panne Postgres ne fasse pas redémarrer le pod.
+func HealthzHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+// ReadyzHandler : sonde de disponibilité, 503 tant que la base est injoignable ou le schéma en retard.
+func ReadyzHandler(dbConn *sql.DB, migrator *migrate.Migrator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+		defer cancel()
+
+		if err := health.Check(ctx, dbConn, migrator); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "schema": migrator.Plan().Last().Name})
+	}
+}
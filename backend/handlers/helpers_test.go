@@ -0,0 +1,180 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/repository/memory"
+	"online-learning-platform-backend/routes"
+)
+
+// Même secret que middleware.AuthRequired et handlers.LoginHandler.
+var testSecret = []byte("dev-secret-key-change-me")
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestRouter câble les routes testées comme main.go, sur un store en mémoire.
+func newTestRouter(t *testing.T) (*gin.Engine, *memory.Store) {
+	t.Helper()
+	store := memory.New()
+	r := gin.New()
+	routes.UseOrganization(r, store)
+	routes.RegisterOrganizationRoutes(r, store)
+	routes.RegisterUserRoutes(r, store)
+	routes.RegisterAuthRoutes(r, store)
+	routes.RegisterOnboardingRoutes(r, store)
+	routes.RegisterCoursesRoutes(r, store)
+	routes.RegisterStaffRoutes(r, store)
+	routes.RegisterCertificatesRoutes(r, store, store, testBadgeKeys(t))
+	routes.RegisterBadgesRoutes(r, store, store, testBadgeKeys(t))
+	routes.RegisterGamificationRoutes(r, store, testGamificationRules(t))
+	routes.RegisterProgressRoutes(r, store)
+	routes.RegisterAnalyticsRoutes(r, store)
+	routes.RegisterReviewsRoutes(r, store)
+	routes.RegisterNotificationsRoutes(r, store)
+	return r, store
+}
+
+var (
+	badgeKeyOnce    sync.Once
+	badgeKey        *badges.Key
+	retiredBadgeKey *rsa.PrivateKey
+	badgeKeyErr     error
+)
+
+func generateBadgeKeys(t *testing.T) {
+	t.Helper()
+	badgeKeyOnce.Do(func() {
+		if badgeKey, badgeKeyErr = badges.GenerateKey(); badgeKeyErr == nil {
+			retiredBadgeKey, badgeKeyErr = rsa.GenerateKey(rand.Reader, 2048)
+		}
+	})
+	if badgeKeyErr != nil {
+		t.Fatal(badgeKeyErr)
+	}
+}
+
+// testBadgeKey : une seule clé RSA courante pour tous les tests, sa génération est lente.
+func testBadgeKey(t *testing.T) *badges.Key {
+	generateBadgeKeys(t)
+	return badgeKey
+}
+
+// testRetiredBadgeKey : une clé d'avant la rotation, dont seule la partie publique reste au trousseau.
+func testRetiredBadgeKey(t *testing.T) *badges.Key {
+	generateBadgeKeys(t)
+	return badges.NewKey(retiredBadgeKey)
+}
+
+func testBadgeKeys(t *testing.T) *badges.Keyring {
+	generateBadgeKeys(t)
+	return badges.NewKeyring(badgeKey, &retiredBadgeKey.PublicKey)
+}
+
+func testGamificationRules(t *testing.T) *gamification.Rules {
+	t.Helper()
+	rules, err := gamification.DefaultRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
+
+// defaultOrgID est l'établissement par défaut de memory.New, celui des requêtes sans X-Organization.
+const defaultOrgID = 1
+
+// seedUser crée un compte directement dans le store (mot de passe haché au coût minimal), membre
+// de l'établissement par défaut comme après une inscription.
+func seedUser(t *testing.T, store *memory.Store, email, password, role string) db.CreateUserRow {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.CreateUser(context.Background(), db.CreateUserParams{
+		Name:     email,
+		Email:    email,
+		Password: string(hash),
+		Role:     role,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.EnsureOrganizationMember(context.Background(), db.EnsureOrganizationMemberParams{OrganizationID: defaultOrgID, UserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// tokenFor signe un token équivalent à celui que renvoie /login.
+func tokenFor(t *testing.T, userID int32, role string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"role":    role,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// do envoie une requête JSON ; token vide pour une requête anonyme.
+func do(t *testing.T, r http.Handler, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	return doIn(t, r, "", method, path, token, body)
+}
+
+// doIn envoie la requête dans l'établissement org (en-tête X-Organization, omis si vide).
+func doIn(t *testing.T, r http.Handler, org, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if org != "" {
+		req.Header.Set("X-Organization", org)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("réponse illisible %q: %v", w.Body.String(), err)
+	}
+}
+
+func expectStatus(t *testing.T, w *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if w.Code != want {
+		t.Fatalf("statut %d, attendu %d (corps %s)", w.Code, want, w.Body.String())
+	}
+}
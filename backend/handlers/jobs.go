@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	defaultJobsPageSize = 50
+	maxJobsPageSize     = 200
+)
+
+var jobStatuses = map[string]bool{"queued": true, "running": true, "succeeded": true, "dead": true}
+
+func parseJobID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de tâche invalide"})
+		return 0, false
+	}
+	return id, true
+}
+
+// ListJobsHandler : ?status=dead&kind=...&page=&page_size= ; les plus récentes en premier.
+func ListJobsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.Query("status")
+		if status != "" && !jobStatuses[status] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Statut de tâche invalide"})
+			return
+		}
+		page, ok := boundedQueryInt(c, "page", 1, 1<<20)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page invalide"})
+			return
+		}
+		pageSize, ok := boundedQueryInt(c, "page_size", defaultJobsPageSize, maxJobsPageSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page_size invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		jobs, err := queries.ListJobs(ctx, db.ListJobsParams{
+			Status: status,
+			Kind:   c.Query("kind"),
+			Limit:  int32(pageSize),
+			Offset: int32((page - 1) * pageSize),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if jobs == nil {
+			jobs = []db.Job{}
+		}
+		c.JSON(http.StatusOK, gin.H{"jobs": jobs, "page": page, "page_size": pageSize})
+	}
+}
+
+// JobStatsHandler renvoie le nombre de tâches par type et par statut.
+func JobStatsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		rows, err := queries.CountJobsByStatus(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		totals := map[string]int64{"queued": 0, "running": 0, "succeeded": 0, "dead": 0}
+		byKind := map[string]map[string]int64{}
+		for _, row := range rows {
+			totals[row.Status] += row.Count
+			if byKind[row.Kind] == nil {
+				byKind[row.Kind] = map[string]int64{}
+			}
+			byKind[row.Kind][row.Status] = row.Count
+		}
+		c.JSON(http.StatusOK, gin.H{"totals": totals, "by_kind": byKind})
+	}
+}
+
+func GetJobHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		job, err := queries.GetJob(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tâche introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// RetryJobHandler remet en file une tâche abandonnée (dead), compteur de tentatives remis à zéro.
+func RetryJobHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		job, err := queries.RequeueDeadJob(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, err := queries.GetJob(ctx, id); errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tâche introuvable"})
+				return
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": "Seule une tâche abandonnée peut être relancée"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// DeleteJobHandler supprime une tâche terminée ou abandonnée ; une tâche en attente ou en cours est refusée.
+func DeleteJobHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseJobID(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		deleted, err := queries.DeleteJob(ctx, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if deleted == 0 {
+			if _, err := queries.GetJob(ctx, id); errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Tâche introuvable"})
+				return
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": "Une tâche en attente ou en cours ne peut pas être supprimée"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func ListJobSchedulesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		schedules, err := queries.ListJobSchedules(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if schedules == nil {
+			schedules = []db.JobSchedule{}
+		}
+		c.JSON(http.StatusOK, schedules)
+	}
+}
+
+// SetJobScheduleEnabledHandler suspend ou réactive une tâche planifiée.
+func SetJobScheduleEnabledHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Enabled *bool `json:"enabled" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		updated, err := queries.SetJobScheduleEnabled(ctx, db.SetJobScheduleEnabledParams{Name: c.Param("name"), Enabled: *req.Enabled})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if updated == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Planification introuvable"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"name": c.Param("name"), "enabled": *req.Enabled})
+	}
+}
@@ -0,0 +1,663 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	maxGroupParticipants   = 8
+	maxMessageAttachments  = 5
+	maxAttachmentSizeBytes = 5 << 20
+	defaultInboxPageSize   = 20
+	maxInboxPageSize       = 100
+)
+
+type InboxEntry struct {
+	ID              int32  `json:"id"`
+	Subject         string `json:"subject"`
+	IsGroup         bool   `json:"is_group"`
+	Participants    string `json:"participants"`
+	LastMessageAt   string `json:"last_message_at"`
+	LastMessageID   *int32 `json:"last_message_id"`
+	LastSenderID    *int32 `json:"last_sender_id"`
+	LastMessageBody string `json:"last_message_body"`
+	UnreadCount     int64  `json:"unread_count"`
+}
+
+type ParticipantResponse struct {
+	UserID            int32   `json:"user_id"`
+	Name              string  `json:"name"`
+	Role              string  `json:"role"`
+	LastReadMessageID *int32  `json:"last_read_message_id"`
+	LastReadAt        *string `json:"last_read_at"`
+}
+
+type AttachmentResponse struct {
+	ID          int32  `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int32  `json:"size_bytes"`
+}
+
+type MessageResponse struct {
+	ID          int32                `json:"id"`
+	SenderID    int32                `json:"sender_id"`
+	Body        string               `json:"body"`
+	CreatedAt   string               `json:"created_at"`
+	ReadBy      []int32              `json:"read_by"`
+	Attachments []AttachmentResponse `json:"attachments"`
+}
+
+// canMessage applique les règles de la messagerie : les admins écrivent à tous et tout le monde peut
+// leur écrire ; sinon il faut partager un cours, comme membre de l'équipe ou comme inscrit suivi par celle-ci.
+func canMessage(ctx context.Context, queries db.Querier, senderID int32, senderRole string, recipient db.User) (bool, error) {
+	if recipient.ID == senderID {
+		return false, nil
+	}
+	if senderRole == "admin" || strings.ToLower(recipient.Role) == "admin" {
+		return true, nil
+	}
+	return queries.CanMessageUser(ctx, db.CanMessageUserParams{SenderID: senderID, RecipientID: recipient.ID})
+}
+
+// loadParticipation vérifie que l'utilisateur courant fait partie de la conversation ; sinon elle n'existe pas pour lui.
+func loadParticipation(c *gin.Context, ctx context.Context, queries db.Querier, conversationID int32) (db.ConversationParticipant, bool) {
+	participant, err := queries.GetConversationParticipant(ctx, db.GetConversationParticipantParams{
+		ConversationID: conversationID,
+		UserID:         currentUserID(c),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation introuvable"})
+		return participant, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return participant, false
+	}
+	return participant, true
+}
+
+func toParticipantResponses(rows []db.ListConversationParticipantsRow) []ParticipantResponse {
+	participants := make([]ParticipantResponse, 0, len(rows))
+	for _, row := range rows {
+		p := ParticipantResponse{UserID: row.UserID, Name: row.Name, Role: row.Role}
+		if row.LastReadMessageID.Valid {
+			p.LastReadMessageID = &row.LastReadMessageID.Int32
+		}
+		if row.LastReadAt.Valid {
+			readAt := row.LastReadAt.Time.Format(time.RFC3339)
+			p.LastReadAt = &readAt
+		}
+		participants = append(participants, p)
+	}
+	return participants
+}
+
+func boundedQueryInt(c *gin.Context, name string, fallback, max int) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 || value > max {
+		return 0, false
+	}
+	return value, true
+}
+
+// ListInboxHandler renvoie les conversations de l'utilisateur, la plus récemment active en premier.
+func ListInboxHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, ok := boundedQueryInt(c, "page", 1, 1<<20)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page invalide"})
+			return
+		}
+		pageSize, ok := boundedQueryInt(c, "page_size", defaultInboxPageSize, maxInboxPageSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page_size invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		total, err := queries.CountInbox(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		unread, err := queries.CountUnreadMessages(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		rows, err := queries.ListInbox(ctx, db.ListInboxParams{
+			UserID: userID,
+			Limit:  int32(pageSize),
+			Offset: int32((page - 1) * pageSize),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		items := make([]InboxEntry, 0, len(rows))
+		for _, row := range rows {
+			entry := InboxEntry{
+				ID:              row.ID,
+				Subject:         row.Subject,
+				IsGroup:         row.IsGroup,
+				Participants:    row.ParticipantNames.String,
+				LastMessageAt:   row.LastMessageAt.Format(time.RFC3339),
+				LastMessageBody: row.LastMessageBody,
+				UnreadCount:     row.UnreadCount,
+			}
+			if row.LastMessageID.Valid {
+				entry.LastMessageID = &row.LastMessageID.Int32
+			}
+			if row.LastSenderID.Valid {
+				entry.LastSenderID = &row.LastSenderID.Int32
+			}
+			items = append(items, entry)
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"items":        items,
+			"page":         page,
+			"page_size":    pageSize,
+			"total":        total,
+			"unread_total": unread,
+		})
+	}
+}
+
+func UnreadMessagesCountHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		unread, err := queries.CountUnreadMessages(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"unread": unread})
+	}
+}
+
+// CreateConversationHandler ouvre une conversation ; à deux, la conversation existante est réutilisée.
+func CreateConversationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ParticipantIDs []int32 `json:"participant_ids" binding:"required,min=1"`
+			Subject        string  `json:"subject" binding:"max=200"`
+			Body           string  `json:"body" binding:"max=10000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		role := currentRole(c)
+		recipients := make([]int32, 0, len(req.ParticipantIDs))
+		seen := map[int32]bool{userID: true}
+		for _, id := range req.ParticipantIDs {
+			if !seen[id] {
+				seen[id] = true
+				recipients = append(recipients, id)
+			}
+		}
+		if len(recipients) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Une conversation nécessite au moins un autre participant"})
+			return
+		}
+		if len(recipients)+1 > maxGroupParticipants {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Trop de participants pour une conversation de groupe"})
+			return
+		}
+		for _, id := range recipients {
+			recipient, err := queries.GetUserByID(ctx, id)
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Destinataire introuvable"})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			allowed, err := canMessage(ctx, queries, userID, role, recipient)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if !allowed {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Vous ne pouvez pas écrire à " + recipient.Name})
+				return
+			}
+		}
+
+		isGroup := len(recipients) > 1
+		if !isGroup {
+			existingID, err := queries.FindDirectConversation(ctx, db.FindDirectConversationParams{UserID: userID, OtherUserID: recipients[0]})
+			if err == nil {
+				// Conversation existante : le premier message y est posté comme avec SendMessageHandler.
+				var conversation db.Conversation
+				err := queries.InTx(ctx, func(qtx db.Querier) error {
+					err := postTextMessage(ctx, qtx, existingID, userID, req.Body)
+					if err == nil {
+						conversation, err = qtx.GetConversation(ctx, existingID)
+					}
+					return err
+				})
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+				c.JSON(http.StatusOK, conversation)
+				return
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		var conversation db.Conversation
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			conversation, err = qtx.CreateConversation(ctx, db.CreateConversationParams{
+				Subject:   strings.TrimSpace(req.Subject),
+				IsGroup:   isGroup,
+				CreatedBy: sql.NullInt32{Int32: userID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			for _, id := range append([]int32{userID}, recipients...) {
+				if err := qtx.AddConversationParticipant(ctx, db.AddConversationParticipantParams{ConversationID: conversation.ID, UserID: id}); err != nil {
+					return err
+				}
+			}
+			return postTextMessage(ctx, qtx, conversation.ID, userID, req.Body)
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, conversation)
+	}
+}
+
+func GetConversationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		conversationID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de conversation invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadParticipation(c, ctx, queries, conversationID); !ok {
+			return
+		}
+		conversation, err := queries.GetConversation(ctx, conversationID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		participants, err := queries.ListConversationParticipants(ctx, conversationID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"conversation": conversation,
+			"participants": toParticipantResponses(participants),
+		})
+	}
+}
+
+// ListMessagesHandler pagine à rebours avec ?before=<id> ; read_by liste les participants ayant lu chaque message.
+func ListMessagesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		conversationID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de conversation invalide"})
+			return
+		}
+		before, ok := optionalIDQuery(c, "before")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre before invalide"})
+			return
+		}
+		limit, ok := boundedQueryInt(c, "limit", 50, maxInboxPageSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre limit invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadParticipation(c, ctx, queries, conversationID); !ok {
+			return
+		}
+		rows, err := queries.ListMessages(ctx, db.ListMessagesParams{ConversationID: conversationID, BeforeID: before, Limit: int32(limit)})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		participants, err := queries.ListConversationParticipants(ctx, conversationID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		messages := make([]MessageResponse, 0, len(rows))
+		index := make(map[int32]int, len(rows))
+		for _, row := range rows {
+			readBy := []int32{}
+			for _, p := range participants {
+				if p.UserID != row.SenderID && p.LastReadMessageID.Valid && p.LastReadMessageID.Int32 >= row.ID {
+					readBy = append(readBy, p.UserID)
+				}
+			}
+			index[row.ID] = len(messages)
+			messages = append(messages, MessageResponse{
+				ID:          row.ID,
+				SenderID:    row.SenderID,
+				Body:        row.Body,
+				CreatedAt:   row.CreatedAt.Format(time.RFC3339),
+				ReadBy:      readBy,
+				Attachments: []AttachmentResponse{},
+			})
+		}
+		if len(rows) > 0 {
+			// Les messages arrivent du plus récent au plus ancien.
+			attachments, err := queries.ListMessageAttachments(ctx, db.ListMessageAttachmentsParams{
+				ConversationID: conversationID,
+				FromMessageID:  rows[len(rows)-1].ID,
+				ToMessageID:    rows[0].ID,
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			for _, a := range attachments {
+				if i, ok := index[a.MessageID]; ok {
+					messages[i].Attachments = append(messages[i].Attachments, AttachmentResponse{
+						ID: a.ID, Filename: a.Filename, ContentType: a.ContentType, SizeBytes: a.SizeBytes,
+					})
+				}
+			}
+		}
+
+		var nextBefore *int32
+		if len(rows) == limit {
+			nextBefore = &rows[len(rows)-1].ID
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"messages":     messages,
+			"participants": toParticipantResponses(participants),
+			"next_before":  nextBefore,
+		})
+	}
+}
+
+// postTextMessage poste le message d'ouverture d'une conversation, sans pièce jointe ; rien si
+// body est vide.
+func postTextMessage(ctx context.Context, qtx db.Querier, conversationID, userID int32, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+	message, err := qtx.CreateMessage(ctx, db.CreateMessageParams{ConversationID: conversationID, SenderID: userID, Body: body})
+	if err != nil {
+		return err
+	}
+	err = qtx.TouchConversation(ctx, conversationID)
+	if err == nil {
+		err = qtx.MarkConversationRead(ctx, db.MarkConversationReadParams{ConversationID: conversationID, UserID: userID})
+	}
+	if err == nil {
+		err = publishMessage(ctx, qtx, conversationID, MessageResponse{
+			ID:          message.ID,
+			SenderID:    message.SenderID,
+			Body:        message.Body,
+			CreatedAt:   message.CreatedAt.Format(time.RFC3339),
+			ReadBy:      []int32{},
+			Attachments: []AttachmentResponse{},
+		})
+	}
+	return err
+}
+
+// publishMessage prévient les autres participants, dans la transaction qui crée le message.
+func publishMessage(ctx context.Context, qtx db.Querier, conversationID int32, message MessageResponse) error {
+	participants, err := qtx.ListConversationParticipants(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	recipients := make([]int32, 0, len(participants))
+	for _, p := range participants {
+		if p.UserID != message.SenderID {
+			recipients = append(recipients, p.UserID)
+		}
+	}
+	return events.Publish(ctx, qtx, recipients, events.MessageCreated, gin.H{
+		"conversation_id": conversationID,
+		"message":         message,
+	})
+}
+
+type uploadedAttachment struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+func readAttachment(header *multipart.FileHeader) (uploadedAttachment, error) {
+	if header.Size > maxAttachmentSizeBytes {
+		return uploadedAttachment{}, errors.New("La pièce jointe " + header.Filename + " dépasse 5 Mo")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return uploadedAttachment{}, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxAttachmentSizeBytes+1))
+	if err != nil {
+		return uploadedAttachment{}, err
+	}
+	if len(data) > maxAttachmentSizeBytes {
+		return uploadedAttachment{}, errors.New("La pièce jointe " + header.Filename + " dépasse 5 Mo")
+	}
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+	return uploadedAttachment{filename: filepath.Base(header.Filename), contentType: contentType, data: data}, nil
+}
+
+// SendMessageHandler accepte du JSON ({"body": ...}) ou un formulaire multipart avec des fichiers "attachments".
+func SendMessageHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		conversationID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de conversation invalide"})
+			return
+		}
+
+		var body string
+		var attachments []uploadedAttachment
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, (maxMessageAttachments+1)*maxAttachmentSizeBytes)
+			form, err := c.MultipartForm()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Formulaire invalide ou trop volumineux"})
+				return
+			}
+			if values := form.Value["body"]; len(values) > 0 {
+				body = values[0]
+			}
+			files := form.File["attachments"]
+			if len(files) > maxMessageAttachments {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Trop de pièces jointes (5 maximum)"})
+				return
+			}
+			for _, header := range files {
+				attachment, err := readAttachment(header)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+					return
+				}
+				attachments = append(attachments, attachment)
+			}
+		} else {
+			var req struct {
+				Body string `json:"body" binding:"required"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			body = req.Body
+		}
+		body = strings.TrimSpace(body)
+		if body == "" && len(attachments) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Le message est vide"})
+			return
+		}
+		if len(body) > 10000 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Le message est trop long"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		if _, ok := loadParticipation(c, ctx, queries, conversationID); !ok {
+			return
+		}
+		userID := currentUserID(c)
+
+		var response MessageResponse
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			message, err := qtx.CreateMessage(ctx, db.CreateMessageParams{ConversationID: conversationID, SenderID: userID, Body: body})
+			if err != nil {
+				return err
+			}
+			response = MessageResponse{
+				ID:          message.ID,
+				SenderID:    message.SenderID,
+				Body:        message.Body,
+				CreatedAt:   message.CreatedAt.Format(time.RFC3339),
+				ReadBy:      []int32{},
+				Attachments: []AttachmentResponse{},
+			}
+			for _, a := range attachments {
+				saved, err := qtx.CreateMessageAttachment(ctx, db.CreateMessageAttachmentParams{
+					MessageID:   message.ID,
+					Filename:    a.filename,
+					ContentType: a.contentType,
+					SizeBytes:   int32(len(a.data)),
+					Data:        a.data,
+				})
+				if err != nil {
+					return err
+				}
+				response.Attachments = append(response.Attachments, AttachmentResponse{
+					ID: saved.ID, Filename: saved.Filename, ContentType: saved.ContentType, SizeBytes: saved.SizeBytes,
+				})
+			}
+			// Écrire un message vaut lecture de tout ce qui précède.
+			err = qtx.TouchConversation(ctx, conversationID)
+			if err == nil {
+				err = qtx.MarkConversationRead(ctx, db.MarkConversationReadParams{ConversationID: conversationID, UserID: userID})
+			}
+			if err == nil {
+				err = publishMessage(ctx, qtx, conversationID, response)
+			}
+			return err
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func MarkConversationReadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		conversationID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de conversation invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadParticipation(c, ctx, queries, conversationID); !ok {
+			return
+		}
+		if err := queries.MarkConversationRead(ctx, db.MarkConversationReadParams{ConversationID: conversationID, UserID: currentUserID(c)}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// DownloadAttachmentHandler sert la pièce jointe en téléchargement forcé pour éviter tout rendu dans le navigateur.
+func DownloadAttachmentHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		attachmentID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de pièce jointe invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		attachment, err := queries.GetMessageAttachment(ctx, attachmentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pièce jointe introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if _, ok := loadParticipation(c, ctx, queries, attachment.ConversationID); !ok {
+			return
+		}
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Data(http.StatusOK, attachment.ContentType, attachment.Data)
+	}
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+func ListNotificationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, ok := boundedQueryInt(c, "page", 1, 1<<20)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page invalide"})
+			return
+		}
+		pageSize, ok := boundedQueryInt(c, "page_size", defaultInboxPageSize, maxInboxPageSize)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre page_size invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		counts, err := queries.CountNotifications(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		items, err := queries.ListNotifications(ctx, db.ListNotificationsParams{
+			UserID:     userID,
+			UnreadOnly: c.Query("unread") == "true",
+			Limit:      int32(pageSize),
+			Offset:     int32((page - 1) * pageSize),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if items == nil {
+			items = []db.Notification{}
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"items":     items,
+			"page":      page,
+			"page_size": pageSize,
+			"total":     counts.Total,
+			"unread":    counts.Unread,
+		})
+	}
+}
+
+func MarkNotificationReadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notificationID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de notification invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		updated, err := queries.MarkNotificationRead(ctx, db.MarkNotificationReadParams{ID: notificationID, UserID: currentUserID(c)})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if updated == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Notification introuvable"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func MarkAllNotificationsReadHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		updated, err := queries.MarkAllNotificationsRead(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"updated": updated})
+	}
+}
+
+// effectivePreferences complète les préférences enregistrées par les valeurs par défaut.
+func effectivePreferences(ctx context.Context, queries db.Querier, userID int32) ([]notifications.Preference, error) {
+	saved, err := queries.ListNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byType := make(map[string]db.NotificationPreference, len(saved))
+	for _, p := range saved {
+		byType[p.EventType] = p
+	}
+	prefs := make([]notifications.Preference, 0, len(notifications.Types))
+	for _, eventType := range notifications.Types {
+		p, ok := byType[eventType]
+		if !ok {
+			prefs = append(prefs, notifications.DefaultPreference(eventType))
+			continue
+		}
+		prefs = append(prefs, notifications.Preference{EventType: eventType, InApp: p.InApp, Email: p.Email, Webhook: p.Webhook, Digest: p.Digest})
+	}
+	return prefs, nil
+}
+
+func notificationSettings(ctx context.Context, queries db.Querier, userID int32) (gin.H, error) {
+	prefs, err := effectivePreferences(ctx, queries, userID)
+	if err != nil {
+		return nil, err
+	}
+	webhook := gin.H{"configured": false, "url": ""}
+	hook, err := queries.GetNotificationWebhook(ctx, userID)
+	if err == nil {
+		webhook = gin.H{"configured": true, "url": hook.Url}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return gin.H{"preferences": prefs, "webhook": webhook}, nil
+}
+
+func GetNotificationPreferencesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		settings, err := notificationSettings(ctx, queries, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, settings)
+	}
+}
+
+func UpdateNotificationPreferencesHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Preferences []notifications.Preference `json:"preferences" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		for _, p := range req.Preferences {
+			if !notifications.IsKnownType(p.EventType) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Type de notification inconnu : " + p.EventType})
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		userID := currentUserID(c)
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			for _, p := range req.Preferences {
+				if err := qtx.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+					UserID:    userID,
+					EventType: p.EventType,
+					InApp:     p.InApp,
+					Email:     p.Email,
+					Webhook:   p.Webhook,
+					Digest:    p.Digest,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		settings, err := notificationSettings(ctx, queries, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, settings)
+	}
+}
+
+// SetNotificationWebhookHandler enregistre l'URL et renvoie une seule fois le secret de signature.
+func SetNotificationWebhookHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			URL string `json:"url" binding:"required,max=2000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		parsed, err := notifications.ValidateWebhookURL(ctx, req.URL)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "URL de webhook invalide : https vers une adresse publique uniquement"})
+			return
+		}
+		secret, _, err := generateToken()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		hook, err := queries.UpsertNotificationWebhook(ctx, db.UpsertNotificationWebhookParams{
+			UserID: currentUserID(c),
+			Url:    parsed.String(),
+			Secret: secret,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"url": hook.Url, "secret": hook.Secret})
+	}
+}
+
+func DeleteNotificationWebhookHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		removed, err := queries.DeleteNotificationWebhook(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if removed == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Aucun webhook configuré"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,537 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/metrics"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// L'inscription publique crée toujours un étudiant. Les rôles supérieurs s'obtiennent par une
+// invitation d'un admin (enseignant ou admin) ou par une candidature acceptée (enseignant).
+const userInvitationTTL = 7 * 24 * time.Hour
+
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationRejected = "rejected"
+)
+
+// roleRank ordonne les rôles globaux : une invitation ne fait jamais descendre un compte.
+var roleRank = map[string]int{"student": 0, "teacher": 1, "admin": 2}
+
+type UserInvitationResponse struct {
+	ID         int32   `json:"id"`
+	Email      string  `json:"email"`
+	Role       string  `json:"role"`
+	InvitedBy  *int32  `json:"invited_by"`
+	ExpiresAt  string  `json:"expires_at"`
+	AcceptedAt *string `json:"accepted_at"`
+	// Token n'est renvoyé qu'à la création : seule son empreinte est stockée.
+	Token string `json:"token,omitempty"`
+}
+
+func toUserInvitationResponse(inv db.UserInvitation) UserInvitationResponse {
+	response := UserInvitationResponse{
+		ID:        inv.ID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		ExpiresAt: inv.ExpiresAt.Format(time.RFC3339),
+	}
+	if inv.InvitedBy.Valid {
+		response.InvitedBy = &inv.InvitedBy.Int32
+	}
+	if inv.AcceptedAt.Valid {
+		acceptedAt := inv.AcceptedAt.Time.Format(time.RFC3339)
+		response.AcceptedAt = &acceptedAt
+	}
+	return response
+}
+
+type TeacherApplicationResponse struct {
+	ID         int32   `json:"id"`
+	UserID     int32   `json:"user_id"`
+	Name       string  `json:"name,omitempty"`
+	Email      string  `json:"email,omitempty"`
+	Motivation string  `json:"motivation"`
+	Status     string  `json:"status"`
+	ReviewedBy *int32  `json:"reviewed_by"`
+	ReviewNote *string `json:"review_note"`
+	CreatedAt  string  `json:"created_at"`
+	ReviewedAt *string `json:"reviewed_at"`
+}
+
+func toTeacherApplicationResponse(app db.TeacherApplication) TeacherApplicationResponse {
+	response := TeacherApplicationResponse{
+		ID:         app.ID,
+		UserID:     app.UserID,
+		Motivation: app.Motivation,
+		Status:     app.Status,
+		CreatedAt:  app.CreatedAt.Format(time.RFC3339),
+	}
+	if app.ReviewedBy.Valid {
+		response.ReviewedBy = &app.ReviewedBy.Int32
+	}
+	if app.ReviewNote.Valid {
+		response.ReviewNote = &app.ReviewNote.String
+	}
+	if app.ReviewedAt.Valid {
+		reviewedAt := app.ReviewedAt.Time.Format(time.RFC3339)
+		response.ReviewedAt = &reviewedAt
+	}
+	return response
+}
+
+// submitTeacherApplication enregistre la candidature et prévient les admins. Une candidature
+// déjà en attente renvoie une violation d'unicité (idx_teacher_applications_pending).
+func submitTeacherApplication(ctx context.Context, qtx db.Querier, user db.User, motivation string) (db.TeacherApplication, error) {
+	app, err := qtx.CreateTeacherApplication(ctx, db.CreateTeacherApplicationParams{UserID: user.ID, Motivation: motivation})
+	if err != nil {
+		return app, err
+	}
+	admins, err := qtx.ListAdminIDs(ctx)
+	if err != nil {
+		return app, err
+	}
+	err = notifications.Notify(ctx, qtx, admins, notifications.Notification{
+		Type:  notifications.TeacherApplicationSubmitted,
+		Title: fmt.Sprintf("%s demande à devenir enseignant", user.Name),
+		Body:  motivation,
+		Link:  "/admin/teacher-applications",
+		Data:  gin.H{"application_id": app.ID, "user_id": user.ID},
+	})
+	return app, err
+}
+
+// loadUserInvitation retrouve une invitation par son jeton ; utilisée ou expirée, elle répond 410.
+func loadUserInvitation(c *gin.Context, ctx context.Context, queries db.Querier, token string) (db.UserInvitation, bool) {
+	inv, err := queries.GetUserInvitationByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation introuvable"})
+		return inv, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return inv, false
+	}
+	if inv.AcceptedAt.Valid || !inv.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusGone, gin.H{"error": "Cette invitation a expiré ou a déjà été utilisée"})
+		return inv, false
+	}
+	return inv, true
+}
+
+// CreateUserInvitationHandler (admin) : le jeton renvoyé est à transmettre à la personne invitée,
+// qui crée son compte ou fait évoluer le sien avec.
+func CreateUserInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required,email"`
+			Role  string `json:"role" binding:"required,oneof=teacher admin"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		existing, err := queries.GetUserByEmail(ctx, req.Email)
+		if err == nil && roleRank[existing.Role] >= roleRank[req.Role] {
+			c.JSON(http.StatusConflict, gin.H{"error": "Ce compte a déjà ce rôle"})
+			return
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		token, tokenHash, err := generateToken()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors de la génération de l'invitation"})
+			return
+		}
+		userID := currentUserID(c)
+		inv, err := queries.CreateUserInvitation(ctx, db.CreateUserInvitationParams{
+			Email:     req.Email,
+			Role:      req.Role,
+			TokenHash: tokenHash,
+			InvitedBy: sql.NullInt32{Int32: userID, Valid: userID > 0},
+			ExpiresAt: time.Now().Add(userInvitationTTL),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := toUserInvitationResponse(inv)
+		response.Token = token
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func ListUserInvitationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		invitations, err := queries.ListPendingUserInvitations(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []UserInvitationResponse{}
+		for _, inv := range invitations {
+			response = append(response, toUserInvitationResponse(inv))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// RevokeUserInvitationHandler supprime une invitation pas encore utilisée.
+func RevokeUserInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'invitation invalide"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		deleted, err := queries.DeleteUserInvitation(ctx, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if deleted == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation introuvable ou déjà utilisée"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetUserInvitationHandler (public) : ce que l'écran d'accueil de l'invitation affiche.
+func GetUserInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		inv, ok := loadUserInvitation(c, ctx, queries, c.Param("token"))
+		if !ok {
+			return
+		}
+		_, err := queries.GetUserByEmail(ctx, inv.Email)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"email":          inv.Email,
+			"role":           inv.Role,
+			"expires_at":     inv.ExpiresAt.Format(time.RFC3339),
+			"account_exists": err == nil,
+		})
+	}
+}
+
+// RegisterWithInvitationHandler (public) crée le compte de la personne invitée, avec l'email et
+// le rôle de l'invitation. Si le compte existe déjà, il faut se connecter et passer par
+// AcceptUserInvitationHandler.
+func RegisterWithInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Token    string `json:"token" binding:"required"`
+			Name     string `json:"name" binding:"required"`
+			Password string `json:"password" binding:"required,min=6,max=72"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		inv, ok := loadUserInvitation(c, ctx, queries, req.Token)
+		if !ok {
+			return
+		}
+		hashedPassword, err := hashPassword(req.Password)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors du hash du mot de passe"})
+			return
+		}
+		var user db.CreateUserRow
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			user, err = qtx.CreateUser(ctx, db.CreateUserParams{Name: req.Name, Email: inv.Email, Password: hashedPassword, Role: inv.Role})
+			if repository.IsUniqueViolation(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Un compte existe déjà avec cet email : connectez-vous puis acceptez l'invitation"})
+				return errResponded
+			}
+			if err != nil {
+				return err
+			}
+			accepted, err := qtx.AcceptUserInvitation(ctx, db.AcceptUserInvitationParams{ID: inv.ID, AcceptedBy: sql.NullInt32{Int32: user.ID, Valid: true}})
+			if err != nil {
+				return err
+			}
+			if accepted == 0 {
+				c.JSON(http.StatusGone, gin.H{"error": "Cette invitation a expiré ou a déjà été utilisée"})
+				return errResponded
+			}
+			return qtx.EnsureOrganizationMember(ctx, db.EnsureOrganizationMemberParams{OrganizationID: currentOrganization(c).ID, UserID: user.ID})
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		metrics.Registrations.Inc()
+		c.JSON(http.StatusCreated, user)
+	}
+}
+
+// AcceptUserInvitationHandler fait évoluer le compte connecté, s'il est bien celui invité. Le
+// nouveau rôle figure dans les jetons émis à la prochaine connexion.
+func AcceptUserInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Token string `json:"token" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		inv, ok := loadUserInvitation(c, ctx, queries, req.Token)
+		if !ok {
+			return
+		}
+		userID := currentUserID(c)
+		user, err := queries.GetUserByID(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !strings.EqualFold(user.Email, inv.Email) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Cette invitation est destinée à un autre compte"})
+			return
+		}
+		if roleRank[user.Role] >= roleRank[inv.Role] {
+			c.JSON(http.StatusConflict, gin.H{"error": "Votre compte a déjà ce rôle"})
+			return
+		}
+
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			accepted, err := qtx.AcceptUserInvitation(ctx, db.AcceptUserInvitationParams{ID: inv.ID, AcceptedBy: sql.NullInt32{Int32: userID, Valid: true}})
+			if err != nil {
+				return err
+			}
+			if accepted == 0 {
+				c.JSON(http.StatusGone, gin.H{"error": "Cette invitation a expiré ou a déjà été utilisée"})
+				return errResponded
+			}
+			if _, err := qtx.SetUserRole(ctx, db.SetUserRoleParams{ID: userID, Role: inv.Role}); err != nil {
+				return err
+			}
+			return qtx.EnsureOrganizationMember(ctx, db.EnsureOrganizationMemberParams{OrganizationID: currentOrganization(c).ID, UserID: userID})
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": inv.Role, "message": "Reconnectez-vous pour utiliser votre nouveau rôle"})
+	}
+}
+
+// ApplyForTeacherHandler dépose une candidature au rôle enseignant, examinée par un admin.
+func ApplyForTeacherHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Motivation string `json:"motivation" binding:"max=5000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		// Le rôle est relu en base : celui du jeton peut dater d'avant une promotion.
+		user, err := queries.GetUserByID(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user.Role != "student" {
+			c.JSON(http.StatusConflict, gin.H{"error": "Votre compte a déjà le rôle enseignant ou admin"})
+			return
+		}
+		var app db.TeacherApplication
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			app, err = submitTeacherApplication(ctx, qtx, user, req.Motivation)
+			return err
+		})
+		if repository.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Une candidature est déjà en attente"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, toTeacherApplicationResponse(app))
+	}
+}
+
+func ListMyTeacherApplicationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		apps, err := queries.ListUserTeacherApplications(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []TeacherApplicationResponse{}
+		for _, app := range apps {
+			response = append(response, toTeacherApplicationResponse(app))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ListTeacherApplicationsHandler (admin) : file d'examen, les plus anciennes d'abord.
+// ?status=approved|rejected consulte l'historique.
+func ListTeacherApplicationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := c.DefaultQuery("status", ApplicationPending)
+		if status != ApplicationPending && status != ApplicationApproved && status != ApplicationRejected {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Statut invalide"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		rows, err := queries.ListTeacherApplicationsByStatus(ctx, status)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []TeacherApplicationResponse{}
+		for _, row := range rows {
+			item := toTeacherApplicationResponse(db.TeacherApplication{
+				ID:         row.ID,
+				UserID:     row.UserID,
+				Motivation: row.Motivation,
+				Status:     row.Status,
+				ReviewedBy: row.ReviewedBy,
+				ReviewNote: row.ReviewNote,
+				CreatedAt:  row.CreatedAt,
+				ReviewedAt: row.ReviewedAt,
+			})
+			item.Name, item.Email = row.Name, row.Email
+			response = append(response, item)
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ReviewTeacherApplicationHandler (admin) accepte ou refuse une candidature en attente. Acceptée,
+// elle fait passer le compte enseignant, sauf s'il a entre-temps obtenu un rôle supérieur.
+func ReviewTeacherApplicationHandler(queries repository.Store, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de candidature invalide"})
+			return
+		}
+		var req struct {
+			Note string `json:"note" binding:"max=2000"`
+		}
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		status, title := ApplicationRejected, "Votre candidature enseignant n'a pas été retenue"
+		if approve {
+			status, title = ApplicationApproved, "Votre candidature enseignant est acceptée"
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		reviewerID := currentUserID(c)
+		var app db.TeacherApplication
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			app, err = qtx.ReviewTeacherApplication(ctx, db.ReviewTeacherApplicationParams{
+				ID:         id,
+				Status:     status,
+				ReviewedBy: sql.NullInt32{Int32: reviewerID, Valid: reviewerID > 0},
+				ReviewNote: sql.NullString{String: req.Note, Valid: req.Note != ""},
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Candidature en attente introuvable"})
+				return errResponded
+			}
+			if err != nil {
+				return err
+			}
+			if approve {
+				user, err := qtx.GetUserByID(ctx, app.UserID)
+				if err != nil {
+					return err
+				}
+				if roleRank[user.Role] < roleRank["teacher"] {
+					if _, err := qtx.SetUserRole(ctx, db.SetUserRoleParams{ID: user.ID, Role: "teacher"}); err != nil {
+						return err
+					}
+				}
+			}
+			body := req.Note
+			if approve && body == "" {
+				body = "Reconnectez-vous pour créer vos cours."
+			}
+			return notifications.Notify(ctx, qtx, []int32{app.UserID}, notifications.Notification{
+				Type:  notifications.TeacherApplicationReviewed,
+				Title: title,
+				Body:  body,
+				Link:  "/teacher-applications",
+				Data:  gin.H{"application_id": app.ID, "status": status},
+			})
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toTeacherApplicationResponse(app))
+	}
+}
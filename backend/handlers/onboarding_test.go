@@ -0,0 +1,213 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+)
+
+func TestRegisterAlwaysCreatesStudents(t *testing.T) {
+	r, store := newTestRouter(t)
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+
+	w := do(t, r, http.MethodPost, "/register", "", map[string]string{
+		"name": "Mallory", "email": "mallory@example.com", "password": "secret123", "role": "admin",
+	})
+	expectStatus(t, w, http.StatusForbidden)
+	if _, err := store.GetUserByEmail(context.Background(), "mallory@example.com"); err == nil {
+		t.Fatal("aucun compte ne doit être créé pour une inscription admin")
+	}
+
+	w = do(t, r, http.MethodPost, "/register", "", map[string]string{"name": "Sans rôle", "email": "plain@example.com", "password": "secret123"})
+	expectStatus(t, w, http.StatusCreated)
+
+	// Demander le rôle enseignant crée un étudiant et une candidature en attente.
+	w = do(t, r, http.MethodPost, "/register", "", map[string]string{
+		"name": "Tina", "email": "tina@example.com", "password": "secret123", "role": "teacher", "motivation": "Formatrice Go",
+	})
+	expectStatus(t, w, http.StatusCreated)
+	var body struct {
+		Role               string `json:"role"`
+		TeacherApplication *struct {
+			Status     string `json:"status"`
+			Motivation string `json:"motivation"`
+		} `json:"teacher_application"`
+	}
+	decode(t, w, &body)
+	if body.Role != "student" || body.TeacherApplication == nil || body.TeacherApplication.Status != "pending" {
+		t.Fatalf("réponse inattendue : %+v", body)
+	}
+	if got := store.Notifications(admin.ID); len(got) != 1 || got[0].Type != notifications.TeacherApplicationSubmitted {
+		t.Fatalf("l'admin doit être prévenu de la candidature : %+v", got)
+	}
+}
+
+// invite crée une invitation par l'API admin et renvoie le jeton.
+func invite(t *testing.T, r http.Handler, adminToken, email, role string) string {
+	t.Helper()
+	w := do(t, r, http.MethodPost, "/admin/invitations", adminToken, map[string]string{"email": email, "role": role})
+	expectStatus(t, w, http.StatusCreated)
+	var inv struct {
+		Token string `json:"token"`
+	}
+	decode(t, w, &inv)
+	if inv.Token == "" {
+		t.Fatal("le jeton doit être renvoyé à la création")
+	}
+	return inv.Token
+}
+
+func TestInvitationRegistration(t *testing.T) {
+	r, store := newTestRouter(t)
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+	teacher := seedUser(t, store, "teacher@example.com", "secret123", "teacher")
+	adminToken := tokenFor(t, admin.ID, "admin")
+
+	body := map[string]string{"email": "new@example.com", "role": "teacher"}
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/invitations", tokenFor(t, teacher.ID, "teacher"), body), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/invitations", adminToken, map[string]string{"email": "new@example.com", "role": "student"}), http.StatusBadRequest)
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/invitations", adminToken, map[string]string{"email": "teacher@example.com", "role": "teacher"}), http.StatusConflict)
+
+	token := invite(t, r, adminToken, "new@example.com", "teacher")
+	w := do(t, r, http.MethodGet, "/invitations/"+token, "", nil)
+	expectStatus(t, w, http.StatusOK)
+	var info map[string]any
+	decode(t, w, &info)
+	if info["email"] != "new@example.com" || info["role"] != "teacher" || info["account_exists"] != false {
+		t.Fatalf("invitation inattendue : %v", info)
+	}
+
+	register := map[string]string{"token": token, "name": "Nina", "password": "secret123"}
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/register", "", register), http.StatusCreated)
+	user, err := store.GetUserByEmail(context.Background(), "new@example.com")
+	if err != nil || user.Role != "teacher" {
+		t.Fatalf("compte %+v (%v), attendu un enseignant", user, err)
+	}
+	// Usage unique.
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/register", "", register), http.StatusGone)
+	expectStatus(t, do(t, r, http.MethodGet, "/invitations/"+token, "", nil), http.StatusGone)
+	expectStatus(t, do(t, r, http.MethodGet, "/invitations/inconnu", "", nil), http.StatusNotFound)
+}
+
+func TestInvitationUpgradesExistingAccount(t *testing.T) {
+	r, store := newTestRouter(t)
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+	student := seedUser(t, store, "ana@example.com", "secret123", "student")
+	other := seedUser(t, store, "other@example.com", "secret123", "student")
+
+	token := invite(t, r, tokenFor(t, admin.ID, "admin"), "ana@example.com", "admin")
+	// Le compte existe : l'inscription par invitation est refusée, il faut se connecter.
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/register", "", map[string]string{"token": token, "name": "Ana", "password": "secret123"}), http.StatusConflict)
+
+	accept := map[string]string{"token": token}
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/accept", "", accept), http.StatusUnauthorized)
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/accept", tokenFor(t, other.ID, "student"), accept), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/accept", tokenFor(t, student.ID, "student"), accept), http.StatusOK)
+	user, _ := store.GetUserByEmail(context.Background(), "ana@example.com")
+	if user.Role != "admin" {
+		t.Fatalf("rôle %q, attendu admin", user.Role)
+	}
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/accept", tokenFor(t, student.ID, "admin"), accept), http.StatusGone)
+}
+
+func TestExpiredAndRevokedInvitations(t *testing.T) {
+	r, store := newTestRouter(t)
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+	adminToken := tokenFor(t, admin.ID, "admin")
+
+	sum := sha256.Sum256([]byte("jeton-expire"))
+	_, err := store.CreateUserInvitation(context.Background(), db.CreateUserInvitationParams{
+		Email:     "late@example.com",
+		Role:      "teacher",
+		TokenHash: hex.EncodeToString(sum[:]),
+		InvitedBy: sql.NullInt32{Int32: admin.ID, Valid: true},
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectStatus(t, do(t, r, http.MethodGet, "/invitations/jeton-expire", "", nil), http.StatusGone)
+	expectStatus(t, do(t, r, http.MethodPost, "/invitations/register", "", map[string]string{"token": "jeton-expire", "name": "L", "password": "secret123"}), http.StatusGone)
+
+	token := invite(t, r, adminToken, "revoked@example.com", "teacher")
+	var pending []struct {
+		ID    int32  `json:"id"`
+		Email string `json:"email"`
+		Token string `json:"token"`
+	}
+	w := do(t, r, http.MethodGet, "/admin/invitations", adminToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &pending)
+	if len(pending) != 1 || pending[0].Email != "revoked@example.com" || pending[0].Token != "" {
+		t.Fatalf("invitations en attente : %+v", pending)
+	}
+	path := fmt.Sprintf("/admin/invitations/%d", pending[0].ID)
+	expectStatus(t, do(t, r, http.MethodDelete, path, adminToken, nil), http.StatusNoContent)
+	expectStatus(t, do(t, r, http.MethodDelete, path, adminToken, nil), http.StatusNotFound)
+	expectStatus(t, do(t, r, http.MethodGet, "/invitations/"+token, "", nil), http.StatusNotFound)
+}
+
+func TestTeacherApplicationReview(t *testing.T) {
+	r, store := newTestRouter(t)
+	admin := seedUser(t, store, "admin@example.com", "secret123", "admin")
+	alice := seedUser(t, store, "alice@example.com", "secret123", "student")
+	bob := seedUser(t, store, "bob@example.com", "secret123", "student")
+	adminToken := tokenFor(t, admin.ID, "admin")
+
+	apply := map[string]string{"motivation": "Dix ans d'enseignement"}
+	expectStatus(t, do(t, r, http.MethodPost, "/teacher-applications", tokenFor(t, alice.ID, "student"), apply), http.StatusCreated)
+	expectStatus(t, do(t, r, http.MethodPost, "/teacher-applications", tokenFor(t, alice.ID, "student"), apply), http.StatusConflict)
+	expectStatus(t, do(t, r, http.MethodPost, "/teacher-applications", tokenFor(t, bob.ID, "student"), apply), http.StatusCreated)
+	expectStatus(t, do(t, r, http.MethodPost, "/teacher-applications", tokenFor(t, admin.ID, "admin"), apply), http.StatusConflict)
+
+	expectStatus(t, do(t, r, http.MethodGet, "/admin/teacher-applications", tokenFor(t, alice.ID, "student"), nil), http.StatusForbidden)
+	var queue []struct {
+		ID     int32  `json:"id"`
+		UserID int32  `json:"user_id"`
+		Email  string `json:"email"`
+	}
+	w := do(t, r, http.MethodGet, "/admin/teacher-applications", adminToken, nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &queue)
+	if len(queue) != 2 || queue[0].Email != "alice@example.com" {
+		t.Fatalf("file d'examen : %+v", queue)
+	}
+
+	approve := fmt.Sprintf("/admin/teacher-applications/%d/approve", queue[0].ID)
+	expectStatus(t, do(t, r, http.MethodPost, approve, adminToken, nil), http.StatusOK)
+	expectStatus(t, do(t, r, http.MethodPost, approve, adminToken, nil), http.StatusNotFound)
+	reject := fmt.Sprintf("/admin/teacher-applications/%d/reject", queue[1].ID)
+	expectStatus(t, do(t, r, http.MethodPost, reject, adminToken, map[string]string{"note": "Profil incomplet"}), http.StatusOK)
+
+	ctx := context.Background()
+	if user, _ := store.GetUserByID(ctx, alice.ID); user.Role != "teacher" {
+		t.Fatalf("rôle d'Alice %q, attendu teacher", user.Role)
+	}
+	if user, _ := store.GetUserByID(ctx, bob.ID); user.Role != "student" {
+		t.Fatalf("rôle de Bob %q, attendu student", user.Role)
+	}
+	if got := store.Notifications(bob.ID); len(got) != 1 || got[0].Body != "Profil incomplet" {
+		t.Fatalf("Bob doit être prévenu du refus : %+v", got)
+	}
+
+	var mine []struct {
+		Status string `json:"status"`
+	}
+	w = do(t, r, http.MethodGet, "/teacher-applications/me", tokenFor(t, alice.ID, "student"), nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &mine)
+	if len(mine) != 1 || mine[0].Status != "approved" {
+		t.Fatalf("candidatures d'Alice : %+v", mine)
+	}
+	if len(store.Notifications(admin.ID)) != 2 {
+		t.Fatal("chaque candidature doit prévenir les admins")
+	}
+}
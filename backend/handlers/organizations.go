@@ -0,0 +1,519 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Plusieurs établissements partagent le déploiement. Chaque requête HTTP est rattachée à l'un
+// d'eux par l'en-tête X-Organization (slug), l'établissement par défaut sinon ; cours et
+// cohortes des autres établissements y sont invisibles. Le rôle global « admin » est celui du
+// super-admin : il gère tous les établissements.
+const (
+	OrganizationHeader      = "X-Organization"
+	DefaultOrganizationSlug = "default"
+)
+
+// Rôles au sein d'un établissement (table organization_members).
+const (
+	OrgMember     = "member"
+	OrgInstructor = "instructor"
+	OrgAdmin      = "admin"
+)
+
+var (
+	organizationSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+	colorPattern            = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+type OrganizationResponse struct {
+	ID           int32   `json:"id"`
+	Slug         string  `json:"slug"`
+	Name         string  `json:"name"`
+	LogoURL      *string `json:"logo_url"`
+	PrimaryColor *string `json:"primary_color"`
+	AccentColor  *string `json:"accent_color"`
+	SupportEmail *string `json:"support_email"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+func nullableString(v sql.NullString) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+func toOrganizationResponse(org db.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:           org.ID,
+		Slug:         org.Slug,
+		Name:         org.Name,
+		LogoURL:      nullableString(org.LogoUrl),
+		PrimaryColor: nullableString(org.PrimaryColor),
+		AccentColor:  nullableString(org.AccentColor),
+		SupportEmail: nullableString(org.SupportEmail),
+		UpdatedAt:    org.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// brandingRequest : habillage d'un établissement, modifiable par ses admins.
+type brandingRequest struct {
+	Name         string `json:"name" binding:"required,max=200"`
+	LogoURL      string `json:"logo_url"`
+	PrimaryColor string `json:"primary_color"`
+	AccentColor  string `json:"accent_color"`
+	SupportEmail string `json:"support_email" binding:"omitempty,email"`
+}
+
+func (req brandingRequest) validate() error {
+	if req.LogoURL != "" {
+		u, err := url.Parse(req.LogoURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.New("logo_url doit être une URL http(s)")
+		}
+	}
+	for _, color := range []string{req.PrimaryColor, req.AccentColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			return errors.New("les couleurs s'écrivent au format #RRGGBB")
+		}
+	}
+	return nil
+}
+
+func optionalString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+// ResolveOrganization rattache la requête à l'établissement de l'en-tête X-Organization (404
+// s'il n'existe pas) et réserve sa connexion à la base pour la durée de la requête.
+func ResolveOrganization(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		slug := strings.ToLower(strings.TrimSpace(c.GetHeader(OrganizationHeader)))
+		if slug == "" {
+			slug = DefaultOrganizationSlug
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		org, err := queries.GetOrganizationBySlug(ctx, slug)
+		cancel()
+		if errors.Is(err, sql.ErrNoRows) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Établissement introuvable"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Set("organization", org)
+		tenantCtx, release := repository.WithOrganization(c.Request.Context(), org.ID)
+		defer release()
+		c.Request = c.Request.WithContext(tenantCtx)
+		c.Next()
+	}
+}
+
+// currentOrganization renvoie l'établissement posé par ResolveOrganization.
+func currentOrganization(c *gin.Context) db.Organization {
+	org, _ := c.Get("organization")
+	o, _ := org.(db.Organization)
+	return o
+}
+
+// organizationRole renvoie le rôle de l'utilisateur courant dans l'établissement de la
+// requête, ou "" s'il n'en est pas membre. Le résultat est gardé pour la suite de la requête.
+func organizationRole(c *gin.Context, ctx context.Context, queries db.Querier) (string, error) {
+	if role, ok := c.Get("organization_role"); ok {
+		return role.(string), nil
+	}
+	role, err := queries.GetOrganizationMemberRole(ctx, db.GetOrganizationMemberRoleParams{
+		OrganizationID: currentOrganization(c).ID,
+		UserID:         currentUserID(c),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		role, err = "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	c.Set("organization_role", role)
+	return role, nil
+}
+
+// canAuthorInOrganization : le super-admin, les formateurs et admins de l'établissement, et les
+// enseignants (rôle global) qui en sont membres créent des cours.
+func canAuthorInOrganization(c *gin.Context, ctx context.Context, queries db.Querier) (bool, error) {
+	if currentRole(c) == "admin" {
+		return true, nil
+	}
+	role, err := organizationRole(c, ctx, queries)
+	if err != nil {
+		return false, err
+	}
+	return role == OrgInstructor || role == OrgAdmin || (role != "" && canAuthorCourses(currentRole(c))), nil
+}
+
+// requireOrganizationAdmin répond 403 si l'utilisateur n'administre pas l'établissement.
+func requireOrganizationAdmin(c *gin.Context, ctx context.Context, queries db.Querier) bool {
+	if currentRole(c) == "admin" {
+		return true
+	}
+	role, err := organizationRole(c, ctx, queries)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if role != OrgAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Réservé aux administrateurs de l'établissement"})
+		return false
+	}
+	return true
+}
+
+// GetCurrentOrganizationHandler (public) : nom et habillage de l'établissement, pour le frontend.
+func GetCurrentOrganizationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, toOrganizationResponse(currentOrganization(c)))
+	}
+}
+
+// UpdateCurrentOrganizationHandler (admin de l'établissement) modifie son habillage ; le slug
+// ne change que par l'API super-admin.
+func UpdateCurrentOrganizationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req brandingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := req.validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		org := currentOrganization(c)
+		updated, err := queries.UpdateOrganization(ctx, db.UpdateOrganizationParams{
+			ID:           org.ID,
+			Slug:         org.Slug,
+			Name:         req.Name,
+			LogoUrl:      optionalString(req.LogoURL),
+			PrimaryColor: optionalString(req.PrimaryColor),
+			AccentColor:  optionalString(req.AccentColor),
+			SupportEmail: optionalString(req.SupportEmail),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toOrganizationResponse(updated))
+	}
+}
+
+func ListOrganizationMembersHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		members, err := queries.ListOrganizationMembers(ctx, currentOrganization(c).ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if members == nil {
+			members = []db.ListOrganizationMembersRow{}
+		}
+		c.JSON(http.StatusOK, members)
+	}
+}
+
+// AddOrganizationMemberHandler rattache un compte existant à l'établissement, ou change son rôle
+// s'il en est déjà membre.
+func AddOrganizationMemberHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required,email"`
+			Role  string `json:"role" binding:"required,oneof=member instructor admin"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		user, err := queries.GetUserByEmail(ctx, req.Email)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Aucun compte avec cet email"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		member, err := queries.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{
+			OrganizationID: currentOrganization(c).ID,
+			UserID:         user.ID,
+			Role:           req.Role,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, member)
+	}
+}
+
+func UpdateOrganizationMemberHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+		var req struct {
+			Role string `json:"role" binding:"required,oneof=member instructor admin"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		updated, err := queries.UpdateOrganizationMemberRole(ctx, db.UpdateOrganizationMemberRoleParams{
+			OrganizationID: currentOrganization(c).ID,
+			UserID:         userID,
+			Role:           req.Role,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if updated == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ce compte n'est pas membre de l'établissement"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": req.Role})
+	}
+}
+
+// RemoveOrganizationMemberHandler retire un compte de l'établissement ; ses inscriptions et
+// rôles d'équipe pédagogique dans les cours restent en place.
+func RemoveOrganizationMemberHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if !requireOrganizationAdmin(c, ctx, queries) {
+			return
+		}
+		removed, err := queries.RemoveOrganizationMember(ctx, db.RemoveOrganizationMemberParams{
+			OrganizationID: currentOrganization(c).ID,
+			UserID:         userID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if removed == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ce compte n'est pas membre de l'établissement"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ListMyOrganizationsHandler liste les établissements du compte connecté, pour choisir lequel
+// envoyer dans X-Organization.
+func ListMyOrganizationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		orgs, err := queries.ListUserOrganizations(ctx, currentUserID(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if orgs == nil {
+			orgs = []db.ListUserOrganizationsRow{}
+		}
+		c.JSON(http.StatusOK, orgs)
+	}
+}
+
+// organizationRequest : création ou modification d'un établissement par le super-admin.
+type organizationRequest struct {
+	Slug string `json:"slug" binding:"required"`
+	brandingRequest
+}
+
+func (req organizationRequest) params() (db.CreateOrganizationParams, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !organizationSlugPattern.MatchString(slug) {
+		return db.CreateOrganizationParams{}, errors.New("le slug ne contient que des minuscules, chiffres et tirets (2 à 63 caractères)")
+	}
+	if err := req.validate(); err != nil {
+		return db.CreateOrganizationParams{}, err
+	}
+	return db.CreateOrganizationParams{
+		Slug:         slug,
+		Name:         req.Name,
+		LogoUrl:      optionalString(req.LogoURL),
+		PrimaryColor: optionalString(req.PrimaryColor),
+		AccentColor:  optionalString(req.AccentColor),
+		SupportEmail: optionalString(req.SupportEmail),
+	}, nil
+}
+
+// ListOrganizationsHandler (super-admin) liste tous les établissements.
+func ListOrganizationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		orgs, err := queries.ListOrganizations(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []OrganizationResponse{}
+		for _, org := range orgs {
+			response = append(response, toOrganizationResponse(org))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// CreateOrganizationHandler (super-admin) crée un établissement ; admin_email, facultatif,
+// désigne son premier administrateur.
+func CreateOrganizationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			organizationRequest
+			AdminEmail string `json:"admin_email" binding:"omitempty,email"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		params, err := req.params()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		var org db.Organization
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			var err error
+			org, err = qtx.CreateOrganization(ctx, params)
+			if repository.IsUniqueViolation(err) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Ce slug est déjà utilisé"})
+				return errResponded
+			}
+			if err != nil || req.AdminEmail == "" {
+				return err
+			}
+			admin, err := qtx.GetUserByEmail(ctx, req.AdminEmail)
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Aucun compte avec l'email admin_email"})
+				return errResponded
+			}
+			if err != nil {
+				return err
+			}
+			_, err = qtx.AddOrganizationMember(ctx, db.AddOrganizationMemberParams{OrganizationID: org.ID, UserID: admin.ID, Role: OrgAdmin})
+			return err
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, toOrganizationResponse(org))
+	}
+}
+
+// UpdateOrganizationHandler (super-admin) modifie n'importe quel établissement, slug compris.
+func UpdateOrganizationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'établissement invalide"})
+			return
+		}
+		var req organizationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		params, err := req.params()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		org, err := queries.UpdateOrganization(ctx, db.UpdateOrganizationParams{
+			ID:           id,
+			Slug:         params.Slug,
+			Name:         params.Name,
+			LogoUrl:      params.LogoUrl,
+			PrimaryColor: params.PrimaryColor,
+			AccentColor:  params.AccentColor,
+			SupportEmail: params.SupportEmail,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Établissement introuvable"})
+			return
+		}
+		if repository.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Ce slug est déjà utilisé"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, toOrganizationResponse(org))
+	}
+}
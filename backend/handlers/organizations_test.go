@@ -0,0 +1,141 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// createOrganization crée un établissement par l'API super-admin et renvoie son identifiant.
+func createOrganization(t *testing.T, r http.Handler, superToken string, body map[string]string) int32 {
+	t.Helper()
+	w := do(t, r, http.MethodPost, "/admin/organizations", superToken, body)
+	expectStatus(t, w, http.StatusCreated)
+	var org struct {
+		ID int32 `json:"id"`
+	}
+	decode(t, w, &org)
+	return org.ID
+}
+
+func TestOrganizationIsolation(t *testing.T) {
+	r, store := newTestRouter(t)
+	super := seedUser(t, store, "root@example.com", "secret123", "admin")
+	teacher := seedUser(t, store, "teacher@example.com", "secret123", "teacher")
+	director := seedUser(t, store, "director@example.com", "secret123", "student")
+	superToken := tokenFor(t, super.ID, "admin")
+
+	createOrganization(t, r, superToken, map[string]string{"slug": "ecole-b", "name": "École B", "admin_email": director.Email})
+
+	w := do(t, r, http.MethodPost, "/courses", tokenFor(t, teacher.ID, "teacher"), map[string]string{"title": "Cours A"})
+	expectStatus(t, w, http.StatusCreated)
+	var courseA struct {
+		ID int32 `json:"id"`
+	}
+	decode(t, w, &courseA)
+	pathA := fmt.Sprintf("/courses/%d", courseA.ID)
+
+	// L'enseignant n'est pas membre de l'école B : il n'y crée rien et n'y voit pas son cours.
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPost, "/courses", tokenFor(t, teacher.ID, "teacher"), map[string]string{"title": "Intrus"}), http.StatusForbidden)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodGet, pathA, "", nil), http.StatusNotFound)
+	expectStatus(t, do(t, r, http.MethodGet, pathA, "", nil), http.StatusOK)
+	// Idem pour ce qui se rattache au cours, même pour son équipe.
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodGet, pathA+"/reviews", "", nil), http.StatusNotFound)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodGet, pathA+"/staff", tokenFor(t, teacher.ID, "teacher"), nil), http.StatusNotFound)
+	expectStatus(t, do(t, r, http.MethodGet, pathA+"/staff", tokenFor(t, teacher.ID, "teacher"), nil), http.StatusOK)
+
+	// L'admin de l'établissement y crée des cours sans être enseignant au niveau plateforme.
+	directorToken := tokenFor(t, director.ID, "student")
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPost, "/courses", directorToken, map[string]string{"title": "Cours B"}), http.StatusCreated)
+	expectStatus(t, do(t, r, http.MethodPost, "/courses", directorToken, map[string]string{"title": "Cours B"}), http.StatusForbidden)
+
+	for org, want := range map[string]string{"": "Cours A", "ecole-b": "Cours B"} {
+		w := doIn(t, r, org, http.MethodGet, "/courses", "", nil)
+		expectStatus(t, w, http.StatusOK)
+		var list []struct {
+			Title string `json:"title"`
+		}
+		decode(t, w, &list)
+		if len(list) != 1 || list[0].Title != want {
+			t.Fatalf("catalogue de %q : %+v, attendu %q seul", org, list, want)
+		}
+	}
+	expectStatus(t, doIn(t, r, "inconnue", http.MethodGet, "/courses", "", nil), http.StatusNotFound)
+}
+
+func TestOrganizationAdministration(t *testing.T) {
+	r, store := newTestRouter(t)
+	super := seedUser(t, store, "root@example.com", "secret123", "admin")
+	director := seedUser(t, store, "director@example.com", "secret123", "student")
+	ana := seedUser(t, store, "ana@example.com", "secret123", "student")
+	superToken := tokenFor(t, super.ID, "admin")
+	directorToken := tokenFor(t, director.ID, "student")
+
+	org := map[string]string{"slug": "ecole-b", "name": "École B", "admin_email": director.Email}
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/organizations", directorToken, org), http.StatusForbidden)
+	orgID := createOrganization(t, r, superToken, org)
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/organizations", superToken, org), http.StatusConflict)
+	expectStatus(t, do(t, r, http.MethodPost, "/admin/organizations", superToken, map[string]string{"slug": "École B", "name": "x"}), http.StatusBadRequest)
+
+	// Habillage : réservé aux admins de l'établissement, visible de tous.
+	branding := map[string]string{"name": "École B", "primary_color": "#1a73e8", "logo_url": "https://cdn.example.com/b.png"}
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPut, "/organization", tokenFor(t, ana.ID, "student"), branding), http.StatusForbidden)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPut, "/organization", directorToken, map[string]string{"name": "École B", "accent_color": "rouge"}), http.StatusBadRequest)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPut, "/organization", directorToken, branding), http.StatusOK)
+	w := doIn(t, r, "ecole-b", http.MethodGet, "/organization", "", nil)
+	expectStatus(t, w, http.StatusOK)
+	var got map[string]any
+	decode(t, w, &got)
+	if got["slug"] != "ecole-b" || got["primary_color"] != "#1a73e8" || got["accent_color"] != nil {
+		t.Fatalf("habillage inattendu : %v", got)
+	}
+
+	// Membres : ajout par email, changement de rôle, retrait.
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPost, "/organization/members", directorToken, map[string]string{"email": "absent@example.com", "role": "member"}), http.StatusNotFound)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPost, "/organization/members", directorToken, map[string]string{"email": ana.Email, "role": "member"}), http.StatusCreated)
+	memberPath := fmt.Sprintf("/organization/members/%d", ana.ID)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPut, memberPath, directorToken, map[string]string{"role": "instructor"}), http.StatusOK)
+	ctx := context.Background()
+	role, err := store.GetOrganizationMemberRole(ctx, db.GetOrganizationMemberRoleParams{OrganizationID: orgID, UserID: ana.ID})
+	if err != nil || role != "instructor" {
+		t.Fatalf("rôle d'Ana %q (%v), attendu instructor", role, err)
+	}
+	// L'admin de l'école B n'administre pas l'établissement par défaut.
+	expectStatus(t, do(t, r, http.MethodGet, "/organization/members", directorToken, nil), http.StatusForbidden)
+
+	w = do(t, r, http.MethodGet, "/me/organizations", tokenFor(t, ana.ID, "student"), nil)
+	expectStatus(t, w, http.StatusOK)
+	var mine []struct {
+		Slug string `json:"slug"`
+		Role string `json:"role"`
+	}
+	decode(t, w, &mine)
+	if len(mine) != 2 {
+		t.Fatalf("établissements d'Ana : %+v", mine)
+	}
+
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodDelete, memberPath, directorToken, nil), http.StatusNoContent)
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodDelete, memberPath, directorToken, nil), http.StatusNotFound)
+	// Le super-admin gère les membres de n'importe quel établissement.
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodGet, "/organization/members", superToken, nil), http.StatusOK)
+}
+
+func TestRegistrationJoinsRequestOrganization(t *testing.T) {
+	r, store := newTestRouter(t)
+	super := seedUser(t, store, "root@example.com", "secret123", "admin")
+	orgID := createOrganization(t, r, tokenFor(t, super.ID, "admin"), map[string]string{"slug": "ecole-b", "name": "École B"})
+
+	body := map[string]string{"name": "Léa", "email": "lea@example.com", "password": "secret123"}
+	expectStatus(t, doIn(t, r, "ecole-b", http.MethodPost, "/register", "", body), http.StatusCreated)
+	user, err := store.GetUserByEmail(context.Background(), "lea@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgs, err := store.ListUserOrganizations(context.Background(), user.ID)
+	if err != nil || len(orgs) != 1 || orgs[0].ID != orgID || orgs[0].Role != "member" {
+		t.Fatalf("établissements de Léa : %+v (%v)", orgs, err)
+	}
+}
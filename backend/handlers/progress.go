@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/certificates"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/repository"
+)
+
+type ProgressResponse struct {
+	CourseID         int32   `json:"course_id"`
+	CompletedLessons int32   `json:"completed_lessons"`
+	TotalLessons     int32   `json:"total_lessons"`
+	Ratio            float64 `json:"ratio"`
+	CompletedAt      *string `json:"completed_at"`
+}
+
+func toProgressResponse(courseID int32, p db.GetEnrollmentProgressRow) ProgressResponse {
+	completed := int32(p.CompletedLessons)
+	response := ProgressResponse{CourseID: courseID, CompletedLessons: completed, TotalLessons: p.TotalLessons}
+	if p.TotalLessons > 0 {
+		response.Ratio = float64(completed) / float64(p.TotalLessons)
+	}
+	if p.CompletedAt.Valid {
+		completedAt := p.CompletedAt.Time.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+	return response
+}
+
+// loadProgress renvoie la progression de l'utilisateur courant ; 404 s'il n'est pas inscrit ou si
+// le cours est d'un autre établissement.
+func loadProgress(c *gin.Context, ctx context.Context, queries db.Querier, courseID int32) (db.GetEnrollmentProgressRow, bool) {
+	if _, ok := loadCourse(c, ctx, queries, courseID); !ok {
+		return db.GetEnrollmentProgressRow{}, false
+	}
+	progress, err := queries.GetEnrollmentProgress(ctx, db.GetEnrollmentProgressParams{UserID: currentUserID(c), CourseID: courseID})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Vous n'êtes pas inscrit à ce cours"})
+		return progress, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return progress, false
+	}
+	return progress, true
+}
+
+// loadVisibleLesson vérifie que la leçon appartient à la révision que voit l'étudiant (épinglée, sinon
+// publiée) et renvoie sa progression ; 404 sinon. Une leçon du cours hors de cette révision ne
+// compte pas pour son parcours.
+func loadVisibleLesson(c *gin.Context, ctx context.Context, queries db.Querier, courseID, lessonID int32) (db.GetEnrollmentProgressRow, bool) {
+	progress, ok := loadProgress(c, ctx, queries, courseID)
+	if !ok {
+		return progress, false
+	}
+	if progress.RevisionID.Valid {
+		rev, err := queries.GetCourseRevision(ctx, progress.RevisionID.Int32)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return progress, false
+		}
+		lessons, err := decodeLessons(rev.Lessons)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return progress, false
+		}
+		for _, lesson := range lessons {
+			if lesson.ID == lessonID {
+				return progress, true
+			}
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "Leçon introuvable"})
+	return progress, false
+}
+
+func GetCourseProgressHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		progress, ok := loadProgress(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, toProgressResponse(courseID, progress))
+	}
+}
+
+// CompleteLessonHandler marque une leçon comme terminée et clôt l'inscription à la dernière.
+func CompleteLessonHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		lessonID, ok := parseIDParam(c, "lessonId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de leçon invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadVisibleLesson(c, ctx, queries, courseID, lessonID); !ok {
+			return
+		}
+		userID := currentUserID(c)
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			if err := qtx.CompleteLesson(ctx, db.CompleteLessonParams{UserID: userID, LessonID: lessonID, CourseID: courseID}); err != nil {
+				return err
+			}
+			return gamification.Record(ctx, qtx, gamification.Event{
+				UserID:    userID,
+				CourseID:  courseID,
+				Type:      gamification.EventLessonCompleted,
+				SourceKey: fmt.Sprintf("%s:%d:%d", gamification.EventLessonCompleted, userID, lessonID),
+			})
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		progress, ok := loadProgress(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		if !progress.CompletedAt.Valid && progress.TotalLessons > 0 && progress.CompletedLessons >= int64(progress.TotalLessons) {
+			completedAt := time.Now()
+			// Le certificat est créé avec l'achèvement ; son PDF, les badges et les points sont
+			// produits en tâche de fond.
+			err := queries.InTx(ctx, func(qtx db.Querier) error {
+				if err := qtx.MarkEnrollmentCompleted(ctx, progress.ID); err != nil {
+					return err
+				}
+				if _, _, err := certificates.Issue(ctx, qtx, userID, courseID, completedAt); err != nil {
+					return err
+				}
+				if err := badges.EnqueueCourseAward(ctx, qtx, userID, courseID); err != nil {
+					return err
+				}
+				return gamification.Record(ctx, qtx, gamification.Event{
+					UserID:     userID,
+					CourseID:   courseID,
+					Type:       gamification.EventCourseCompleted,
+					SourceKey:  fmt.Sprintf("%s:%d:%d", gamification.EventCourseCompleted, userID, courseID),
+					OccurredAt: completedAt,
+				})
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			progress.CompletedAt = sql.NullTime{Time: completedAt, Valid: true}
+		}
+		c.JSON(http.StatusOK, toProgressResponse(courseID, progress))
+	}
+}
+
+// activityClockSkew (secondes) absorbe l'écart entre le temps mesuré par la page et l'arrivée des
+// envois ; le temps enregistré ne dépasse jamais le temps réellement écoulé.
+const activityClockSkew = 5
+
+// RecordLessonActivityHandler enregistre du temps passé sur une leçon de la révision visible, pour
+// les statistiques du cours ; la page de leçon l'envoie par tranches de 10 minutes au plus. Une
+// tranche plus longue que le temps écoulé depuis la précédente est refusée (429).
+func RecordLessonActivityHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		lessonID, ok := parseIDParam(c, "lessonId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de leçon invalide"})
+			return
+		}
+		var req struct {
+			Seconds int32 `json:"seconds" binding:"required,min=1,max=600"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadVisibleLesson(c, ctx, queries, courseID, lessonID); !ok {
+			return
+		}
+		recorded, err := queries.RecordLessonActivity(ctx, db.RecordLessonActivityParams{
+			UserID:    currentUserID(c),
+			CourseID:  courseID,
+			LessonID:  lessonID,
+			Seconds:   req.Seconds,
+			ClockSkew: activityClockSkew,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if recorded == 0 {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Plus de temps déclaré que de temps écoulé depuis l'envoi précédent"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository/memory"
+)
+
+// publishLessons crée n leçons, les publie dans une révision du cours et renvoie leurs identifiants.
+func publishLessons(t *testing.T, store *memory.Store, courseID int32, n int) []int32 {
+	t.Helper()
+	ctx := context.Background()
+	var ids []int32
+	var lessons []map[string]any
+	for i := 1; i <= n; i++ {
+		lesson, err := store.CreateLesson(ctx, courseID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, lesson.ID)
+		lessons = append(lessons, map[string]any{"id": lesson.ID, "title": fmt.Sprintf("Leçon %d", i), "body": ""})
+	}
+	raw, err := json.Marshal(lessons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rev, err := store.CreateCourseRevision(ctx, db.CreateCourseRevisionParams{CourseID: courseID, Title: "Réseaux", Lessons: raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.PublishCourseRevision(ctx, db.PublishCourseRevisionParams{ID: courseID, Title: "Réseaux", PublishedRevisionID: sql.NullInt32{Int32: rev.ID, Valid: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestCompleteLessonOutsideVisibleRevision(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ctx := context.Background()
+
+	lessons := publishLessons(t, store, f.courseID, 2)
+	// Leçon du brouillon, pas encore publiée.
+	draft, err := store.CreateLesson(ctx, f.courseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.EnrollInCohort(ctx, db.EnrollInCohortParams{UserID: f.outsider.ID, CourseID: f.courseID}); err != nil {
+		t.Fatal(err)
+	}
+	token := tokenFor(t, f.outsider.ID, "student")
+	complete := func(lessonID int32) string {
+		return fmt.Sprintf("/courses/%d/lessons/%d/complete", f.courseID, lessonID)
+	}
+
+	expectStatus(t, do(t, r, http.MethodPost, complete(draft.ID), token, nil), http.StatusNotFound)
+	// Une complétion antérieure hors révision ne compte pas non plus.
+	if err := store.CompleteLesson(ctx, db.CompleteLessonParams{UserID: f.outsider.ID, LessonID: draft.ID, CourseID: f.courseID}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := do(t, r, http.MethodPost, complete(lessons[0]), token, nil)
+	expectStatus(t, w, http.StatusOK)
+	var progress struct {
+		CompletedLessons int32   `json:"completed_lessons"`
+		TotalLessons     int32   `json:"total_lessons"`
+		CompletedAt      *string `json:"completed_at"`
+	}
+	decode(t, w, &progress)
+	if progress.CompletedLessons != 1 || progress.TotalLessons != 2 || progress.CompletedAt != nil {
+		t.Fatalf("progression : %+v", progress)
+	}
+	if completions, err := store.ListCourseCompletions(ctx, f.courseID); err != nil || len(completions) != 0 {
+		t.Fatalf("inscription close trop tôt : %+v, %v", completions, err)
+	}
+
+	w = do(t, r, http.MethodPost, complete(lessons[1]), token, nil)
+	expectStatus(t, w, http.StatusOK)
+	decode(t, w, &progress)
+	if progress.CompletedLessons != 2 || progress.CompletedAt == nil {
+		t.Fatalf("progression finale : %+v", progress)
+	}
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Part minimale du cours à avoir suivie pour pouvoir le noter.
+const reviewMinProgress = 0.5
+
+// loadReview charge l'avis de l'URL ; celui d'un cours d'un autre établissement est traité comme inexistant.
+func loadReview(c *gin.Context, ctx context.Context, queries db.Querier) (db.CourseReview, bool) {
+	reviewID, ok := parseIDParam(c, "id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'avis invalide"})
+		return db.CourseReview{}, false
+	}
+	review, err := queries.GetCourseReview(ctx, reviewID)
+	var course db.Course
+	if err == nil {
+		course, err = queries.GetCourse(ctx, review.CourseID)
+	}
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Avis introuvable"})
+		return review, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return review, false
+	}
+	return review, true
+}
+
+func ListCourseReviewsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourse(c, ctx, queries, courseID); !ok {
+			return
+		}
+		reviews, err := queries.ListVisibleCourseReviews(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, r := range reviews {
+			var reply gin.H
+			if r.ReplyBody.Valid {
+				reply = gin.H{"body": r.ReplyBody.String, "replied_at": r.RepliedAt.Time.Format(time.RFC3339)}
+			}
+			response = append(response, gin.H{
+				"id":          r.ID,
+				"user_id":     r.UserID,
+				"author_name": r.AuthorName,
+				"rating":      r.Rating,
+				"body":        r.Body,
+				"reply":       reply,
+				"created_at":  r.CreatedAt.Format(time.RFC3339),
+				"updated_at":  r.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SaveMyCourseReviewHandler crée ou modifie l'avis de l'étudiant courant (un seul par cours).
+func SaveMyCourseReviewHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			Rating int32  `json:"rating" binding:"required,min=1,max=5"`
+			Body   string `json:"body" binding:"max=5000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		progress, ok := loadProgress(c, ctx, queries, courseID)
+		if !ok {
+			return
+		}
+		if !progress.CompletedAt.Valid && toProgressResponse(courseID, progress).Ratio < reviewMinProgress {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Terminez au moins la moitié du cours avant de le noter"})
+			return
+		}
+		review, err := queries.UpsertCourseReview(ctx, db.UpsertCourseReviewParams{
+			CourseID: courseID,
+			UserID:   currentUserID(c),
+			Rating:   req.Rating,
+			Body:     req.Body,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, review)
+	}
+}
+
+// ReplyCourseReviewHandler : réponse publique de l'équipe pédagogique.
+func ReplyCourseReviewHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Body string `json:"body" binding:"required,max=5000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		review, ok := loadReview(c, ctx, queries)
+		if !ok {
+			return
+		}
+		if _, ok := loadCourseWithPermission(c, ctx, queries, review.CourseID, permEditContent); !ok {
+			return
+		}
+		userID := currentUserID(c)
+		review, err := queries.ReplyToCourseReview(ctx, db.ReplyToCourseReviewParams{
+			ID:        review.ID,
+			ReplyBody: sql.NullString{String: req.Body, Valid: true},
+			RepliedBy: sql.NullInt32{Int32: userID, Valid: userID > 0},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, review)
+	}
+}
+
+func ReportCourseReviewHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Reason string `json:"reason" binding:"required,max=1000"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		review, ok := loadReview(c, ctx, queries)
+		if !ok {
+			return
+		}
+		err := queries.ReportCourseReview(ctx, db.ReportCourseReviewParams{
+			ReviewID: review.ID,
+			UserID:   currentUserID(c),
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusAccepted)
+	}
+}
+
+func ListReportedReviewsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		reviews, err := queries.ListReportedCourseReviews(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if reviews == nil {
+			reviews = []db.ListReportedCourseReviewsRow{}
+		}
+		c.JSON(http.StatusOK, reviews)
+	}
+}
+
+// SetReviewVisibilityHandler : masquer un avis le retire aussi de la moyenne du cours (trigger SQL).
+func SetReviewVisibilityHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Hidden *bool `json:"hidden" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		review, ok := loadReview(c, ctx, queries)
+		if !ok {
+			return
+		}
+		userID := currentUserID(c)
+		review, err := queries.SetCourseReviewHidden(ctx, db.SetCourseReviewHiddenParams{
+			ID:          review.ID,
+			Hidden:      *req.Hidden,
+			ModeratedBy: sql.NullInt32{Int32: userID, Valid: userID > 0},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, review)
+	}
+}
@@ -0,0 +1,754 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+type LessonContent struct {
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// CourseContent est le contenu versionné d'un cours : ce qu'un brouillon édite et ce qu'une révision fige.
+type CourseContent struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Lessons     []LessonContent `json:"lessons"`
+}
+
+type RevisionResponse struct {
+	ID        int32  `json:"id"`
+	Number    int32  `json:"number"`
+	CreatedBy *int32 `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+	CourseContent
+}
+
+type DraftResponse struct {
+	CourseID  int32   `json:"course_id"`
+	Version   int32   `json:"version"`
+	UpdatedAt *string `json:"updated_at"`
+	CourseContent
+}
+
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type PositionChange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+type LessonChange struct {
+	LessonID int32           `json:"lesson_id"`
+	Title    *FieldChange    `json:"title,omitempty"`
+	Body     *FieldChange    `json:"body,omitempty"`
+	Position *PositionChange `json:"position,omitempty"`
+}
+
+type RevisionDiff struct {
+	From           int32           `json:"from"`
+	To             int32           `json:"to"`
+	Title          *FieldChange    `json:"title,omitempty"`
+	Description    *FieldChange    `json:"description,omitempty"`
+	LessonsAdded   []LessonContent `json:"lessons_added"`
+	LessonsRemoved []LessonContent `json:"lessons_removed"`
+	LessonsChanged []LessonChange  `json:"lessons_changed"`
+}
+
+func decodeLessons(raw json.RawMessage) ([]LessonContent, error) {
+	lessons := []LessonContent{}
+	if len(raw) == 0 {
+		return lessons, nil
+	}
+	if err := json.Unmarshal(raw, &lessons); err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
+
+func revisionContent(rev db.CourseRevision) (CourseContent, error) {
+	lessons, err := decodeLessons(rev.Lessons)
+	if err != nil {
+		return CourseContent{}, err
+	}
+	return CourseContent{Title: rev.Title, Description: rev.Description.String, Lessons: lessons}, nil
+}
+
+func toRevisionResponse(rev db.CourseRevision) (RevisionResponse, error) {
+	content, err := revisionContent(rev)
+	if err != nil {
+		return RevisionResponse{}, err
+	}
+	var createdBy *int32
+	if rev.CreatedBy.Valid {
+		createdBy = &rev.CreatedBy.Int32
+	}
+	return RevisionResponse{
+		ID:            rev.ID,
+		Number:        rev.Number,
+		CreatedBy:     createdBy,
+		CreatedAt:     rev.CreatedAt.Format(time.RFC3339),
+		CourseContent: content,
+	}, nil
+}
+
+func diffField(from, to string) *FieldChange {
+	if from == to {
+		return nil
+	}
+	return &FieldChange{From: from, To: to}
+}
+
+// diffCourseContent compare deux contenus ; les leçons sont appariées par identifiant.
+func diffCourseContent(from, to CourseContent) RevisionDiff {
+	diff := RevisionDiff{
+		Title:          diffField(from.Title, to.Title),
+		Description:    diffField(from.Description, to.Description),
+		LessonsAdded:   []LessonContent{},
+		LessonsRemoved: []LessonContent{},
+		LessonsChanged: []LessonChange{},
+	}
+	fromPos := map[int32]int{}
+	for i, lesson := range from.Lessons {
+		fromPos[lesson.ID] = i
+	}
+	toIDs := map[int32]bool{}
+	for i, lesson := range to.Lessons {
+		toIDs[lesson.ID] = true
+		pos, ok := fromPos[lesson.ID]
+		if !ok {
+			diff.LessonsAdded = append(diff.LessonsAdded, lesson)
+			continue
+		}
+		old := from.Lessons[pos]
+		change := LessonChange{
+			LessonID: lesson.ID,
+			Title:    diffField(old.Title, lesson.Title),
+			Body:     diffField(old.Body, lesson.Body),
+		}
+		if pos != i {
+			change.Position = &PositionChange{From: pos, To: i}
+		}
+		if change.Title != nil || change.Body != nil || change.Position != nil {
+			diff.LessonsChanged = append(diff.LessonsChanged, change)
+		}
+	}
+	for _, lesson := range from.Lessons {
+		if !toIDs[lesson.ID] {
+			diff.LessonsRemoved = append(diff.LessonsRemoved, lesson)
+		}
+	}
+	return diff
+}
+
+func toDraftResponse(draft db.CourseDraft) (DraftResponse, error) {
+	lessons, err := decodeLessons(draft.Lessons)
+	if err != nil {
+		return DraftResponse{}, err
+	}
+	updatedAt := draft.UpdatedAt.Format(time.RFC3339)
+	return DraftResponse{
+		CourseID:      draft.CourseID,
+		Version:       draft.Version,
+		UpdatedAt:     &updatedAt,
+		CourseContent: CourseContent{Title: draft.Title, Description: draft.Description.String, Lessons: lessons},
+	}, nil
+}
+
+// currentDraftVersion renvoie 0 tant qu'aucun brouillon n'existe.
+func currentDraftVersion(ctx context.Context, queries db.Querier, courseID int32) (int32, error) {
+	draft, err := queries.GetCourseDraft(ctx, courseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return draft.Version, err
+}
+
+func parseRevisionNumber(c *gin.Context) (int32, bool) {
+	number, err := strconv.ParseInt(c.Param("number"), 10, 32)
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Numéro de révision invalide"})
+		return 0, false
+	}
+	return int32(number), true
+}
+
+func loadRevision(c *gin.Context, ctx context.Context, queries db.Querier, courseID, number int32) (db.CourseRevision, bool) {
+	rev, err := queries.GetCourseRevisionByNumber(ctx, db.GetCourseRevisionByNumberParams{CourseID: courseID, Number: number})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Révision introuvable"})
+		return rev, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return rev, false
+	}
+	return rev, true
+}
+
+// publishContent fige un contenu dans une nouvelle révision et la rend visible aux étudiants.
+func publishContent(ctx context.Context, qtx db.Querier, courseID, userID int32, content CourseContent) (db.CourseRevision, error) {
+	// Le numéro de révision est calculé à partir du dernier : le verrou sur le cours
+	// sérialise les publications et les restaurations concurrentes.
+	if _, err := qtx.GetCourseForUpdate(ctx, courseID); err != nil {
+		return db.CourseRevision{}, err
+	}
+	lessons, err := json.Marshal(content.Lessons)
+	if err != nil {
+		return db.CourseRevision{}, err
+	}
+	description := sql.NullString{String: content.Description, Valid: content.Description != ""}
+	rev, err := qtx.CreateCourseRevision(ctx, db.CreateCourseRevisionParams{
+		CourseID:    courseID,
+		Title:       content.Title,
+		Description: description,
+		Lessons:     lessons,
+		CreatedBy:   sql.NullInt32{Int32: userID, Valid: userID > 0},
+	})
+	if err != nil {
+		return rev, err
+	}
+	_, err = qtx.PublishCourseRevision(ctx, db.PublishCourseRevisionParams{
+		ID:                  courseID,
+		Title:               content.Title,
+		Description:         description,
+		PublishedRevisionID: sql.NullInt32{Int32: rev.ID, Valid: true},
+	})
+	if err != nil {
+		return rev, err
+	}
+	// Les étudiants sont prévenus par le worker, hors de la transaction de la requête.
+	err = notifications.QueueCourseUpdate(ctx, qtx, courseID, rev.Number, content.Title)
+	return rev, err
+}
+
+// GetCourseDraftHandler sert d'aperçu : sans brouillon, on repart de la version publiée.
+func GetCourseDraftHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent)
+		if !ok {
+			return
+		}
+		draft, err := queries.GetCourseDraft(ctx, courseID)
+		if err == nil {
+			if notModified(c, draftETag(courseID, draft.Version)) {
+				return
+			}
+			response, err := toDraftResponse(draft)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, response)
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// Le brouillon n'existe pas encore : la version 0 sert d'ETag pour sa création.
+		c.Header("ETag", draftETag(courseID, 0))
+
+		content := CourseContent{Title: course.Title, Description: course.Description.String, Lessons: []LessonContent{}}
+		if course.PublishedRevisionID.Valid {
+			rev, err := queries.GetCourseRevision(ctx, course.PublishedRevisionID.Int32)
+			if err == nil {
+				content, err = revisionContent(rev)
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		c.JSON(http.StatusOK, DraftResponse{CourseID: courseID, CourseContent: content})
+	}
+}
+
+func SaveCourseDraftHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			Title       string `json:"title" binding:"required"`
+			Description string `json:"description"`
+			Lessons     []struct {
+				ID    *int32 `json:"id"`
+				Title string `json:"title" binding:"required"`
+				Body  string `json:"body"`
+			} `json:"lessons" binding:"dive"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		version, err := currentDraftVersion(ctx, queries, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !requireIfMatch(c, draftETag(courseID, version)) {
+			return
+		}
+		ids, err := queries.ListLessonIDsByCourse(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		known := map[int32]bool{}
+		for _, id := range ids {
+			known[id] = true
+		}
+
+		userID := currentUserID(c)
+		description := sql.NullString{String: req.Description, Valid: req.Description != ""}
+		updatedBy := sql.NullInt32{Int32: userID, Valid: userID > 0}
+		var draft db.CourseDraft
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			seen := map[int32]bool{}
+			lessons := make([]LessonContent, 0, len(req.Lessons))
+			for _, l := range req.Lessons {
+				var id int32
+				if l.ID != nil {
+					if !known[*l.ID] || seen[*l.ID] {
+						c.JSON(http.StatusBadRequest, gin.H{"error": "Leçon inconnue ou dupliquée : " + strconv.Itoa(int(*l.ID))})
+						return errResponded
+					}
+					id = *l.ID
+				} else {
+					lesson, err := qtx.CreateLesson(ctx, courseID)
+					if err != nil {
+						return err
+					}
+					id = lesson.ID
+				}
+				seen[id] = true
+				lessons = append(lessons, LessonContent{ID: id, Title: l.Title, Body: l.Body})
+			}
+			raw, err := json.Marshal(lessons)
+			if err != nil {
+				return err
+			}
+			// La condition sur la version est revérifiée en base : un autre éditeur a pu écrire entre-temps.
+			if version == 0 {
+				draft, err = qtx.CreateCourseDraft(ctx, db.CreateCourseDraftParams{
+					CourseID:    courseID,
+					Title:       req.Title,
+					Description: description,
+					Lessons:     raw,
+					UpdatedBy:   updatedBy,
+				})
+			} else {
+				draft, err = qtx.UpdateCourseDraft(ctx, db.UpdateCourseDraftParams{
+					CourseID:    courseID,
+					Title:       req.Title,
+					Description: description,
+					Lessons:     raw,
+					UpdatedBy:   updatedBy,
+					Version:     version,
+				})
+			}
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusPreconditionFailed, gin.H{"error": staleResourceMessage})
+				return errResponded
+			}
+			return err
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response, err := toDraftResponse(draft)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("ETag", draftETag(courseID, draft.Version))
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func PublishCourseDraftHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		var rev db.CourseRevision
+		err := queries.InTx(ctx, func(qtx db.Querier) error {
+			draft, err := qtx.GetCourseDraft(ctx, courseID)
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Aucun brouillon à publier"})
+				return errResponded
+			}
+			if err != nil {
+				return err
+			}
+			// On publie exactement la version du brouillon que l'éditeur a prévisualisée.
+			if !requireIfMatch(c, draftETag(courseID, draft.Version)) {
+				return errResponded
+			}
+			lessons, err := decodeLessons(draft.Lessons)
+			if err != nil {
+				return err
+			}
+			deleted, err := qtx.DeleteCourseDraft(ctx, db.DeleteCourseDraftParams{CourseID: courseID, Version: draft.Version})
+			if err != nil {
+				return err
+			}
+			if deleted == 0 {
+				c.JSON(http.StatusPreconditionFailed, gin.H{"error": staleResourceMessage})
+				return errResponded
+			}
+			rev, err = publishContent(ctx, qtx, courseID, currentUserID(c), CourseContent{
+				Title:       draft.Title,
+				Description: draft.Description.String,
+				Lessons:     lessons,
+			})
+			return err
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response, err := toRevisionResponse(rev)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func ListCourseRevisionsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent)
+		if !ok {
+			return
+		}
+		revisions, err := queries.ListCourseRevisions(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []gin.H{}
+		for _, rev := range revisions {
+			var createdBy *int32
+			if rev.CreatedBy.Valid {
+				createdBy = &rev.CreatedBy.Int32
+			}
+			response = append(response, gin.H{
+				"number":     rev.Number,
+				"title":      rev.Title,
+				"created_by": createdBy,
+				"created_at": rev.CreatedAt.Format(time.RFC3339),
+				"published":  course.PublishedRevisionID.Valid && course.PublishedRevisionID.Int32 == rev.ID,
+			})
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func GetCourseRevisionHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		number, ok := parseRevisionNumber(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		rev, ok := loadRevision(c, ctx, queries, courseID, number)
+		if !ok {
+			return
+		}
+		if notModified(c, revisionETag(rev.ID)) {
+			return
+		}
+		response, err := toRevisionResponse(rev)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DiffCourseRevisionsHandler compare la révision :number à ?against= (par défaut la précédente).
+func DiffCourseRevisionsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		number, ok := parseRevisionNumber(c)
+		if !ok {
+			return
+		}
+		against := int64(number - 1)
+		if raw := c.Query("against"); raw != "" {
+			var err error
+			against, err = strconv.ParseInt(raw, 10, 32)
+			if err != nil || against <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Paramètre against invalide"})
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		rev, ok := loadRevision(c, ctx, queries, courseID, number)
+		if !ok {
+			return
+		}
+		to, err := revisionContent(rev)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// La première révision se compare à un cours vide.
+		from := CourseContent{Lessons: []LessonContent{}}
+		if against > 0 {
+			base, ok := loadRevision(c, ctx, queries, courseID, int32(against))
+			if !ok {
+				return
+			}
+			if from, err = revisionContent(base); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		diff := diffCourseContent(from, to)
+		diff.From = int32(against)
+		diff.To = number
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+// RollbackCourseRevisionHandler republie le contenu d'une ancienne révision sous un nouveau numéro.
+func RollbackCourseRevisionHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		number, ok := parseRevisionNumber(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permEditContent); !ok {
+			return
+		}
+		target, ok := loadRevision(c, ctx, queries, courseID, number)
+		if !ok {
+			return
+		}
+		content, err := revisionContent(target)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		var rev db.CourseRevision
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			// Verrouille le cours pour que la comparaison de version et la publication soient atomiques.
+			course, err := qtx.GetCourseForUpdate(ctx, courseID)
+			if err != nil {
+				return err
+			}
+			if !requireIfMatch(c, courseETag(course.ID, course.Version)) {
+				return errResponded
+			}
+			rev, err = publishContent(ctx, qtx, courseID, currentUserID(c), content)
+			return err
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response, err := toRevisionResponse(rev)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+// GetCourseContentHandler renvoie la version épinglée par l'étudiant, sinon la version publiée.
+func GetCourseContentHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, err := queries.GetCourse(ctx, courseID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && !inCurrentOrganization(c, course.OrganizationID)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cours introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		revisionID := course.PublishedRevisionID
+		enrollment, err := queries.GetEnrollment(ctx, db.GetEnrollmentParams{UserID: currentUserID(c), CourseID: courseID})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err == nil && enrollment.PinnedRevisionID.Valid {
+			revisionID = enrollment.PinnedRevisionID
+		}
+		if !revisionID.Valid {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ce cours n'a pas encore de version publiée"})
+			return
+		}
+		// Une révision ne change jamais : son identifiant suffit comme ETag.
+		if notModified(c, revisionETag(revisionID.Int32)) {
+			return
+		}
+		rev, err := queries.GetCourseRevision(ctx, revisionID.Int32)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response, err := toRevisionResponse(rev)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// PinCourseVersionHandler permet à un étudiant inscrit de rester sur la version de ses débuts.
+func PinCourseVersionHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			Pinned *bool `json:"pinned" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourse(c, ctx, queries, courseID); !ok {
+			return
+		}
+		params := db.GetEnrollmentParams{UserID: currentUserID(c), CourseID: courseID}
+		enrollment, err := queries.GetEnrollment(ctx, params)
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Vous n'êtes pas inscrit à ce cours"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if *req.Pinned {
+			if !enrollment.StartedRevisionID.Valid {
+				c.JSON(http.StatusConflict, gin.H{"error": "Aucune version de départ à épingler"})
+				return
+			}
+			enrollment, err = queries.PinEnrollment(ctx, db.PinEnrollmentParams(params))
+		} else {
+			enrollment, err = queries.UnpinEnrollment(ctx, db.UnpinEnrollmentParams(params))
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, enrollment)
+	}
+}
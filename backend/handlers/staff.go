@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const staffInvitationTTL = 7 * 24 * time.Hour
+
+type StaffInvitationResponse struct {
+	ID        int32  `json:"id"`
+	CourseID  int32  `json:"course_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	ExpiresAt string `json:"expires_at"`
+	// Token n'est renvoyé qu'à la création : seule son empreinte est stockée.
+	Token string `json:"token,omitempty"`
+}
+
+func toStaffInvitationResponse(inv db.CourseStaffInvitation) StaffInvitationResponse {
+	return StaffInvitationResponse{
+		ID:        inv.ID,
+		CourseID:  inv.CourseID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		ExpiresAt: inv.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// generateToken renvoie un jeton aléatoire à transmettre à l'utilisateur et son empreinte à stocker.
+func generateToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func ListCourseStaffHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourse(c, ctx, queries, courseID); !ok {
+			return
+		}
+		if currentRole(c) != "admin" {
+			role, err := courseStaffRole(ctx, queries, courseID, currentUserID(c))
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if role == "" {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Réservé à l'équipe pédagogique du cours"})
+				return
+			}
+		}
+		staff, err := queries.ListCourseStaff(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if staff == nil {
+			staff = []db.ListCourseStaffRow{}
+		}
+		c.JSON(http.StatusOK, staff)
+	}
+}
+
+func InviteCourseStaffHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			Email string `json:"email" binding:"required,email"`
+			Role  string `json:"role" binding:"required,oneof=co_instructor ta grader"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageStaff); !ok {
+			return
+		}
+		token, tokenHash, err := generateToken()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors de la génération de l'invitation"})
+			return
+		}
+		userID := currentUserID(c)
+		inv, err := queries.CreateStaffInvitation(ctx, db.CreateStaffInvitationParams{
+			CourseID:  courseID,
+			Email:     strings.ToLower(req.Email),
+			Role:      req.Role,
+			TokenHash: tokenHash,
+			InvitedBy: sql.NullInt32{Int32: userID, Valid: userID > 0},
+			ExpiresAt: time.Now().Add(staffInvitationTTL),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := toStaffInvitationResponse(inv)
+		response.Token = token
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func ListStaffInvitationsHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageStaff); !ok {
+			return
+		}
+		invitations, err := queries.ListPendingStaffInvitations(ctx, courseID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response := []StaffInvitationResponse{}
+		for _, inv := range invitations {
+			response = append(response, toStaffInvitationResponse(inv))
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// AcceptStaffInvitationHandler : l'invitation ne vaut que pour le compte dont l'email a été invité.
+func AcceptStaffInvitationHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Token string `json:"token" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		inv, err := queries.GetStaffInvitationByTokenHash(ctx, hashToken(req.Token))
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation introuvable"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		userID := currentUserID(c)
+		user, err := queries.GetUserByID(ctx, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !strings.EqualFold(user.Email, inv.Email) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Cette invitation est destinée à un autre compte"})
+			return
+		}
+		role, err := courseStaffRole(ctx, queries, inv.CourseID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if role == StaffOwner {
+			c.JSON(http.StatusConflict, gin.H{"error": "Vous êtes déjà propriétaire de ce cours"})
+			return
+		}
+
+		var member db.CourseStaff
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			accepted, err := qtx.AcceptStaffInvitation(ctx, inv.ID)
+			if err != nil {
+				return err
+			}
+			if accepted == 0 {
+				c.JSON(http.StatusGone, gin.H{"error": "Cette invitation a expiré ou a déjà été utilisée"})
+				return errResponded
+			}
+			member, err = qtx.AddCourseStaff(ctx, db.AddCourseStaffParams{CourseID: inv.CourseID, UserID: userID, Role: inv.Role})
+			return err
+		})
+		if errors.Is(err, errResponded) {
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, member)
+	}
+}
+
+func UpdateCourseStaffRoleHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+		var req struct {
+			Role string `json:"role" binding:"required,oneof=co_instructor ta grader"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageStaff); !ok {
+			return
+		}
+		role, err := courseStaffRole(ctx, queries, courseID, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		switch role {
+		case "":
+			c.JSON(http.StatusNotFound, gin.H{"error": "Cet utilisateur ne fait pas partie de l'équipe du cours"})
+			return
+		case StaffOwner:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Utilisez le transfert de propriété pour changer de propriétaire"})
+			return
+		}
+		if _, err := queries.UpdateCourseStaffRole(ctx, db.UpdateCourseStaffRoleParams{CourseID: courseID, UserID: userID, Role: req.Role}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"course_id": courseID, "user_id": userID, "role": req.Role})
+	}
+}
+
+func RemoveCourseStaffHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		userID, ok := parseIDParam(c, "userId")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant d'utilisateur invalide"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		if _, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageStaff); !ok {
+			return
+		}
+		// La requête ne supprime jamais le propriétaire.
+		removed, err := queries.RemoveCourseStaff(ctx, db.RemoveCourseStaffParams{CourseID: courseID, UserID: userID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if removed == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Membre introuvable ou propriétaire du cours"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// TransferCourseOwnershipHandler passe la propriété à un membre existant ; l'ancien propriétaire reste co-formateur.
+func TransferCourseOwnershipHandler(queries repository.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseID, ok := parseIDParam(c, "id")
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Identifiant de cours invalide"})
+			return
+		}
+		var req struct {
+			UserID int32 `json:"user_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		course, ok := loadCourseWithPermission(c, ctx, queries, courseID, permManageStaff)
+		if !ok {
+			return
+		}
+		role, err := courseStaffRole(ctx, queries, courseID, req.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		switch role {
+		case "":
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Le nouveau propriétaire doit d'abord faire partie de l'équipe du cours"})
+			return
+		case StaffOwner:
+			c.JSON(http.StatusConflict, gin.H{"error": "Cet utilisateur est déjà propriétaire"})
+			return
+		}
+
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			// Rétrograder d'abord : l'index unique n'autorise qu'un propriétaire à la fois.
+			if course.AuthorID.Valid {
+				if _, err := qtx.UpdateCourseStaffRole(ctx, db.UpdateCourseStaffRoleParams{
+					CourseID: courseID,
+					UserID:   course.AuthorID.Int32,
+					Role:     StaffCoInstructor,
+				}); err != nil {
+					return err
+				}
+			}
+			if _, err := qtx.UpdateCourseStaffRole(ctx, db.UpdateCourseStaffRoleParams{CourseID: courseID, UserID: req.UserID, Role: StaffOwner}); err != nil {
+				return err
+			}
+			return qtx.SetCourseAuthor(ctx, db.SetCourseAuthorParams{ID: courseID, AuthorID: sql.NullInt32{Int32: req.UserID, Valid: true}})
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"course_id": courseID, "owner_id": req.UserID})
+	}
+}
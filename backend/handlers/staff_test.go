@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository/memory"
+)
+
+// staffFixture : un cours avec son propriétaire, un co-formateur et un TA, plus un étudiant extérieur.
+type staffFixture struct {
+	courseID                          int32
+	owner, coInstructor, ta, outsider db.CreateUserRow
+	admin                             db.CreateUserRow
+}
+
+func newStaffFixture(t *testing.T, store *memory.Store) staffFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := staffFixture{
+		owner:        seedUser(t, store, "owner@example.com", "secret123", "teacher"),
+		coInstructor: seedUser(t, store, "co@example.com", "secret123", "teacher"),
+		ta:           seedUser(t, store, "ta@example.com", "secret123", "student"),
+		outsider:     seedUser(t, store, "outsider@example.com", "secret123", "student"),
+		admin:        seedUser(t, store, "admin@example.com", "secret123", "admin"),
+	}
+	course, err := store.CreateCourse(ctx, db.CreateCourseParams{Title: "Réseaux", OrganizationID: defaultOrgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.courseID = course.ID
+	for _, m := range []struct {
+		id   int32
+		role string
+	}{{f.owner.ID, "owner"}, {f.coInstructor.ID, "co_instructor"}, {f.ta.ID, "ta"}} {
+		if _, err := store.AddCourseStaff(ctx, db.AddCourseStaffParams{CourseID: course.ID, UserID: m.id, Role: m.role}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func TestListCourseStaffAuthorization(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	path := fmt.Sprintf("/courses/%d/staff", f.courseID)
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"anonyme", "", http.StatusUnauthorized},
+		{"hors de l'équipe", tokenFor(t, f.outsider.ID, "student"), http.StatusForbidden},
+		{"TA", tokenFor(t, f.ta.ID, "student"), http.StatusOK},
+		{"propriétaire", tokenFor(t, f.owner.ID, "teacher"), http.StatusOK},
+		{"admin hors équipe", tokenFor(t, f.admin.ID, "admin"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, r, http.MethodGet, path, tt.token, nil)
+			expectStatus(t, w, tt.want)
+			if tt.want == http.StatusOK {
+				var staff []db.ListCourseStaffRow
+				decode(t, w, &staff)
+				if len(staff) != 3 {
+					t.Fatalf("%d membres, attendu 3", len(staff))
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateCourseStaffRoleAuthorization(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	path := fmt.Sprintf("/courses/%d/staff/%d", f.courseID, f.ta.ID)
+	body := map[string]string{"role": "grader"}
+
+	// Seul le propriétaire (ou un admin) gère l'équipe : ni le co-formateur ni le TA.
+	for _, who := range []struct {
+		name  string
+		token string
+	}{
+		{"co-formateur", tokenFor(t, f.coInstructor.ID, "teacher")},
+		{"TA", tokenFor(t, f.ta.ID, "student")},
+		{"hors de l'équipe", tokenFor(t, f.outsider.ID, "student")},
+	} {
+		t.Run(who.name, func(t *testing.T) {
+			expectStatus(t, do(t, r, http.MethodPut, path, who.token, body), http.StatusForbidden)
+		})
+	}
+
+	expectStatus(t, do(t, r, http.MethodPut, path, tokenFor(t, f.owner.ID, "teacher"), body), http.StatusOK)
+	role, err := store.GetCourseStaffRole(context.Background(), db.GetCourseStaffRoleParams{CourseID: f.courseID, UserID: f.ta.ID})
+	if err != nil || role != "grader" {
+		t.Fatalf("rôle %q (%v), attendu grader", role, err)
+	}
+
+	// Le propriétaire ne se change pas par cette route.
+	ownerPath := fmt.Sprintf("/courses/%d/staff/%d", f.courseID, f.owner.ID)
+	expectStatus(t, do(t, r, http.MethodPut, ownerPath, tokenFor(t, f.admin.ID, "admin"), body), http.StatusBadRequest)
+	// Un utilisateur hors de l'équipe n'a pas de rôle à modifier.
+	outsiderPath := fmt.Sprintf("/courses/%d/staff/%d", f.courseID, f.outsider.ID)
+	expectStatus(t, do(t, r, http.MethodPut, outsiderPath, tokenFor(t, f.owner.ID, "teacher"), body), http.StatusNotFound)
+	// Cours inexistant.
+	expectStatus(t, do(t, r, http.MethodPut, fmt.Sprintf("/courses/999/staff/%d", f.ta.ID), tokenFor(t, f.owner.ID, "teacher"), body), http.StatusNotFound)
+}
+
+func TestRemoveCourseStaff(t *testing.T) {
+	r, store := newTestRouter(t)
+	f := newStaffFixture(t, store)
+	ownerToken := tokenFor(t, f.owner.ID, "teacher")
+
+	taPath := fmt.Sprintf("/courses/%d/staff/%d", f.courseID, f.ta.ID)
+	expectStatus(t, do(t, r, http.MethodDelete, taPath, tokenFor(t, f.coInstructor.ID, "teacher"), nil), http.StatusForbidden)
+	expectStatus(t, do(t, r, http.MethodDelete, taPath, ownerToken, nil), http.StatusNoContent)
+	expectStatus(t, do(t, r, http.MethodDelete, taPath, ownerToken, nil), http.StatusNotFound)
+
+	// Le propriétaire ne peut pas être retiré, même par un admin.
+	ownerPath := fmt.Sprintf("/courses/%d/staff/%d", f.courseID, f.owner.ID)
+	expectStatus(t, do(t, r, http.MethodDelete, ownerPath, tokenFor(t, f.admin.ID, "admin"), nil), http.StatusNotFound)
+}
@@ -0,0 +1,7 @@
+package handlers
+
+import "errors"
+
+// errResponded annule une transaction (repository.Store.InTx) dont la réponse HTTP a déjà été
+// écrite dans la fonction de transaction : 400, 404, 412... L'appelant n'a plus qu'à sortir.
+var errResponded = errors.New("réponse déjà envoyée")
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"database/sql"
 	"net/http"
 	"time"
 
@@ -10,18 +9,34 @@ import (
 	"github.com/go-playground/validator/v10"
 	"golang.org/x/crypto/bcrypt"
 	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/metrics"
+	"online-learning-platform-backend/internal/repository"
 )
 
 var validate = validator.New()
 
-// Handler d'inscription utilisateur
-func RegisterUserHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// RegisterResponse : le compte créé et, si l'inscription demandait le rôle enseignant, la
+// candidature déposée à sa place.
+type RegisterResponse struct {
+	db.CreateUserRow
+	TeacherApplication *TeacherApplicationResponse `json:"teacher_application,omitempty"`
+}
+
+// RegisterUserHandler : l'inscription publique crée toujours un étudiant. role=teacher dépose en
+// plus une candidature examinée par un admin ; role=admin est refusé (invitation uniquement).
+func RegisterUserHandler(queries repository.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req struct {
-			Name     string `json:"name" binding:"required"`
-			Email    string `json:"email" binding:"required,email"`
-			Password string `json:"password" binding:"required,min=6,max=72"`
-			Role     string `json:"role" binding:"required,oneof=student teacher admin"`
+			Name       string `json:"name" binding:"required"`
+			Email      string `json:"email" binding:"required,email"`
+			Password   string `json:"password" binding:"required,min=6,max=72"`
+			Role       string `json:"role" binding:"omitempty,oneof=student teacher admin"`
+			Motivation string `json:"motivation" binding:"max=5000"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			if fieldErr, ok := err.(validator.ValidationErrors); ok {
@@ -35,25 +50,56 @@ func RegisterUserHandler(queries *db.Queries, dbConn *sql.DB) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if req.Role == "admin" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Le rôle admin s'obtient uniquement sur invitation"})
+			return
+		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 		defer cancel()
 
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		hashedPassword, err := hashPassword(req.Password)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur lors du hash du mot de passe"})
 			return
 		}
-		user, err := queries.CreateUser(ctx, db.CreateUserParams{
-			Name:     req.Name,
-			Email:    req.Email,
-			Password: string(hashedPassword),
-			Role:     req.Role,
+		var response RegisterResponse
+		err = queries.InTx(ctx, func(qtx db.Querier) error {
+			user, err := qtx.CreateUser(ctx, db.CreateUserParams{
+				Name:     req.Name,
+				Email:    req.Email,
+				Password: hashedPassword,
+				Role:     "student",
+			})
+			if err != nil {
+				return err
+			}
+			// Le compte rejoint l'établissement auprès duquel il s'inscrit.
+			err = qtx.EnsureOrganizationMember(ctx, db.EnsureOrganizationMemberParams{OrganizationID: currentOrganization(c).ID, UserID: user.ID})
+			if err != nil {
+				return err
+			}
+			response.CreateUserRow = user
+			if req.Role != "teacher" {
+				return nil
+			}
+			app, err := submitTeacherApplication(ctx, qtx, db.User{ID: user.ID, Name: user.Name}, req.Motivation)
+			if err != nil {
+				return err
+			}
+			applied := toTeacherApplicationResponse(app)
+			response.TeacherApplication = &applied
+			return nil
 		})
+		if repository.IsUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Un compte existe déjà avec cet email"})
+			return
+		}
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(http.StatusCreated, user)
+		metrics.Registrations.Inc()
+		c.JSON(http.StatusCreated, response)
 	}
 }
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRegisterUser(t *testing.T) {
+	r, store := newTestRouter(t)
+
+	w := do(t, r, http.MethodPost, "/register", "", map[string]string{
+		"name":     "Alice",
+		"email":    "alice@example.com",
+		"password": "secret123",
+		"role":     "student",
+	})
+	expectStatus(t, w, http.StatusCreated)
+
+	var body map[string]any
+	decode(t, w, &body)
+	if body["email"] != "alice@example.com" || body["role"] != "student" {
+		t.Fatalf("réponse inattendue: %v", body)
+	}
+	if _, ok := body["password"]; ok {
+		t.Fatal("le mot de passe ne doit pas être renvoyé")
+	}
+
+	user, err := store.GetUserByEmail(context.Background(), "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("secret123")) != nil {
+		t.Fatal("le mot de passe doit être stocké haché")
+	}
+}
+
+func TestRegisterUserValidation(t *testing.T) {
+	r, _ := newTestRouter(t)
+
+	tests := []struct {
+		name string
+		body map[string]string
+	}{
+		{"email invalide", map[string]string{"name": "A", "email": "pas-un-email", "password": "secret123", "role": "student"}},
+		{"mot de passe trop court", map[string]string{"name": "A", "email": "a@example.com", "password": "abc", "role": "student"}},
+		{"rôle inconnu", map[string]string{"name": "A", "email": "a@example.com", "password": "secret123", "role": "root"}},
+		{"nom manquant", map[string]string{"email": "a@example.com", "password": "secret123", "role": "student"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, r, http.MethodPost, "/register", "", tt.body)
+			expectStatus(t, w, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestRegisterUserDuplicateEmail(t *testing.T) {
+	r, store := newTestRouter(t)
+	seedUser(t, store, "bob@example.com", "secret123", "student")
+
+	w := do(t, r, http.MethodPost, "/register", "", map[string]string{
+		"name":     "Bob bis",
+		"email":    "bob@example.com",
+		"password": "secret456",
+		"role":     "student",
+	})
+	expectStatus(t, w, http.StatusConflict)
+}
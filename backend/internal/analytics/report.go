@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// Seuils de la détection des apprenants en difficulté.
+const (
+	// InactiveAfter : sans activité depuis ce délai, un apprenant qui n'a pas terminé est signalé.
+	InactiveAfter = 14 * 24 * time.Hour
+	// BehindRatio : part de la progression moyenne du cours en dessous de laquelle on est en retard.
+	BehindRatio = 0.5
+)
+
+// Lesson est une leçon de la révision publiée, dans l'ordre du cours.
+type Lesson struct {
+	ID    int32
+	Title string
+}
+
+// FunnelStep : part des inscrits ayant terminé la leçon et perte par rapport à l'étape précédente
+// (les inscrits pour la première leçon).
+type FunnelStep struct {
+	LessonID       int32   `json:"lesson_id"`
+	Position       int     `json:"position"`
+	Title          string  `json:"title"`
+	Completions    int32   `json:"completions"`
+	CompletionRate float64 `json:"completion_rate"`
+	DropOff        float64 `json:"drop_off"`
+	Learners       int32   `json:"learners"`
+	AvgTimeSeconds int64   `json:"avg_time_seconds"`
+}
+
+// Funnel assemble l'entonnoir de complétion des leçons à partir de lesson_stats.
+func Funnel(lessons []Lesson, stats []db.LessonStat, enrolled int64) []FunnelStep {
+	byLesson := make(map[int32]db.LessonStat, len(stats))
+	for _, s := range stats {
+		byLesson[s.LessonID] = s
+	}
+	steps := make([]FunnelStep, 0, len(lessons))
+	previous := 1.0
+	for i, lesson := range lessons {
+		s := byLesson[lesson.ID]
+		step := FunnelStep{LessonID: lesson.ID, Position: i + 1, Title: lesson.Title, Completions: s.Completions, Learners: s.Learners}
+		if enrolled > 0 {
+			step.CompletionRate = float64(s.Completions) / float64(enrolled)
+			step.DropOff = max(previous-step.CompletionRate, 0)
+			previous = step.CompletionRate
+		}
+		if s.Learners > 0 {
+			step.AvgTimeSeconds = s.TimeSpentSeconds / int64(s.Learners)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// Raisons pour lesquelles un apprenant est signalé.
+const (
+	RiskNotStarted = "not_started"
+	RiskInactive   = "inactive"
+	RiskBehind     = "behind"
+)
+
+// RiskReasons qualifie un apprenant renvoyé par ListAtRiskLearners : jamais commencé, ou
+// inactif, et en retard si sa progression est nettement sous la moyenne du cours.
+func RiskReasons(learner db.ListAtRiskLearnersRow, averageLessons float64) []string {
+	if learner.LessonsCompleted == 0 && learner.TimeSpentSeconds == 0 {
+		return []string{RiskNotStarted}
+	}
+	reasons := []string{RiskInactive}
+	if float64(learner.LessonsCompleted) < averageLessons*BehindRatio {
+		reasons = append(reasons, RiskBehind)
+	}
+	return reasons
+}
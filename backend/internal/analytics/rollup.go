@@ -0,0 +1,87 @@
+// Package analytics tient à jour les agrégats des tableaux de bord enseignants (inscriptions par
+// jour, statistiques par leçon et par apprenant) pour que leurs requêtes ne parcourent jamais les
+// tables brutes.
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// JobRollup recalcule les agrégats touchés depuis le passage précédent.
+const JobRollup = "analytics.rollup"
+
+const (
+	rollupName     = "course_stats"
+	rollupSchedule = "@every 5m"
+	// overlap : chaque passage relit un peu avant le précédent, pour rattraper les lignes validées
+	// en retard (transactions longues, décalage d'horloge). Recalculer une clé deux fois est sans effet.
+	overlap = 15 * time.Minute
+)
+
+// Rollup met à jour course_daily_stats, lesson_stats et learner_stats.
+type Rollup struct {
+	queries repository.Store
+}
+
+func NewRollup(queries repository.Store) *Rollup {
+	return &Rollup{queries: queries}
+}
+
+// Register déclare le recalcul auprès du worker et le planifie toutes les 5 minutes.
+func (r *Rollup) Register(w *jobs.Worker) {
+	w.Handle(JobRollup, func(ctx context.Context, job db.Job) error {
+		return r.Run(ctx)
+	})
+	w.Schedule(JobRollup, rollupSchedule, JobRollup, nil)
+}
+
+// Run recalcule, à partir des tables brutes, chaque clé (cours et jour, leçon, apprenant) qui a
+// reçu une ligne depuis le dernier passage ; le premier passage recalcule tout.
+func (r *Rollup) Run(ctx context.Context) error {
+	until := time.Now()
+	return r.queries.InTx(ctx, func(qtx db.Querier) error {
+		since, err := qtx.GetAnalyticsWatermark(ctx, rollupName)
+		if errors.Is(err, sql.ErrNoRows) {
+			since = time.Time{}
+		} else if err != nil {
+			return err
+		} else {
+			since = since.Add(-overlap)
+		}
+		days, err := qtx.RefreshCourseDailyStats(ctx, since)
+		if err != nil {
+			return err
+		}
+		lessons, err := qtx.RefreshLessonStats(ctx, since)
+		if err != nil {
+			return err
+		}
+		// Après les leçons : les apprenants désinscrits encore agrégés y désignent les leçons à recalculer.
+		if _, err := qtx.PruneLearnerStats(ctx); err != nil {
+			return err
+		}
+		learners, err := qtx.RefreshLearnerStats(ctx, since)
+		if err != nil {
+			return err
+		}
+		slog.DebugContext(ctx, "analytics: agrégats recalculés", "days", days, "lessons", lessons, "learners", learners)
+		return qtx.SetAnalyticsWatermark(ctx, db.SetAnalyticsWatermarkParams{Name: rollupName, ProcessedUntil: until})
+	})
+}
+
+// ComputedAt renvoie la date du dernier recalcul, zéro s'il n'a jamais eu lieu.
+func ComputedAt(ctx context.Context, queries db.Querier) (time.Time, error) {
+	at, err := queries.GetAnalyticsWatermark(ctx, rollupName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return at, err
+}
@@ -0,0 +1,169 @@
+package badges
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Types de tâches exécutées par le worker (internal/jobs).
+const (
+	// JobAwardCourse décerne à un étudiant les badges actifs du cours qu'il vient de terminer.
+	JobAwardCourse = "badges.award_course"
+	// JobAwardClass rattrape les étudiants qui avaient déjà terminé le cours d'une classe de
+	// badge nouvelle ou réactivée.
+	JobAwardClass = "badges.award_class"
+)
+
+// EnqueueCourseAward programme l'attribution des badges d'un cours terminé ; à appeler dans la
+// transaction qui enregistre l'achèvement.
+func EnqueueCourseAward(ctx context.Context, queries db.Querier, userID, courseID int32) error {
+	_, err := jobs.Enqueue(ctx, queries, JobAwardCourse, map[string]int32{"user_id": userID, "course_id": courseID},
+		jobs.Options{UniqueKey: fmt.Sprintf("%s:%d:%d", JobAwardCourse, userID, courseID)})
+	return err
+}
+
+// EnqueueClassAward programme le rattrapage d'une classe de badge.
+func EnqueueClassAward(ctx context.Context, queries db.Querier, classID int32) error {
+	_, err := jobs.Enqueue(ctx, queries, JobAwardClass, map[string]int32{"badge_class_id": classID},
+		jobs.Options{UniqueKey: fmt.Sprintf("%s:%d", JobAwardClass, classID)})
+	return err
+}
+
+// Awarder signe et enregistre les credentials, hors des requêtes HTTP.
+type Awarder struct {
+	queries repository.Store
+	key     *Key
+}
+
+func NewAwarder(queries repository.Store, key *Key) *Awarder {
+	return &Awarder{queries: queries, key: key}
+}
+
+// Register déclare les attributions auprès du worker.
+func (a *Awarder) Register(w *jobs.Worker) {
+	w.Handle(JobAwardCourse, func(ctx context.Context, job db.Job) error {
+		var payload struct {
+			UserID   int32 `json:"user_id"`
+			CourseID int32 `json:"course_id"`
+		}
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return a.AwardCourse(ctx, payload.UserID, payload.CourseID)
+	})
+	w.Handle(JobAwardClass, func(ctx context.Context, job db.Job) error {
+		var payload struct {
+			BadgeClassID int32 `json:"badge_class_id"`
+		}
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return a.AwardClass(ctx, payload.BadgeClassID)
+	})
+}
+
+// AwardCourse décerne les badges actifs d'un cours à un étudiant qui l'a terminé.
+func (a *Awarder) AwardCourse(ctx context.Context, userID, courseID int32) error {
+	progress, err := a.queries.GetEnrollmentProgress(ctx, db.GetEnrollmentProgressParams{UserID: userID, CourseID: courseID})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !progress.CompletedAt.Valid) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	classes, err := a.queries.ListActiveCourseBadgeClasses(ctx, courseID)
+	if err != nil {
+		return err
+	}
+	for _, class := range classes {
+		if _, err := a.Award(ctx, class, userID, progress.CompletedAt.Time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AwardClass décerne une classe de badge à tous ceux qui ont déjà terminé son cours.
+func (a *Awarder) AwardClass(ctx context.Context, classID int32) error {
+	class, err := a.queries.GetBadgeClass(ctx, classID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !class.Active {
+		return nil
+	}
+	completions, err := a.queries.ListCourseCompletions(ctx, class.CourseID)
+	if err != nil {
+		return err
+	}
+	for _, completion := range completions {
+		if _, err := a.Award(ctx, class, completion.UserID, completion.CompletedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Award signe et enregistre le credential d'un titulaire, puis le prévient. Sans effet si
+// celui-ci a déjà ce badge : created vaut alors false.
+func (a *Awarder) Award(ctx context.Context, class db.BadgeClass, userID int32, completedAt time.Time) (created bool, err error) {
+	user, err := a.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	org, err := a.queries.GetOrganization(ctx, class.OrganizationID)
+	if err != nil {
+		return false, err
+	}
+	id := uuid.NewString()
+	cred, err := NewCredential(id, class, org, user.Email, completedAt)
+	if err != nil {
+		return false, err
+	}
+	token, err := Sign(a.key, cred)
+	if err != nil {
+		return false, err
+	}
+	document, err := json.Marshal(cred)
+	if err != nil {
+		return false, err
+	}
+	err = a.queries.InTx(ctx, func(qtx db.Querier) error {
+		_, err := qtx.CreateBadgeCredential(ctx, db.CreateBadgeCredentialParams{
+			ID:           id,
+			BadgeClassID: class.ID,
+			UserID:       userID,
+			Credential:   document,
+			Jwt:          token,
+			KeyID:        a.key.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		created = true
+		return notifications.Notify(ctx, qtx, []int32{userID}, notifications.Notification{
+			Type:  notifications.BadgeAwarded,
+			Title: fmt.Sprintf("Nouveau badge : %s", class.Name),
+			Body:  "Retrouvez-le dans votre backpack et exportez-le vers le portefeuille de votre choix.",
+			Link:  "/badges",
+			Data:  map[string]any{"credential_id": id, "badge_class_id": class.ID, "course_id": class.CourseID},
+		})
+	})
+	return created, err
+}
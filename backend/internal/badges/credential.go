@@ -0,0 +1,243 @@
+// Package badges délivre des badges Open Badges 3.0 : des OpenBadgeCredential (W3C Verifiable
+// Credentials) signés avec la clé de la plateforme au format VC-JWT, décernés à la fin d'un cours
+// pour chaque classe de badge active de ce cours.
+package badges
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"online-learning-platform-backend/internal/db"
+)
+
+// Contextes JSON-LD d'un OpenBadgeCredential (Open Badges 3.0.3, VC Data Model 2.0).
+var Contexts = []string{
+	"https://www.w3.org/ns/credentials/v2",
+	"https://purl.imsglobal.org/spec/ob/v3p0/context-3.0.3.json",
+}
+
+type Image struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Profile : l'émetteur, ici l'établissement qui propose le cours.
+type Profile struct {
+	Context []string `json:"@context,omitempty"`
+	ID      string   `json:"id"`
+	Type    []string `json:"type"`
+	Name    string   `json:"name"`
+	Email   string   `json:"email,omitempty"`
+	Image   *Image   `json:"image,omitempty"`
+}
+
+type Criteria struct {
+	Narrative string `json:"narrative"`
+}
+
+// Achievement : la classe de badge.
+type Achievement struct {
+	Context         []string `json:"@context,omitempty"`
+	ID              string   `json:"id"`
+	Type            []string `json:"type"`
+	AchievementType string   `json:"achievementType"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Criteria        Criteria `json:"criteria"`
+	Image           *Image   `json:"image,omitempty"`
+	Creator         *Profile `json:"creator,omitempty"`
+}
+
+// IdentityObject désigne le titulaire par l'empreinte salée de son email, sans l'exposer.
+type IdentityObject struct {
+	Type         string `json:"type"`
+	IdentityHash string `json:"identityHash"`
+	IdentityType string `json:"identityType"`
+	Hashed       bool   `json:"hashed"`
+	Salt         string `json:"salt"`
+}
+
+type Subject struct {
+	Type            []string         `json:"type"`
+	Identifier      []IdentityObject `json:"identifier"`
+	Achievement     Achievement      `json:"achievement"`
+	ActivityEndDate string           `json:"activityEndDate,omitempty"`
+}
+
+type Credential struct {
+	Context           []string `json:"@context"`
+	ID                string   `json:"id"`
+	Type              []string `json:"type"`
+	Issuer            Profile  `json:"issuer"`
+	ValidFrom         string   `json:"validFrom"`
+	Name              string   `json:"name"`
+	CredentialSubject Subject  `json:"credentialSubject"`
+}
+
+// URL construit une adresse publique de l'API (PUBLIC_API_URL) : identifiants des documents
+// JSON-LD, tous déréférençables.
+func URL(path string) string {
+	base := os.Getenv("PUBLIC_API_URL")
+	if base == "" {
+		base = "http://localhost:8080"
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
+func CredentialURL(id string) string { return URL("/badges/credentials/" + id) }
+func AchievementURL(id int32) string { return URL(fmt.Sprintf("/badges/classes/%d", id)) }
+func IssuerURL(orgID int32) string   { return URL(fmt.Sprintf("/badges/issuers/%d", orgID)) }
+func KeyURL(key *Key) string         { return URL("/badges/keys/" + key.ID) }
+
+func imageOf(url string) *Image {
+	if url == "" {
+		return nil
+	}
+	return &Image{ID: url, Type: "Image"}
+}
+
+// IssuerProfile décrit un établissement en émetteur Open Badges.
+func IssuerProfile(org db.Organization) Profile {
+	return Profile{
+		ID:    IssuerURL(org.ID),
+		Type:  []string{"Profile"},
+		Name:  org.Name,
+		Email: org.SupportEmail.String,
+		Image: imageOf(org.LogoUrl.String),
+	}
+}
+
+// AchievementOf décrit une classe de badge.
+func AchievementOf(class db.BadgeClass, org db.Organization) Achievement {
+	issuer := IssuerProfile(org)
+	return Achievement{
+		ID:              AchievementURL(class.ID),
+		Type:            []string{"Achievement"},
+		AchievementType: "Course",
+		Name:            class.Name,
+		Description:     class.Description,
+		Criteria:        Criteria{Narrative: class.Criteria},
+		Image:           imageOf(class.ImageUrl.String),
+		Creator:         &issuer,
+	}
+}
+
+// NewCredential assemble le credential d'un titulaire ; validFrom est la date d'achèvement du cours.
+func NewCredential(id string, class db.BadgeClass, org db.Organization, email string, completedAt time.Time) (Credential, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return Credential{}, err
+	}
+	identity := IdentityObject{
+		Type:         "IdentityObject",
+		IdentityType: "emailAddress",
+		Hashed:       true,
+		Salt:         hex.EncodeToString(salt),
+	}
+	identity.IdentityHash = HashIdentity(email, identity.Salt)
+	return Credential{
+		Context:   Contexts,
+		ID:        CredentialURL(id),
+		Type:      []string{"VerifiableCredential", "OpenBadgeCredential"},
+		Issuer:    IssuerProfile(org),
+		ValidFrom: completedAt.UTC().Format(time.RFC3339),
+		Name:      class.Name,
+		CredentialSubject: Subject{
+			Type:            []string{"AchievementSubject"},
+			Identifier:      []IdentityObject{identity},
+			Achievement:     AchievementOf(class, org),
+			ActivityEndDate: completedAt.UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// HashIdentity : sha256$<hex>, sur l'email en minuscules suivi du sel, comme le prévoit la
+// spécification pour qu'un tiers puisse vérifier un email donné.
+func HashIdentity(email, salt string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email)) + salt))
+	return "sha256$" + hex.EncodeToString(sum[:])
+}
+
+// Sign produit la forme VC-JWT du credential : le document dans la revendication vc, et les
+// revendications enregistrées qui en reprennent l'identifiant, l'émetteur et la date.
+func Sign(key *Key, cred Credential) (string, error) {
+	validFrom, err := time.Parse(time.RFC3339, cred.ValidFrom)
+	if err != nil {
+		return "", err
+	}
+	vc, err := toMap(cred)
+	if err != nil {
+		return "", err
+	}
+	return SignClaims(key, jwt.MapClaims{
+		"iss": cred.Issuer.ID,
+		"jti": cred.ID,
+		"nbf": validFrom.Unix(),
+		"iat": time.Now().Unix(),
+		"vc":  vc,
+	})
+}
+
+// SignClaims signe des revendications avec la clé de la plateforme (JWS RS256), le kid désignant
+// la clé publiée ; les certificats s'en servent aussi pour leur manifeste.
+func SignClaims(key *Key, claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = KeyURL(key)
+	return token.SignedString(key.private)
+}
+
+// ErrUnknownKey : le document a été signé avec une clé absente du trousseau.
+var ErrUnknownKey = errors.New("clé de signature inconnue")
+
+// Verify contrôle la signature d'un VC-JWT avec la clé keyID qui l'a signé (key_id du
+// credential), courante ou retirée, et renvoie le credential qu'il contient.
+func Verify(keys *Keyring, keyID, token string) (json.RawMessage, error) {
+	claims, err := VerifyClaims(keys, keyID, token)
+	if err != nil {
+		return nil, err
+	}
+	if claims["vc"] == nil {
+		return nil, errors.New("revendication vc absente")
+	}
+	return json.Marshal(claims["vc"])
+}
+
+// VerifyClaims contrôle un JWS signé par SignClaims avec la clé keyID et renvoie ses revendications.
+func VerifyClaims(keys *Keyring, keyID, token string) (jwt.MapClaims, error) {
+	public, ok := keys.Public(keyID)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
+		// Seule l'empreinte compte : l'adresse publique de l'API a pu changer depuis la signature.
+		if kid, _ := t.Header["kid"].(string); !strings.HasSuffix(kid, "/"+keyID) {
+			return nil, ErrUnknownKey
+		}
+		return public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("revendications illisibles")
+	}
+	return claims, nil
+}
+
+func toMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	return m, json.Unmarshal(raw, &m)
+}
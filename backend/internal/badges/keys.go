@@ -0,0 +1,191 @@
+package badges
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+)
+
+// Key est la clé de signature de la plateforme. RS256 est l'algorithme que toute implémentation
+// Open Badges 3.0 doit accepter pour les VC-JWT.
+type Key struct {
+	private *rsa.PrivateKey
+	// ID est l'empreinte JWK (RFC 7638) de la clé publique.
+	ID string
+}
+
+// NewKey enveloppe une clé RSA existante.
+func NewKey(private *rsa.PrivateKey) *Key {
+	return &Key{private: private, ID: thumbprint(&private.PublicKey)}
+}
+
+// GenerateKey tire une nouvelle clé RSA 2048 bits.
+func GenerateKey() (*Key, error) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(private), nil
+}
+
+// LoadKey lit la clé PEM (PKCS#8 ou PKCS#1) de BADGE_SIGNING_KEY_FILE, ou son contenu dans
+// BADGE_SIGNING_KEY. À défaut, une clé éphémère n'est générée qu'avec APP_ENV=development ou
+// test : les badges délivrés ne sont alors plus vérifiables après un redémarrage.
+func LoadKey() (*Key, error) {
+	raw := []byte(os.Getenv("BADGE_SIGNING_KEY"))
+	if path := os.Getenv("BADGE_SIGNING_KEY_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("clé de signature des badges: %w", err)
+		}
+		raw = content
+	}
+	if len(raw) == 0 {
+		switch os.Getenv("APP_ENV") {
+		case "development", "test":
+			slog.Warn("badges: clé de signature éphémère, définir BADGE_SIGNING_KEY_FILE")
+			return GenerateKey()
+		}
+		return nil, errors.New("clé de signature des badges absente (BADGE_SIGNING_KEY_FILE), ou APP_ENV=development pour une clé éphémère")
+	}
+	return ParseKey(raw)
+}
+
+// ParseKey lit une clé privée RSA au format PEM.
+func ParseKey(raw []byte) (*Key, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("clé de signature des badges: PEM attendu")
+	}
+	if private, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewKey(private), nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("clé de signature des badges: %w", err)
+	}
+	private, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("clé de signature des badges: clé RSA attendue")
+	}
+	return NewKey(private), nil
+}
+
+// JWK : la clé publique au format JSON Web Key, publiée pour la vérification.
+func (k *Key) JWK() map[string]string {
+	return jwkOf(k.public())
+}
+
+func (k *Key) public() *rsa.PublicKey {
+	return &k.private.PublicKey
+}
+
+// Keyring réunit la clé courante, qui signe, et les clés publiques des clés retirées : un badge
+// se vérifie avec la clé qui l'a signé, même après une rotation. Toutes sont publiées.
+type Keyring struct {
+	Current *Key
+	retired []*rsa.PublicKey
+}
+
+func NewKeyring(current *Key, retired ...*rsa.PublicKey) *Keyring {
+	return &Keyring{Current: current, retired: retired}
+}
+
+// LoadKeyring lit la clé courante (LoadKey) et les clés publiques retirées de
+// BADGE_RETIRED_KEYS_FILE : des blocs PEM PUBLIC KEY mis bout à bout.
+func LoadKeyring() (*Keyring, error) {
+	current, err := LoadKey()
+	if err != nil {
+		return nil, err
+	}
+	path := os.Getenv("BADGE_RETIRED_KEYS_FILE")
+	if path == "" {
+		return NewKeyring(current), nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("clés retirées des badges: %w", err)
+	}
+	retired, err := ParsePublicKeys(raw)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyring(current, retired...), nil
+}
+
+// ParsePublicKeys lit une suite de clés publiques RSA au format PEM (PKIX ou PKCS#1).
+func ParsePublicKeys(raw []byte) ([]*rsa.PublicKey, error) {
+	var keys []*rsa.PublicKey
+	for {
+		var block *pem.Block
+		block, raw = pem.Decode(raw)
+		if block == nil {
+			return keys, nil
+		}
+		if public, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+			keys = append(keys, public)
+			continue
+		}
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("clés retirées des badges: %w", err)
+		}
+		public, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("clés retirées des badges: clé RSA attendue")
+		}
+		keys = append(keys, public)
+	}
+}
+
+// Public renvoie la clé publique d'empreinte kid, courante ou retirée.
+func (r *Keyring) Public(kid string) (*rsa.PublicKey, bool) {
+	if kid == r.Current.ID {
+		return r.Current.public(), true
+	}
+	for _, public := range r.retired {
+		if thumbprint(public) == kid {
+			return public, true
+		}
+	}
+	return nil, false
+}
+
+// JWKS : toutes les clés publiques, la courante en premier.
+func (r *Keyring) JWKS() []map[string]string {
+	keys := []map[string]string{r.Current.JWK()}
+	for _, public := range r.retired {
+		keys = append(keys, jwkOf(public))
+	}
+	return keys
+}
+
+func jwkOf(public *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": thumbprint(public),
+		"use": "sig",
+		"alg": "RS256",
+		"n":   b64(public.N.Bytes()),
+		"e":   b64(big.NewInt(int64(public.E)).Bytes()),
+	}
+}
+
+func thumbprint(public *rsa.PublicKey) string {
+	// Membres obligatoires, dans l'ordre lexicographique et sans espaces (RFC 7638).
+	canonical := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, b64(big.NewInt(int64(public.E)).Bytes()), b64(public.N.Bytes()))
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:])
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,84 @@
+// Package certificates délivre les certificats de réussite des cours : identifiant public
+// vérifiable, modèle personnalisable par l'équipe du cours, PDF généré en tâche de fond avec un
+// QR code pointant vers la page de vérification, et révocation.
+package certificates
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+)
+
+const (
+	// JobGenerate produit le PDF d'un certificat en attente (internal/jobs).
+	JobGenerate = "certificates.generate"
+
+	StatusPending = "pending"
+	StatusIssued  = "issued"
+)
+
+// Alphabet sans caractères ambigus (0/O, 1/I) : l'identifiant se recopie depuis un papier.
+const idAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewID tire un identifiant public de la forme XXXX-XXXX-XXXX (60 bits d'aléa).
+func NewID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, v := range buf {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteByte(idAlphabet[int(v)%len(idAlphabet)])
+	}
+	return b.String(), nil
+}
+
+// NormalizeID accepte un identifiant saisi à la main (minuscules, espaces).
+func NormalizeID(id string) string {
+	return strings.ToUpper(strings.TrimSpace(id))
+}
+
+// Issue crée le certificat d'un étudiant qui vient de terminer un cours et enfile sa
+// génération ; à appeler dans la transaction qui enregistre l'achèvement. Un étudiant n'a qu'un
+// certificat par cours : created vaut false s'il existait déjà.
+func Issue(ctx context.Context, queries db.Querier, userID, courseID int32, completedAt time.Time) (cert db.Certificate, created bool, err error) {
+	id, err := NewID()
+	if err != nil {
+		return db.Certificate{}, false, err
+	}
+	cert, err = queries.CreateCertificate(ctx, db.CreateCertificateParams{
+		ID:          id,
+		UserID:      userID,
+		CourseID:    courseID,
+		CompletedAt: completedAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Certificate{}, false, nil
+	}
+	if err != nil {
+		return db.Certificate{}, false, err
+	}
+	if _, err := jobs.Enqueue(ctx, queries, JobGenerate, map[string]string{"id": cert.ID}, jobs.Options{UniqueKey: JobGenerate + ":" + cert.ID}); err != nil {
+		return db.Certificate{}, false, err
+	}
+	return cert, true, nil
+}
+
+// VerifyURL est l'adresse publique de vérification imprimée dans le QR code.
+func VerifyURL(id string) string {
+	base := os.Getenv("PUBLIC_API_URL")
+	if base == "" {
+		base = "http://localhost:8080"
+	}
+	return strings.TrimRight(base, "/") + "/certificates/" + id + "/verify"
+}
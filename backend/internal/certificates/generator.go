@@ -0,0 +1,116 @@
+package certificates
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Generator produit les PDF des certificats en attente, hors des requêtes HTTP, et signe leur
+// manifeste avec la clé courante de la plateforme.
+type Generator struct {
+	queries repository.Store
+	key     *badges.Key
+}
+
+func NewGenerator(queries repository.Store, key *badges.Key) *Generator {
+	return &Generator{queries: queries, key: key}
+}
+
+// Register déclare la génération auprès du worker.
+func (g *Generator) Register(w *jobs.Worker) {
+	w.Handle(JobGenerate, func(ctx context.Context, job db.Job) error {
+		var payload struct {
+			ID string `json:"id"`
+		}
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return g.Generate(ctx, payload.ID)
+	})
+}
+
+// TemplateFor renvoie le modèle du cours, ou le modèle par défaut.
+func TemplateFor(ctx context.Context, queries db.Querier, courseID int32) (Template, error) {
+	row, err := queries.GetCertificateTemplate(ctx, courseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultTemplate, nil
+	}
+	if err != nil {
+		return Template{}, err
+	}
+	return TemplateFromDB(row), nil
+}
+
+// DataFor reprend les informations figées à la création du certificat.
+func DataFor(cert db.Certificate) Data {
+	return Data{
+		ID:         cert.ID,
+		Name:       cert.RecipientName,
+		Course:     cert.CourseTitle,
+		Instructor: cert.InstructorName,
+		Date:       FormatDate(cert.CompletedAt),
+	}
+}
+
+// Generate rend le PDF d'un certificat en attente, l'enregistre avec son empreinte SHA-256 et son
+// manifeste signé, et prévient l'étudiant. Sans effet sur un certificat déjà délivré : la tâche peut être rejouée.
+func (g *Generator) Generate(ctx context.Context, id string) error {
+	cert, err := g.queries.GetCertificate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cert.Status != StatusPending {
+		return nil
+	}
+	tpl, err := TemplateFor(ctx, g.queries, cert.CourseID)
+	if err != nil {
+		return err
+	}
+	content, err := Render(tpl, DataFor(cert), VerifyURL(cert.ID))
+	if err != nil {
+		// Modèle modifié entre-temps et devenu invalide : le modèle par défaut prend le relais.
+		if content, err = Render(DefaultTemplate, DataFor(cert), VerifyURL(cert.ID)); err != nil {
+			return fmt.Errorf("rendu du certificat %s: %w", cert.ID, err)
+		}
+	}
+	sum := sha256.Sum256(content)
+	manifest, err := SignManifest(g.key, cert, hex.EncodeToString(sum[:]))
+	if err != nil {
+		return err
+	}
+	return g.queries.InTx(ctx, func(qtx db.Querier) error {
+		if err := qtx.SaveCertificateFile(ctx, db.SaveCertificateFileParams{CertificateID: cert.ID, Content: content}); err != nil {
+			return err
+		}
+		issued, err := qtx.MarkCertificateIssued(ctx, db.MarkCertificateIssuedParams{
+			ID:        cert.ID,
+			PdfSha256: sql.NullString{String: hex.EncodeToString(sum[:]), Valid: true},
+			Manifest:  sql.NullString{String: manifest, Valid: true},
+			KeyID:     sql.NullString{String: g.key.ID, Valid: true},
+		})
+		if err != nil || issued == 0 {
+			return err
+		}
+		return notifications.Notify(ctx, qtx, []int32{cert.UserID}, notifications.Notification{
+			Type:      notifications.CertificateIssued,
+			Title:     fmt.Sprintf("Votre certificat pour « %s » est disponible", cert.CourseTitle),
+			Body:      "Téléchargez-le ou partagez son lien de vérification.",
+			Link:      "/certificates/" + cert.ID,
+			Data:      map[string]any{"certificate_id": cert.ID, "course_id": cert.CourseID},
+			DedupeKey: "certificate:" + cert.ID,
+		})
+	})
+}
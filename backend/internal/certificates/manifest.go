@@ -0,0 +1,48 @@
+package certificates
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/db"
+)
+
+// Le PDF n'est pas signé lui-même : un manifeste détaché, JWS RS256 signé avec la clé des badges,
+// atteste ce que le PDF affiche et son empreinte. Il se vérifie hors ligne avec /badges/keys.
+
+// ErrManifestMismatch : le manifeste est bien signé mais atteste un autre certificat ou un autre PDF.
+var ErrManifestMismatch = errors.New("le manifeste ne correspond pas au certificat")
+
+// SignManifest signe le manifeste d'un certificat dont le PDF a l'empreinte pdfSHA256 (hex).
+func SignManifest(key *badges.Key, cert db.Certificate, pdfSHA256 string) (string, error) {
+	return badges.SignClaims(key, jwt.MapClaims{
+		"jti": cert.ID,
+		"iat": time.Now().Unix(),
+		"certificate": map[string]string{
+			"id":              cert.ID,
+			"recipient_name":  cert.RecipientName,
+			"course_title":    cert.CourseTitle,
+			"instructor_name": cert.InstructorName,
+			"completed_at":    cert.CompletedAt.Format("2006-01-02"),
+			"pdf_sha256":      pdfSHA256,
+			"verify_url":      VerifyURL(cert.ID),
+		},
+	})
+}
+
+// VerifyManifest contrôle la signature du manifeste avec la clé qui l'a signé et qu'il atteste
+// bien ce certificat et son PDF.
+func VerifyManifest(keys *badges.Keyring, cert db.Certificate) error {
+	claims, err := badges.VerifyClaims(keys, cert.KeyID.String, cert.Manifest.String)
+	if err != nil {
+		return err
+	}
+	attested, _ := claims["certificate"].(map[string]any)
+	if claims["jti"] != cert.ID || attested["pdf_sha256"] != cert.PdfSha256.String ||
+		attested["recipient_name"] != cert.RecipientName || attested["course_title"] != cert.CourseTitle {
+		return ErrManifestMismatch
+	}
+	return nil
+}
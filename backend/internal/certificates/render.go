@@ -0,0 +1,98 @@
+package certificates
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Render produit le PDF d'un certificat (A4 paysage). Les polices standard du PDF ne couvrent
+// que le jeu Windows-1252, suffisant pour le français ; les autres caractères sont remplacés.
+func Render(tpl Template, data Data, verifyURL string) ([]byte, error) {
+	body, err := tpl.renderBody(data)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := qrcode.Encode(verifyURL, qrcode.Medium, 256)
+	if err != nil {
+		return nil, fmt.Errorf("QR code: %w", err)
+	}
+	signatory := tpl.SignatoryName
+	if signatory == "" {
+		signatory = data.Instructor
+	}
+	r, g, b := parseColor(tpl.AccentColor)
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetCompression(true)
+	// Date fixe : deux rendus du même certificat donnent le même fichier (empreinte stable).
+	pdf.SetCreationDate(time.Unix(0, 0).UTC())
+	pdf.SetModificationDate(time.Unix(0, 0).UTC())
+	pdf.SetTitle(tpl.Title, true)
+	pdf.SetSubject(data.Course, true)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetDrawColor(r, g, b)
+	pdf.SetLineWidth(2)
+	pdf.Rect(10, 10, 277, 190, "D")
+	pdf.SetLineWidth(0.4)
+	pdf.Rect(15, 15, 267, 180, "D")
+
+	pdf.SetTextColor(r, g, b)
+	pdf.SetFont("Helvetica", "B", 30)
+	pdf.SetXY(20, 35)
+	pdf.CellFormat(257, 14, tr(tpl.Title), "", 1, "C", false, 0, "")
+
+	pdf.SetTextColor(40, 40, 40)
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.SetXY(20, 60)
+	pdf.CellFormat(257, 8, tr("Ce certificat atteste que"), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 26)
+	pdf.SetXY(20, 72)
+	pdf.CellFormat(257, 14, tr(data.Name), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.SetXY(40, 95)
+	pdf.MultiCell(217, 8, tr(body), "", "C", false)
+
+	pdf.SetDrawColor(120, 120, 120)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(30, 165, 110, 165)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetXY(30, 167)
+	pdf.CellFormat(80, 6, tr(signatory), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetXY(30, 173)
+	pdf.CellFormat(80, 5, tr(tpl.SignatoryTitle), "", 1, "C", false, 0, "")
+
+	pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+	pdf.ImageOptions("qr", 237, 140, 35, 35, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetXY(167, 177)
+	pdf.CellFormat(105, 5, tr("Certificat n° "+data.ID+" - délivré le "+data.Date), "", 1, "R", false, 0, "")
+	pdf.SetXY(167, 182)
+	pdf.CellFormat(105, 5, verifyURL, "", 1, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseColor lit une couleur #rrggbb déjà validée ; la couleur par défaut sinon.
+func parseColor(hex string) (int, int, int) {
+	if len(hex) != 7 || hex[0] != '#' {
+		hex = DefaultTemplate.AccentColor
+	}
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		v, _ = strconv.ParseUint(DefaultTemplate.AccentColor[1:], 16, 32)
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)
+}
@@ -0,0 +1,108 @@
+package certificates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+const (
+	maxTitleLength = 120
+	maxBodyLength  = 2000
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Data regroupe les champs utilisables dans le corps d'un modèle : {{.Name}}, {{.Course}},
+// {{.Date}}, {{.Instructor}} et {{.ID}}.
+type Data struct {
+	ID         string
+	Name       string
+	Course     string
+	Instructor string
+	Date       string
+}
+
+// Template décrit la mise en forme d'un certificat. Body est un modèle text/template ; les
+// champs vides de signataire reprennent l'enseignant propriétaire du cours.
+type Template struct {
+	Title          string
+	Body           string
+	SignatoryName  string
+	SignatoryTitle string
+	AccentColor    string
+}
+
+// DefaultTemplate s'applique aux cours dont l'équipe n'a pas personnalisé le certificat.
+var DefaultTemplate = Template{
+	Title:          "Certificat de réussite",
+	Body:           "a suivi avec succès le cours « {{.Course}} » et en a validé l'ensemble des leçons le {{.Date}}.",
+	SignatoryTitle: "Enseignant·e responsable",
+	AccentColor:    "#1f4e79",
+}
+
+// SampleData sert à valider un modèle et à en afficher l'aperçu.
+var SampleData = Data{
+	ID:         "ABCD-EFGH-JKLM",
+	Name:       "Camille Martin",
+	Course:     "Introduction à Go",
+	Instructor: "Alex Dupont",
+	Date:       FormatDate(time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC)),
+}
+
+// TemplateFromDB complète le modèle enregistré avec les valeurs par défaut.
+func TemplateFromDB(row db.CertificateTemplate) Template {
+	t := Template{
+		Title:          row.Title,
+		Body:           row.Body,
+		SignatoryName:  row.SignatoryName.String,
+		SignatoryTitle: row.SignatoryTitle.String,
+		AccentColor:    row.AccentColor.String,
+	}
+	if t.AccentColor == "" {
+		t.AccentColor = DefaultTemplate.AccentColor
+	}
+	return t
+}
+
+// Validate refuse un modèle trop long, mal formé ou qui utilise un champ inconnu.
+func (t Template) Validate() error {
+	if strings.TrimSpace(t.Title) == "" || len([]rune(t.Title)) > maxTitleLength {
+		return fmt.Errorf("le titre est obligatoire et limité à %d caractères", maxTitleLength)
+	}
+	if strings.TrimSpace(t.Body) == "" || len([]rune(t.Body)) > maxBodyLength {
+		return fmt.Errorf("le texte est obligatoire et limité à %d caractères", maxBodyLength)
+	}
+	if t.AccentColor != "" && !colorPattern.MatchString(t.AccentColor) {
+		return errors.New("couleur invalide : format attendu #rrggbb")
+	}
+	if _, err := t.renderBody(SampleData); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t Template) renderBody(data Data) (string, error) {
+	tpl, err := template.New("certificat").Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return "", errors.New("texte du certificat invalide : " + err.Error())
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", errors.New("texte du certificat invalide : " + err.Error())
+	}
+	return buf.String(), nil
+}
+
+var frenchMonths = [...]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+
+// FormatDate écrit une date en toutes lettres : « 14 mars 2026 ».
+func FormatDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), frenchMonths[t.Month()-1], t.Year())
+}
@@ -0,0 +1,140 @@
+// Package database ouvre les pools de connexions Postgres de l'application : database/sql sur le
+// driver pgx (pgx/v5/stdlib), utilisé par les requêtes sqlc de internal/db. Les requêtes HTTP
+// passent par un rôle soumis à l'isolation par établissement (RLS), le worker, les migrations et
+// la CLI par un rôle système qui y échappe explicitement, voir CheckRoles.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Config se lit dans l'environnement, voir ConfigFromEnv.
+type Config struct {
+	URL             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// ConfigFromEnv :
+//
+//	DATABASE_URL, ou à défaut DB_HOST (localhost), DB_PORT (5432), DB_USER (postgres),
+//	DB_PASSWORD (postgres), DB_NAME (online_learning) et DB_SSLMODE (disable) ;
+//	DB_MAX_OPEN_CONNS (25), DB_MAX_IDLE_CONNS (10), DB_CONN_MAX_LIFETIME (30m), DB_CONN_MAX_IDLE_TIME (5m).
+func ConfigFromEnv() (Config, error) {
+	return configFromEnv("DATABASE_URL", "DB_USER", "DB_PASSWORD")
+}
+
+// SystemConfigFromEnv : comme ConfigFromEnv, avec DATABASE_SYSTEM_URL, ou à défaut
+// DB_SYSTEM_USER et DB_SYSTEM_PASSWORD sur le même serveur ; sans eux, la configuration de ConfigFromEnv.
+func SystemConfigFromEnv() (Config, error) {
+	if os.Getenv("DATABASE_SYSTEM_URL") == "" && os.Getenv("DB_SYSTEM_USER") == "" {
+		return ConfigFromEnv()
+	}
+	return configFromEnv("DATABASE_SYSTEM_URL", "DB_SYSTEM_USER", "DB_SYSTEM_PASSWORD")
+}
+
+func configFromEnv(urlKey, userKey, passwordKey string) (Config, error) {
+	cfg := Config{
+		URL:             os.Getenv(urlKey),
+		MaxOpenConns:    25,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+	}
+	if cfg.URL == "" {
+		u := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(getEnv(userKey, "postgres"), getEnv(passwordKey, "postgres")),
+			Host:     getEnv("DB_HOST", "localhost") + ":" + getEnv("DB_PORT", "5432"),
+			Path:     getEnv("DB_NAME", "online_learning"),
+			RawQuery: url.Values{"sslmode": {getEnv("DB_SSLMODE", "disable")}}.Encode(),
+		}
+		cfg.URL = u.String()
+	}
+	for name, target := range map[string]*int{"DB_MAX_OPEN_CONNS": &cfg.MaxOpenConns, "DB_MAX_IDLE_CONNS": &cfg.MaxIdleConns} {
+		if raw := os.Getenv(name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				return cfg, fmt.Errorf("%s invalide : %q", name, raw)
+			}
+			*target = n
+		}
+	}
+	for name, target := range map[string]*time.Duration{"DB_CONN_MAX_LIFETIME": &cfg.ConnMaxLifetime, "DB_CONN_MAX_IDLE_TIME": &cfg.ConnMaxIdleTime} {
+		if raw := os.Getenv(name); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil || d < 0 {
+				return cfg, fmt.Errorf("%s invalide : %q", name, raw)
+			}
+			*target = d
+		}
+	}
+	return cfg, nil
+}
+
+// Open crée le pool. La connexion n'est pas vérifiée ici : le serveur démarre même si la base
+// est encore indisponible, /readyz le signale.
+func Open(cfg Config) (*sql.DB, error) {
+	pool, err := sql.Open("pgx", cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return pool, nil
+}
+
+// Ping vérifie que la base répond, pour les outils en ligne de commande.
+func Ping(ctx context.Context, pool *sql.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return pool.PingContext(ctx)
+}
+
+// BypassesRLS indique si le rôle de la connexion échappe aux politiques RLS (superutilisateur ou BYPASSRLS).
+func BypassesRLS(ctx context.Context, pool *sql.DB) (bool, error) {
+	var bypass bool
+	err := pool.QueryRowContext(ctx, "SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").Scan(&bypass)
+	return bypass, err
+}
+
+// CheckRoles refuse de démarrer si le pool des requêtes HTTP échappe à l'isolation par
+// établissement, ou si le pool système y est soumis (le worker ne verrait alors aucune ligne).
+func CheckRoles(ctx context.Context, app, system *sql.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	bypass, err := BypassesRLS(ctx, app)
+	if err != nil {
+		return fmt.Errorf("rôle applicatif : %w", err)
+	}
+	if bypass {
+		return fmt.Errorf("le rôle de DB_USER échappe aux politiques RLS (superutilisateur ou BYPASSRLS) : utiliser un rôle applicatif dédié")
+	}
+	bypass, err = BypassesRLS(ctx, system)
+	if err != nil {
+		return fmt.Errorf("rôle système : %w", err)
+	}
+	if !bypass {
+		return fmt.Errorf("le rôle de DB_SYSTEM_USER doit avoir BYPASSRLS pour le worker et les migrations")
+	}
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
@@ -11,19 +11,106 @@ import (
 )
 
 const createCourse = `-- name: CreateCourse :one
-INSERT INTO courses (title, description, author_id)
-VALUES ($1, $2, $3)
-RETURNING id, title, description, created_at, updated_at, author_id
+INSERT INTO courses (title, description, author_id, organization_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
 `
 
 type CreateCourseParams struct {
-	Title       string         `json:"title"`
-	Description sql.NullString `json:"description"`
-	AuthorID    sql.NullInt32  `json:"author_id"`
+	Title          string         `json:"title"`
+	Description    sql.NullString `json:"description"`
+	AuthorID       sql.NullInt32  `json:"author_id"`
+	OrganizationID int32          `json:"organization_id"`
 }
 
 func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Course, error) {
-	row := q.queryRow(ctx, q.createCourseStmt, createCourse, arg.Title, arg.Description, arg.AuthorID)
+	row := q.queryRow(ctx, q.createCourseStmt, createCourse,
+		arg.Title,
+		arg.Description,
+		arg.AuthorID,
+		arg.OrganizationID,
+	)
+	var i Course
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthorID,
+		&i.PublishedRevisionID,
+		&i.Version,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingUpdatedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getCatalogVersion = `-- name: GetCatalogVersion :one
+SELECT COUNT(*) AS total,
+       COALESCE(SUM(version), 0)::bigint AS version_sum,
+       COALESCE(MAX(id), 0)::int AS max_id,
+       COALESCE(EXTRACT(EPOCH FROM MAX(rating_updated_at)), 0)::bigint AS ratings_stamp
+FROM courses
+WHERE organization_id = $1
+`
+
+type GetCatalogVersionRow struct {
+	Total        int64 `json:"total"`
+	VersionSum   int64 `json:"version_sum"`
+	MaxID        int32 `json:"max_id"`
+	RatingsStamp int64 `json:"ratings_stamp"`
+}
+
+func (q *Queries) GetCatalogVersion(ctx context.Context, organizationID int32) (GetCatalogVersionRow, error) {
+	row := q.queryRow(ctx, q.getCatalogVersionStmt, getCatalogVersion, organizationID)
+	var i GetCatalogVersionRow
+	err := row.Scan(
+		&i.Total,
+		&i.VersionSum,
+		&i.MaxID,
+		&i.RatingsStamp,
+	)
+	return i, err
+}
+
+const getCourse = `-- name: GetCourse :one
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
+FROM courses
+WHERE id = $1
+`
+
+func (q *Queries) GetCourse(ctx context.Context, id int32) (Course, error) {
+	row := q.queryRow(ctx, q.getCourseStmt, getCourse, id)
+	var i Course
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthorID,
+		&i.PublishedRevisionID,
+		&i.Version,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingUpdatedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getCourseForUpdate = `-- name: GetCourseForUpdate :one
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
+FROM courses
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCourseForUpdate(ctx context.Context, id int32) (Course, error) {
+	row := q.queryRow(ctx, q.getCourseForUpdateStmt, getCourseForUpdate, id)
 	var i Course
 	err := row.Scan(
 		&i.ID,
@@ -32,18 +119,25 @@ func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Cou
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.AuthorID,
+		&i.PublishedRevisionID,
+		&i.Version,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingUpdatedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
 const listCourses = `-- name: ListCourses :many
-SELECT id, title, description, created_at, updated_at, author_id
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
 FROM courses
+WHERE organization_id = $1
 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListCourses(ctx context.Context) ([]Course, error) {
-	rows, err := q.query(ctx, q.listCoursesStmt, listCourses)
+func (q *Queries) ListCourses(ctx context.Context, organizationID int32) ([]Course, error) {
+	rows, err := q.query(ctx, q.listCoursesStmt, listCourses, organizationID)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +152,12 @@ func (q *Queries) ListCourses(ctx context.Context) ([]Course, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.AuthorID,
+			&i.PublishedRevisionID,
+			&i.Version,
+			&i.RatingCount,
+			&i.RatingSum,
+			&i.RatingUpdatedAt,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -71,3 +171,42 @@ func (q *Queries) ListCourses(ctx context.Context) ([]Course, error) {
 	}
 	return items, nil
 }
+
+const publishCourseRevision = `-- name: PublishCourseRevision :one
+UPDATE courses
+SET title = $2, description = $3, published_revision_id = $4, version = version + 1, updated_at = NOW()
+WHERE id = $1
+RETURNING id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
+`
+
+type PublishCourseRevisionParams struct {
+	ID                  int32          `json:"id"`
+	Title               string         `json:"title"`
+	Description         sql.NullString `json:"description"`
+	PublishedRevisionID sql.NullInt32  `json:"published_revision_id"`
+}
+
+func (q *Queries) PublishCourseRevision(ctx context.Context, arg PublishCourseRevisionParams) (Course, error) {
+	row := q.queryRow(ctx, q.publishCourseRevisionStmt, publishCourseRevision,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.PublishedRevisionID,
+	)
+	var i Course
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuthorID,
+		&i.PublishedRevisionID,
+		&i.Version,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.RatingUpdatedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
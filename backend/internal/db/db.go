@@ -24,41 +24,1689 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.acceptStaffInvitationStmt, err = db.PrepareContext(ctx, acceptStaffInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query AcceptStaffInvitation: %w", err)
+	}
+	if q.acceptUserInvitationStmt, err = db.PrepareContext(ctx, acceptUserInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query AcceptUserInvitation: %w", err)
+	}
+	if q.addConversationParticipantStmt, err = db.PrepareContext(ctx, addConversationParticipant); err != nil {
+		return nil, fmt.Errorf("error preparing query AddConversationParticipant: %w", err)
+	}
+	if q.addCourseStaffStmt, err = db.PrepareContext(ctx, addCourseStaff); err != nil {
+		return nil, fmt.Errorf("error preparing query AddCourseStaff: %w", err)
+	}
+	if q.addOrganizationMemberStmt, err = db.PrepareContext(ctx, addOrganizationMember); err != nil {
+		return nil, fmt.Errorf("error preparing query AddOrganizationMember: %w", err)
+	}
+	if q.advanceJobScheduleStmt, err = db.PrepareContext(ctx, advanceJobSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query AdvanceJobSchedule: %w", err)
+	}
+	if q.awardAchievementStmt, err = db.PrepareContext(ctx, awardAchievement); err != nil {
+		return nil, fmt.Errorf("error preparing query AwardAchievement: %w", err)
+	}
+	if q.banFromForumStmt, err = db.PrepareContext(ctx, banFromForum); err != nil {
+		return nil, fmt.Errorf("error preparing query BanFromForum: %w", err)
+	}
+	if q.canMessageUserStmt, err = db.PrepareContext(ctx, canMessageUser); err != nil {
+		return nil, fmt.Errorf("error preparing query CanMessageUser: %w", err)
+	}
+	if q.claimDueAnnouncementStmt, err = db.PrepareContext(ctx, claimDueAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueAnnouncement: %w", err)
+	}
+	if q.claimDueJobScheduleStmt, err = db.PrepareContext(ctx, claimDueJobSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimDueJobSchedule: %w", err)
+	}
+	if q.claimJobStmt, err = db.PrepareContext(ctx, claimJob); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimJob: %w", err)
+	}
+	if q.claimNotificationDeliveriesStmt, err = db.PrepareContext(ctx, claimNotificationDeliveries); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimNotificationDeliveries: %w", err)
+	}
+	if q.completeJobStmt, err = db.PrepareContext(ctx, completeJob); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteJob: %w", err)
+	}
+	if q.completeLessonStmt, err = db.PrepareContext(ctx, completeLesson); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteLesson: %w", err)
+	}
+	if q.countInboxStmt, err = db.PrepareContext(ctx, countInbox); err != nil {
+		return nil, fmt.Errorf("error preparing query CountInbox: %w", err)
+	}
+	if q.countJobsByStatusStmt, err = db.PrepareContext(ctx, countJobsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query CountJobsByStatus: %w", err)
+	}
+	if q.countNotificationsStmt, err = db.PrepareContext(ctx, countNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountNotifications: %w", err)
+	}
+	if q.countUnreadMessagesStmt, err = db.PrepareContext(ctx, countUnreadMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadMessages: %w", err)
+	}
+	if q.countUserPointEventsStmt, err = db.PrepareContext(ctx, countUserPointEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUserPointEvents: %w", err)
+	}
+	if q.courseLeaderboardStmt, err = db.PrepareContext(ctx, courseLeaderboard); err != nil {
+		return nil, fmt.Errorf("error preparing query CourseLeaderboard: %w", err)
+	}
+	if q.createAnnouncementStmt, err = db.PrepareContext(ctx, createAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAnnouncement: %w", err)
+	}
+	if q.createBadgeClassStmt, err = db.PrepareContext(ctx, createBadgeClass); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBadgeClass: %w", err)
+	}
+	if q.createBadgeCredentialStmt, err = db.PrepareContext(ctx, createBadgeCredential); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBadgeCredential: %w", err)
+	}
+	if q.createCertificateStmt, err = db.PrepareContext(ctx, createCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCertificate: %w", err)
+	}
+	if q.createCohortStmt, err = db.PrepareContext(ctx, createCohort); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCohort: %w", err)
+	}
+	if q.createConversationStmt, err = db.PrepareContext(ctx, createConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateConversation: %w", err)
+	}
 	if q.createCourseStmt, err = db.PrepareContext(ctx, createCourse); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCourse: %w", err)
 	}
+	if q.createCourseDraftStmt, err = db.PrepareContext(ctx, createCourseDraft); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCourseDraft: %w", err)
+	}
+	if q.createCourseRevisionStmt, err = db.PrepareContext(ctx, createCourseRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCourseRevision: %w", err)
+	}
+	if q.createForumPostStmt, err = db.PrepareContext(ctx, createForumPost); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateForumPost: %w", err)
+	}
+	if q.createForumThreadStmt, err = db.PrepareContext(ctx, createForumThread); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateForumThread: %w", err)
+	}
+	if q.createLessonStmt, err = db.PrepareContext(ctx, createLesson); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLesson: %w", err)
+	}
+	if q.createMessageStmt, err = db.PrepareContext(ctx, createMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMessage: %w", err)
+	}
+	if q.createMessageAttachmentStmt, err = db.PrepareContext(ctx, createMessageAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMessageAttachment: %w", err)
+	}
+	if q.createNotificationStmt, err = db.PrepareContext(ctx, createNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotification: %w", err)
+	}
+	if q.createNotificationDeliveryStmt, err = db.PrepareContext(ctx, createNotificationDelivery); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotificationDelivery: %w", err)
+	}
+	if q.createOrganizationStmt, err = db.PrepareContext(ctx, createOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOrganization: %w", err)
+	}
+	if q.createStaffInvitationStmt, err = db.PrepareContext(ctx, createStaffInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStaffInvitation: %w", err)
+	}
+	if q.createTeacherApplicationStmt, err = db.PrepareContext(ctx, createTeacherApplication); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTeacherApplication: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createUserEventStmt, err = db.PrepareContext(ctx, createUserEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateUserEvent: %w", err)
+	}
+	if q.createUserInvitationStmt, err = db.PrepareContext(ctx, createUserInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateUserInvitation: %w", err)
+	}
+	if q.deleteAnnouncementStmt, err = db.PrepareContext(ctx, deleteAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAnnouncement: %w", err)
+	}
+	if q.deleteCertificateTemplateStmt, err = db.PrepareContext(ctx, deleteCertificateTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCertificateTemplate: %w", err)
+	}
+	if q.deleteCourseDraftStmt, err = db.PrepareContext(ctx, deleteCourseDraft); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCourseDraft: %w", err)
+	}
+	if q.deleteExpiredStaffInvitationsStmt, err = db.PrepareContext(ctx, deleteExpiredStaffInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredStaffInvitations: %w", err)
+	}
+	if q.deleteExpiredUserInvitationsStmt, err = db.PrepareContext(ctx, deleteExpiredUserInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredUserInvitations: %w", err)
+	}
+	if q.deleteForumThreadStmt, err = db.PrepareContext(ctx, deleteForumThread); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteForumThread: %w", err)
+	}
+	if q.deleteJobStmt, err = db.PrepareContext(ctx, deleteJob); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteJob: %w", err)
+	}
+	if q.deleteNotificationWebhookStmt, err = db.PrepareContext(ctx, deleteNotificationWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNotificationWebhook: %w", err)
+	}
+	if q.deleteUserEventsBeforeStmt, err = db.PrepareContext(ctx, deleteUserEventsBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserEventsBefore: %w", err)
+	}
+	if q.deleteUserInvitationStmt, err = db.PrepareContext(ctx, deleteUserInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserInvitation: %w", err)
+	}
+	if q.enqueueJobStmt, err = db.PrepareContext(ctx, enqueueJob); err != nil {
+		return nil, fmt.Errorf("error preparing query EnqueueJob: %w", err)
+	}
+	if q.enrollInCohortStmt, err = db.PrepareContext(ctx, enrollInCohort); err != nil {
+		return nil, fmt.Errorf("error preparing query EnrollInCohort: %w", err)
+	}
+	if q.ensureGamificationProfileStmt, err = db.PrepareContext(ctx, ensureGamificationProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query EnsureGamificationProfile: %w", err)
+	}
+	if q.ensureOrganizationMemberStmt, err = db.PrepareContext(ctx, ensureOrganizationMember); err != nil {
+		return nil, fmt.Errorf("error preparing query EnsureOrganizationMember: %w", err)
+	}
+	if q.findDirectConversationStmt, err = db.PrepareContext(ctx, findDirectConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query FindDirectConversation: %w", err)
+	}
+	if q.getAnalyticsWatermarkStmt, err = db.PrepareContext(ctx, getAnalyticsWatermark); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAnalyticsWatermark: %w", err)
+	}
+	if q.getAnnouncementStmt, err = db.PrepareContext(ctx, getAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAnnouncement: %w", err)
+	}
+	if q.getBadgeClassStmt, err = db.PrepareContext(ctx, getBadgeClass); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBadgeClass: %w", err)
+	}
+	if q.getBadgeCredentialStmt, err = db.PrepareContext(ctx, getBadgeCredential); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBadgeCredential: %w", err)
+	}
+	if q.getCatalogVersionStmt, err = db.PrepareContext(ctx, getCatalogVersion); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCatalogVersion: %w", err)
+	}
+	if q.getCertificateStmt, err = db.PrepareContext(ctx, getCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCertificate: %w", err)
+	}
+	if q.getCertificateFileStmt, err = db.PrepareContext(ctx, getCertificateFile); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCertificateFile: %w", err)
+	}
+	if q.getCertificateTemplateStmt, err = db.PrepareContext(ctx, getCertificateTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCertificateTemplate: %w", err)
+	}
+	if q.getCohortStmt, err = db.PrepareContext(ctx, getCohort); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCohort: %w", err)
+	}
+	if q.getCohortByCodeStmt, err = db.PrepareContext(ctx, getCohortByCode); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCohortByCode: %w", err)
+	}
+	if q.getConversationStmt, err = db.PrepareContext(ctx, getConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetConversation: %w", err)
+	}
+	if q.getConversationParticipantStmt, err = db.PrepareContext(ctx, getConversationParticipant); err != nil {
+		return nil, fmt.Errorf("error preparing query GetConversationParticipant: %w", err)
+	}
+	if q.getCourseStmt, err = db.PrepareContext(ctx, getCourse); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourse: %w", err)
+	}
+	if q.getCourseAnalyticsSummaryStmt, err = db.PrepareContext(ctx, getCourseAnalyticsSummary); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseAnalyticsSummary: %w", err)
+	}
+	if q.getCourseDraftStmt, err = db.PrepareContext(ctx, getCourseDraft); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseDraft: %w", err)
+	}
+	if q.getCourseForUpdateStmt, err = db.PrepareContext(ctx, getCourseForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseForUpdate: %w", err)
+	}
+	if q.getCourseReviewStmt, err = db.PrepareContext(ctx, getCourseReview); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseReview: %w", err)
+	}
+	if q.getCourseRevisionStmt, err = db.PrepareContext(ctx, getCourseRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseRevision: %w", err)
+	}
+	if q.getCourseRevisionByNumberStmt, err = db.PrepareContext(ctx, getCourseRevisionByNumber); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseRevisionByNumber: %w", err)
+	}
+	if q.getCourseStaffRoleStmt, err = db.PrepareContext(ctx, getCourseStaffRole); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCourseStaffRole: %w", err)
+	}
+	if q.getEnrollmentStmt, err = db.PrepareContext(ctx, getEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEnrollment: %w", err)
+	}
+	if q.getEnrollmentProgressStmt, err = db.PrepareContext(ctx, getEnrollmentProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEnrollmentProgress: %w", err)
+	}
+	if q.getForumPostStmt, err = db.PrepareContext(ctx, getForumPost); err != nil {
+		return nil, fmt.Errorf("error preparing query GetForumPost: %w", err)
+	}
+	if q.getForumThreadStmt, err = db.PrepareContext(ctx, getForumThread); err != nil {
+		return nil, fmt.Errorf("error preparing query GetForumThread: %w", err)
+	}
+	if q.getGamificationProfileStmt, err = db.PrepareContext(ctx, getGamificationProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query GetGamificationProfile: %w", err)
+	}
+	if q.getJobStmt, err = db.PrepareContext(ctx, getJob); err != nil {
+		return nil, fmt.Errorf("error preparing query GetJob: %w", err)
+	}
+	if q.getLatestUserEventIDStmt, err = db.PrepareContext(ctx, getLatestUserEventID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestUserEventID: %w", err)
+	}
+	if q.getLessonStmt, err = db.PrepareContext(ctx, getLesson); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLesson: %w", err)
+	}
+	if q.getMessageAttachmentStmt, err = db.PrepareContext(ctx, getMessageAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageAttachment: %w", err)
+	}
+	if q.getNotificationPreferenceStmt, err = db.PrepareContext(ctx, getNotificationPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationPreference: %w", err)
+	}
+	if q.getNotificationWebhookStmt, err = db.PrepareContext(ctx, getNotificationWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationWebhook: %w", err)
+	}
+	if q.getOrganizationStmt, err = db.PrepareContext(ctx, getOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrganization: %w", err)
+	}
+	if q.getOrganizationBySlugStmt, err = db.PrepareContext(ctx, getOrganizationBySlug); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrganizationBySlug: %w", err)
+	}
+	if q.getOrganizationMemberRoleStmt, err = db.PrepareContext(ctx, getOrganizationMemberRole); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrganizationMemberRole: %w", err)
+	}
+	if q.getPointEventStmt, err = db.PrepareContext(ctx, getPointEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPointEvent: %w", err)
+	}
+	if q.getStaffInvitationByTokenHashStmt, err = db.PrepareContext(ctx, getStaffInvitationByTokenHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStaffInvitationByTokenHash: %w", err)
+	}
 	if q.getUserByEmailStmt, err = db.PrepareContext(ctx, getUserByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByEmail: %w", err)
 	}
+	if q.getUserByIDStmt, err = db.PrepareContext(ctx, getUserByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserByID: %w", err)
+	}
+	if q.getUserEventStmt, err = db.PrepareContext(ctx, getUserEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserEvent: %w", err)
+	}
+	if q.getUserInvitationByTokenHashStmt, err = db.PrepareContext(ctx, getUserInvitationByTokenHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserInvitationByTokenHash: %w", err)
+	}
+	if q.isBannedFromForumStmt, err = db.PrepareContext(ctx, isBannedFromForum); err != nil {
+		return nil, fmt.Errorf("error preparing query IsBannedFromForum: %w", err)
+	}
+	if q.killJobStmt, err = db.PrepareContext(ctx, killJob); err != nil {
+		return nil, fmt.Errorf("error preparing query KillJob: %w", err)
+	}
+	if q.listActiveCourseBadgeClassesStmt, err = db.PrepareContext(ctx, listActiveCourseBadgeClasses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveCourseBadgeClasses: %w", err)
+	}
+	if q.listAdminIDsStmt, err = db.PrepareContext(ctx, listAdminIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAdminIDs: %w", err)
+	}
+	if q.listAllUserEventsAfterStmt, err = db.PrepareContext(ctx, listAllUserEventsAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllUserEventsAfter: %w", err)
+	}
+	if q.listAnnouncementReadsStmt, err = db.PrepareContext(ctx, listAnnouncementReads); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAnnouncementReads: %w", err)
+	}
+	if q.listAnnouncementRecipientsStmt, err = db.PrepareContext(ctx, listAnnouncementRecipients); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAnnouncementRecipients: %w", err)
+	}
+	if q.listAtRiskLearnersStmt, err = db.PrepareContext(ctx, listAtRiskLearners); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAtRiskLearners: %w", err)
+	}
+	if q.listBadgeClassesStmt, err = db.PrepareContext(ctx, listBadgeClasses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBadgeClasses: %w", err)
+	}
+	if q.listCohortRosterStmt, err = db.PrepareContext(ctx, listCohortRoster); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCohortRoster: %w", err)
+	}
+	if q.listCohortsByCourseStmt, err = db.PrepareContext(ctx, listCohortsByCourse); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCohortsByCourse: %w", err)
+	}
+	if q.listConversationParticipantsStmt, err = db.PrepareContext(ctx, listConversationParticipants); err != nil {
+		return nil, fmt.Errorf("error preparing query ListConversationParticipants: %w", err)
+	}
+	if q.listCourseAnnouncementsForStaffStmt, err = db.PrepareContext(ctx, listCourseAnnouncementsForStaff); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseAnnouncementsForStaff: %w", err)
+	}
+	if q.listCourseAnnouncementsForStudentStmt, err = db.PrepareContext(ctx, listCourseAnnouncementsForStudent); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseAnnouncementsForStudent: %w", err)
+	}
+	if q.listCourseCertificatesStmt, err = db.PrepareContext(ctx, listCourseCertificates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseCertificates: %w", err)
+	}
+	if q.listCourseCompletionsStmt, err = db.PrepareContext(ctx, listCourseCompletions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseCompletions: %w", err)
+	}
+	if q.listCourseDailyStatsStmt, err = db.PrepareContext(ctx, listCourseDailyStats); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseDailyStats: %w", err)
+	}
+	if q.listCourseRevisionsStmt, err = db.PrepareContext(ctx, listCourseRevisions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseRevisions: %w", err)
+	}
+	if q.listCourseStaffStmt, err = db.PrepareContext(ctx, listCourseStaff); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseStaff: %w", err)
+	}
+	if q.listCourseStudentIDsStmt, err = db.PrepareContext(ctx, listCourseStudentIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCourseStudentIDs: %w", err)
+	}
 	if q.listCoursesStmt, err = db.PrepareContext(ctx, listCourses); err != nil {
 		return nil, fmt.Errorf("error preparing query ListCourses: %w", err)
 	}
+	if q.listDeadlineRemindersStmt, err = db.PrepareContext(ctx, listDeadlineReminders); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeadlineReminders: %w", err)
+	}
+	if q.listForumBansStmt, err = db.PrepareContext(ctx, listForumBans); err != nil {
+		return nil, fmt.Errorf("error preparing query ListForumBans: %w", err)
+	}
+	if q.listForumPostsStmt, err = db.PrepareContext(ctx, listForumPosts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListForumPosts: %w", err)
+	}
+	if q.listForumThreadsStmt, err = db.PrepareContext(ctx, listForumThreads); err != nil {
+		return nil, fmt.Errorf("error preparing query ListForumThreads: %w", err)
+	}
+	if q.listInboxStmt, err = db.PrepareContext(ctx, listInbox); err != nil {
+		return nil, fmt.Errorf("error preparing query ListInbox: %w", err)
+	}
+	if q.listJobSchedulesStmt, err = db.PrepareContext(ctx, listJobSchedules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJobSchedules: %w", err)
+	}
+	if q.listJobsStmt, err = db.PrepareContext(ctx, listJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJobs: %w", err)
+	}
+	if q.listLessonIDsByCourseStmt, err = db.PrepareContext(ctx, listLessonIDsByCourse); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLessonIDsByCourse: %w", err)
+	}
+	if q.listLessonStatsStmt, err = db.PrepareContext(ctx, listLessonStats); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLessonStats: %w", err)
+	}
+	if q.listMessageAttachmentsStmt, err = db.PrepareContext(ctx, listMessageAttachments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMessageAttachments: %w", err)
+	}
+	if q.listMessagesStmt, err = db.PrepareContext(ctx, listMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMessages: %w", err)
+	}
+	if q.listNotificationPreferencesStmt, err = db.PrepareContext(ctx, listNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNotificationPreferences: %w", err)
+	}
+	if q.listNotificationsStmt, err = db.PrepareContext(ctx, listNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNotifications: %w", err)
+	}
+	if q.listOrganizationMembersStmt, err = db.PrepareContext(ctx, listOrganizationMembers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOrganizationMembers: %w", err)
+	}
+	if q.listOrganizationsStmt, err = db.PrepareContext(ctx, listOrganizations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOrganizations: %w", err)
+	}
+	if q.listPendingStaffInvitationsStmt, err = db.PrepareContext(ctx, listPendingStaffInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingStaffInvitations: %w", err)
+	}
+	if q.listPendingUserInvitationsStmt, err = db.PrepareContext(ctx, listPendingUserInvitations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingUserInvitations: %w", err)
+	}
+	if q.listRecentAchievementsStmt, err = db.PrepareContext(ctx, listRecentAchievements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListRecentAchievements: %w", err)
+	}
+	if q.listReportedCourseReviewsStmt, err = db.PrepareContext(ctx, listReportedCourseReviews); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReportedCourseReviews: %w", err)
+	}
+	if q.listTeacherApplicationsByStatusStmt, err = db.PrepareContext(ctx, listTeacherApplicationsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTeacherApplicationsByStatus: %w", err)
+	}
+	if q.listUserAchievementsStmt, err = db.PrepareContext(ctx, listUserAchievements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserAchievements: %w", err)
+	}
+	if q.listUserBadgeCredentialsStmt, err = db.PrepareContext(ctx, listUserBadgeCredentials); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserBadgeCredentials: %w", err)
+	}
+	if q.listUserCertificatesStmt, err = db.PrepareContext(ctx, listUserCertificates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserCertificates: %w", err)
+	}
+	if q.listUserEventsAfterStmt, err = db.PrepareContext(ctx, listUserEventsAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserEventsAfter: %w", err)
+	}
+	if q.listUserOrganizationsStmt, err = db.PrepareContext(ctx, listUserOrganizations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserOrganizations: %w", err)
+	}
+	if q.listUserTeacherApplicationsStmt, err = db.PrepareContext(ctx, listUserTeacherApplications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserTeacherApplications: %w", err)
+	}
+	if q.listVisibleCourseReviewsStmt, err = db.PrepareContext(ctx, listVisibleCourseReviews); err != nil {
+		return nil, fmt.Errorf("error preparing query ListVisibleCourseReviews: %w", err)
+	}
+	if q.lockGamificationProfileStmt, err = db.PrepareContext(ctx, lockGamificationProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query LockGamificationProfile: %w", err)
+	}
+	if q.markAllNotificationsReadStmt, err = db.PrepareContext(ctx, markAllNotificationsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllNotificationsRead: %w", err)
+	}
+	if q.markAnnouncementPublishedStmt, err = db.PrepareContext(ctx, markAnnouncementPublished); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAnnouncementPublished: %w", err)
+	}
+	if q.markAnnouncementReadStmt, err = db.PrepareContext(ctx, markAnnouncementRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAnnouncementRead: %w", err)
+	}
+	if q.markCertificateIssuedStmt, err = db.PrepareContext(ctx, markCertificateIssued); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkCertificateIssued: %w", err)
+	}
+	if q.markConversationReadStmt, err = db.PrepareContext(ctx, markConversationRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkConversationRead: %w", err)
+	}
+	if q.markEnrollmentCompletedStmt, err = db.PrepareContext(ctx, markEnrollmentCompleted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkEnrollmentCompleted: %w", err)
+	}
+	if q.markNotificationDeliveryFailedStmt, err = db.PrepareContext(ctx, markNotificationDeliveryFailed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationDeliveryFailed: %w", err)
+	}
+	if q.markNotificationDeliverySentStmt, err = db.PrepareContext(ctx, markNotificationDeliverySent); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationDeliverySent: %w", err)
+	}
+	if q.markNotificationReadStmt, err = db.PrepareContext(ctx, markNotificationRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationRead: %w", err)
+	}
+	if q.markPointEventProcessedStmt, err = db.PrepareContext(ctx, markPointEventProcessed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkPointEventProcessed: %w", err)
+	}
+	if q.organizationLeaderboardStmt, err = db.PrepareContext(ctx, organizationLeaderboard); err != nil {
+		return nil, fmt.Errorf("error preparing query OrganizationLeaderboard: %w", err)
+	}
+	if q.pinEnrollmentStmt, err = db.PrepareContext(ctx, pinEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query PinEnrollment: %w", err)
+	}
+	if q.pruneLearnerStatsStmt, err = db.PrepareContext(ctx, pruneLearnerStats); err != nil {
+		return nil, fmt.Errorf("error preparing query PruneLearnerStats: %w", err)
+	}
+	if q.publishCourseRevisionStmt, err = db.PrepareContext(ctx, publishCourseRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query PublishCourseRevision: %w", err)
+	}
+	if q.purgeFinishedJobsStmt, err = db.PrepareContext(ctx, purgeFinishedJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeFinishedJobs: %w", err)
+	}
+	if q.recordLessonActivityStmt, err = db.PrepareContext(ctx, recordLessonActivity); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordLessonActivity: %w", err)
+	}
+	if q.recordPointEventStmt, err = db.PrepareContext(ctx, recordPointEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordPointEvent: %w", err)
+	}
+	if q.refreshCourseDailyStatsStmt, err = db.PrepareContext(ctx, refreshCourseDailyStats); err != nil {
+		return nil, fmt.Errorf("error preparing query RefreshCourseDailyStats: %w", err)
+	}
+	if q.refreshLearnerStatsStmt, err = db.PrepareContext(ctx, refreshLearnerStats); err != nil {
+		return nil, fmt.Errorf("error preparing query RefreshLearnerStats: %w", err)
+	}
+	if q.refreshLessonStatsStmt, err = db.PrepareContext(ctx, refreshLessonStats); err != nil {
+		return nil, fmt.Errorf("error preparing query RefreshLessonStats: %w", err)
+	}
+	if q.removeCourseStaffStmt, err = db.PrepareContext(ctx, removeCourseStaff); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveCourseStaff: %w", err)
+	}
+	if q.removeForumPostUpvoteStmt, err = db.PrepareContext(ctx, removeForumPostUpvote); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveForumPostUpvote: %w", err)
+	}
+	if q.removeFromCohortStmt, err = db.PrepareContext(ctx, removeFromCohort); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveFromCohort: %w", err)
+	}
+	if q.removeOrganizationMemberStmt, err = db.PrepareContext(ctx, removeOrganizationMember); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveOrganizationMember: %w", err)
+	}
+	if q.replyToCourseReviewStmt, err = db.PrepareContext(ctx, replyToCourseReview); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplyToCourseReview: %w", err)
+	}
+	if q.reportCourseReviewStmt, err = db.PrepareContext(ctx, reportCourseReview); err != nil {
+		return nil, fmt.Errorf("error preparing query ReportCourseReview: %w", err)
+	}
+	if q.requeueDeadJobStmt, err = db.PrepareContext(ctx, requeueDeadJob); err != nil {
+		return nil, fmt.Errorf("error preparing query RequeueDeadJob: %w", err)
+	}
+	if q.retryJobLaterStmt, err = db.PrepareContext(ctx, retryJobLater); err != nil {
+		return nil, fmt.Errorf("error preparing query RetryJobLater: %w", err)
+	}
+	if q.reviewTeacherApplicationStmt, err = db.PrepareContext(ctx, reviewTeacherApplication); err != nil {
+		return nil, fmt.Errorf("error preparing query ReviewTeacherApplication: %w", err)
+	}
+	if q.revokeBadgeCredentialStmt, err = db.PrepareContext(ctx, revokeBadgeCredential); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeBadgeCredential: %w", err)
+	}
+	if q.revokeCertificateStmt, err = db.PrepareContext(ctx, revokeCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeCertificate: %w", err)
+	}
+	if q.saveCertificateFileStmt, err = db.PrepareContext(ctx, saveCertificateFile); err != nil {
+		return nil, fmt.Errorf("error preparing query SaveCertificateFile: %w", err)
+	}
+	if q.setAnalyticsWatermarkStmt, err = db.PrepareContext(ctx, setAnalyticsWatermark); err != nil {
+		return nil, fmt.Errorf("error preparing query SetAnalyticsWatermark: %w", err)
+	}
+	if q.setCourseAuthorStmt, err = db.PrepareContext(ctx, setCourseAuthor); err != nil {
+		return nil, fmt.Errorf("error preparing query SetCourseAuthor: %w", err)
+	}
+	if q.setCourseReviewHiddenStmt, err = db.PrepareContext(ctx, setCourseReviewHidden); err != nil {
+		return nil, fmt.Errorf("error preparing query SetCourseReviewHidden: %w", err)
+	}
+	if q.setForumPostHiddenStmt, err = db.PrepareContext(ctx, setForumPostHidden); err != nil {
+		return nil, fmt.Errorf("error preparing query SetForumPostHidden: %w", err)
+	}
+	if q.setForumThreadAnswerStmt, err = db.PrepareContext(ctx, setForumThreadAnswer); err != nil {
+		return nil, fmt.Errorf("error preparing query SetForumThreadAnswer: %w", err)
+	}
+	if q.setJobScheduleEnabledStmt, err = db.PrepareContext(ctx, setJobScheduleEnabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetJobScheduleEnabled: %w", err)
+	}
+	if q.setUserRoleStmt, err = db.PrepareContext(ctx, setUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query SetUserRole: %w", err)
+	}
+	if q.softDeleteForumPostStmt, err = db.PrepareContext(ctx, softDeleteForumPost); err != nil {
+		return nil, fmt.Errorf("error preparing query SoftDeleteForumPost: %w", err)
+	}
+	if q.touchConversationStmt, err = db.PrepareContext(ctx, touchConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchConversation: %w", err)
+	}
+	if q.touchForumThreadStmt, err = db.PrepareContext(ctx, touchForumThread); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchForumThread: %w", err)
+	}
+	if q.unbanFromForumStmt, err = db.PrepareContext(ctx, unbanFromForum); err != nil {
+		return nil, fmt.Errorf("error preparing query UnbanFromForum: %w", err)
+	}
+	if q.unpinEnrollmentStmt, err = db.PrepareContext(ctx, unpinEnrollment); err != nil {
+		return nil, fmt.Errorf("error preparing query UnpinEnrollment: %w", err)
+	}
+	if q.updateAnnouncementStmt, err = db.PrepareContext(ctx, updateAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAnnouncement: %w", err)
+	}
+	if q.updateBadgeClassStmt, err = db.PrepareContext(ctx, updateBadgeClass); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateBadgeClass: %w", err)
+	}
+	if q.updateCohortStmt, err = db.PrepareContext(ctx, updateCohort); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCohort: %w", err)
+	}
+	if q.updateCourseDraftStmt, err = db.PrepareContext(ctx, updateCourseDraft); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCourseDraft: %w", err)
+	}
+	if q.updateCourseStaffRoleStmt, err = db.PrepareContext(ctx, updateCourseStaffRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCourseStaffRole: %w", err)
+	}
+	if q.updateForumThreadFlagsStmt, err = db.PrepareContext(ctx, updateForumThreadFlags); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateForumThreadFlags: %w", err)
+	}
+	if q.updateGamificationProgressStmt, err = db.PrepareContext(ctx, updateGamificationProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateGamificationProgress: %w", err)
+	}
+	if q.updateGamificationSettingsStmt, err = db.PrepareContext(ctx, updateGamificationSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateGamificationSettings: %w", err)
+	}
+	if q.updateOrganizationStmt, err = db.PrepareContext(ctx, updateOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateOrganization: %w", err)
+	}
+	if q.updateOrganizationMemberRoleStmt, err = db.PrepareContext(ctx, updateOrganizationMemberRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateOrganizationMemberRole: %w", err)
+	}
+	if q.updatePublishedAnnouncementStmt, err = db.PrepareContext(ctx, updatePublishedAnnouncement); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePublishedAnnouncement: %w", err)
+	}
+	if q.updateUserPasswordStmt, err = db.PrepareContext(ctx, updateUserPassword); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserPassword: %w", err)
+	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
+	if q.upsertCertificateTemplateStmt, err = db.PrepareContext(ctx, upsertCertificateTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCertificateTemplate: %w", err)
+	}
+	if q.upsertCourseReviewStmt, err = db.PrepareContext(ctx, upsertCourseReview); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCourseReview: %w", err)
+	}
+	if q.upsertJobScheduleStmt, err = db.PrepareContext(ctx, upsertJobSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertJobSchedule: %w", err)
+	}
+	if q.upsertNotificationPreferenceStmt, err = db.PrepareContext(ctx, upsertNotificationPreference); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertNotificationPreference: %w", err)
+	}
+	if q.upsertNotificationWebhookStmt, err = db.PrepareContext(ctx, upsertNotificationWebhook); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertNotificationWebhook: %w", err)
+	}
+	if q.upvoteForumPostStmt, err = db.PrepareContext(ctx, upvoteForumPost); err != nil {
+		return nil, fmt.Errorf("error preparing query UpvoteForumPost: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
-	if q.createCourseStmt != nil {
-		if cerr := q.createCourseStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing createCourseStmt: %w", cerr)
+	if q.acceptStaffInvitationStmt != nil {
+		if cerr := q.acceptStaffInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acceptStaffInvitationStmt: %w", cerr)
 		}
 	}
-	if q.createUserStmt != nil {
-		if cerr := q.createUserStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
+	if q.acceptUserInvitationStmt != nil {
+		if cerr := q.acceptUserInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acceptUserInvitationStmt: %w", cerr)
 		}
 	}
-	if q.getUserByEmailStmt != nil {
-		if cerr := q.getUserByEmailStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing getUserByEmailStmt: %w", cerr)
+	if q.addConversationParticipantStmt != nil {
+		if cerr := q.addConversationParticipantStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addConversationParticipantStmt: %w", cerr)
 		}
 	}
-	if q.listCoursesStmt != nil {
-		if cerr := q.listCoursesStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing listCoursesStmt: %w", cerr)
+	if q.addCourseStaffStmt != nil {
+		if cerr := q.addCourseStaffStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addCourseStaffStmt: %w", cerr)
+		}
+	}
+	if q.addOrganizationMemberStmt != nil {
+		if cerr := q.addOrganizationMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addOrganizationMemberStmt: %w", cerr)
+		}
+	}
+	if q.advanceJobScheduleStmt != nil {
+		if cerr := q.advanceJobScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing advanceJobScheduleStmt: %w", cerr)
+		}
+	}
+	if q.awardAchievementStmt != nil {
+		if cerr := q.awardAchievementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing awardAchievementStmt: %w", cerr)
+		}
+	}
+	if q.banFromForumStmt != nil {
+		if cerr := q.banFromForumStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing banFromForumStmt: %w", cerr)
+		}
+	}
+	if q.canMessageUserStmt != nil {
+		if cerr := q.canMessageUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing canMessageUserStmt: %w", cerr)
+		}
+	}
+	if q.claimDueAnnouncementStmt != nil {
+		if cerr := q.claimDueAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.claimDueJobScheduleStmt != nil {
+		if cerr := q.claimDueJobScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimDueJobScheduleStmt: %w", cerr)
+		}
+	}
+	if q.claimJobStmt != nil {
+		if cerr := q.claimJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimJobStmt: %w", cerr)
+		}
+	}
+	if q.claimNotificationDeliveriesStmt != nil {
+		if cerr := q.claimNotificationDeliveriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimNotificationDeliveriesStmt: %w", cerr)
+		}
+	}
+	if q.completeJobStmt != nil {
+		if cerr := q.completeJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeJobStmt: %w", cerr)
+		}
+	}
+	if q.completeLessonStmt != nil {
+		if cerr := q.completeLessonStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeLessonStmt: %w", cerr)
+		}
+	}
+	if q.countInboxStmt != nil {
+		if cerr := q.countInboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countInboxStmt: %w", cerr)
+		}
+	}
+	if q.countJobsByStatusStmt != nil {
+		if cerr := q.countJobsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countJobsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.countNotificationsStmt != nil {
+		if cerr := q.countNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadMessagesStmt != nil {
+		if cerr := q.countUnreadMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadMessagesStmt: %w", cerr)
+		}
+	}
+	if q.countUserPointEventsStmt != nil {
+		if cerr := q.countUserPointEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUserPointEventsStmt: %w", cerr)
+		}
+	}
+	if q.courseLeaderboardStmt != nil {
+		if cerr := q.courseLeaderboardStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing courseLeaderboardStmt: %w", cerr)
+		}
+	}
+	if q.createAnnouncementStmt != nil {
+		if cerr := q.createAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.createBadgeClassStmt != nil {
+		if cerr := q.createBadgeClassStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBadgeClassStmt: %w", cerr)
+		}
+	}
+	if q.createBadgeCredentialStmt != nil {
+		if cerr := q.createBadgeCredentialStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBadgeCredentialStmt: %w", cerr)
+		}
+	}
+	if q.createCertificateStmt != nil {
+		if cerr := q.createCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCertificateStmt: %w", cerr)
+		}
+	}
+	if q.createCohortStmt != nil {
+		if cerr := q.createCohortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCohortStmt: %w", cerr)
+		}
+	}
+	if q.createConversationStmt != nil {
+		if cerr := q.createConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createConversationStmt: %w", cerr)
+		}
+	}
+	if q.createCourseStmt != nil {
+		if cerr := q.createCourseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCourseStmt: %w", cerr)
+		}
+	}
+	if q.createCourseDraftStmt != nil {
+		if cerr := q.createCourseDraftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCourseDraftStmt: %w", cerr)
+		}
+	}
+	if q.createCourseRevisionStmt != nil {
+		if cerr := q.createCourseRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCourseRevisionStmt: %w", cerr)
+		}
+	}
+	if q.createForumPostStmt != nil {
+		if cerr := q.createForumPostStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createForumPostStmt: %w", cerr)
+		}
+	}
+	if q.createForumThreadStmt != nil {
+		if cerr := q.createForumThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createForumThreadStmt: %w", cerr)
+		}
+	}
+	if q.createLessonStmt != nil {
+		if cerr := q.createLessonStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLessonStmt: %w", cerr)
+		}
+	}
+	if q.createMessageStmt != nil {
+		if cerr := q.createMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMessageStmt: %w", cerr)
+		}
+	}
+	if q.createMessageAttachmentStmt != nil {
+		if cerr := q.createMessageAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMessageAttachmentStmt: %w", cerr)
+		}
+	}
+	if q.createNotificationStmt != nil {
+		if cerr := q.createNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationStmt: %w", cerr)
+		}
+	}
+	if q.createNotificationDeliveryStmt != nil {
+		if cerr := q.createNotificationDeliveryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationDeliveryStmt: %w", cerr)
+		}
+	}
+	if q.createOrganizationStmt != nil {
+		if cerr := q.createOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.createStaffInvitationStmt != nil {
+		if cerr := q.createStaffInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStaffInvitationStmt: %w", cerr)
+		}
+	}
+	if q.createTeacherApplicationStmt != nil {
+		if cerr := q.createTeacherApplicationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTeacherApplicationStmt: %w", cerr)
+		}
+	}
+	if q.createUserStmt != nil {
+		if cerr := q.createUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
+		}
+	}
+	if q.createUserEventStmt != nil {
+		if cerr := q.createUserEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserEventStmt: %w", cerr)
+		}
+	}
+	if q.createUserInvitationStmt != nil {
+		if cerr := q.createUserInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserInvitationStmt: %w", cerr)
+		}
+	}
+	if q.deleteAnnouncementStmt != nil {
+		if cerr := q.deleteAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.deleteCertificateTemplateStmt != nil {
+		if cerr := q.deleteCertificateTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCertificateTemplateStmt: %w", cerr)
+		}
+	}
+	if q.deleteCourseDraftStmt != nil {
+		if cerr := q.deleteCourseDraftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCourseDraftStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredStaffInvitationsStmt != nil {
+		if cerr := q.deleteExpiredStaffInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredStaffInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredUserInvitationsStmt != nil {
+		if cerr := q.deleteExpiredUserInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredUserInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteForumThreadStmt != nil {
+		if cerr := q.deleteForumThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteForumThreadStmt: %w", cerr)
+		}
+	}
+	if q.deleteJobStmt != nil {
+		if cerr := q.deleteJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteJobStmt: %w", cerr)
+		}
+	}
+	if q.deleteNotificationWebhookStmt != nil {
+		if cerr := q.deleteNotificationWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNotificationWebhookStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserEventsBeforeStmt != nil {
+		if cerr := q.deleteUserEventsBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserEventsBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserInvitationStmt != nil {
+		if cerr := q.deleteUserInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserInvitationStmt: %w", cerr)
+		}
+	}
+	if q.enqueueJobStmt != nil {
+		if cerr := q.enqueueJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enqueueJobStmt: %w", cerr)
+		}
+	}
+	if q.enrollInCohortStmt != nil {
+		if cerr := q.enrollInCohortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enrollInCohortStmt: %w", cerr)
+		}
+	}
+	if q.ensureGamificationProfileStmt != nil {
+		if cerr := q.ensureGamificationProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing ensureGamificationProfileStmt: %w", cerr)
+		}
+	}
+	if q.ensureOrganizationMemberStmt != nil {
+		if cerr := q.ensureOrganizationMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing ensureOrganizationMemberStmt: %w", cerr)
+		}
+	}
+	if q.findDirectConversationStmt != nil {
+		if cerr := q.findDirectConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findDirectConversationStmt: %w", cerr)
+		}
+	}
+	if q.getAnalyticsWatermarkStmt != nil {
+		if cerr := q.getAnalyticsWatermarkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAnalyticsWatermarkStmt: %w", cerr)
+		}
+	}
+	if q.getAnnouncementStmt != nil {
+		if cerr := q.getAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.getBadgeClassStmt != nil {
+		if cerr := q.getBadgeClassStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBadgeClassStmt: %w", cerr)
+		}
+	}
+	if q.getBadgeCredentialStmt != nil {
+		if cerr := q.getBadgeCredentialStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBadgeCredentialStmt: %w", cerr)
+		}
+	}
+	if q.getCatalogVersionStmt != nil {
+		if cerr := q.getCatalogVersionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCatalogVersionStmt: %w", cerr)
+		}
+	}
+	if q.getCertificateStmt != nil {
+		if cerr := q.getCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCertificateStmt: %w", cerr)
+		}
+	}
+	if q.getCertificateFileStmt != nil {
+		if cerr := q.getCertificateFileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCertificateFileStmt: %w", cerr)
+		}
+	}
+	if q.getCertificateTemplateStmt != nil {
+		if cerr := q.getCertificateTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCertificateTemplateStmt: %w", cerr)
+		}
+	}
+	if q.getCohortStmt != nil {
+		if cerr := q.getCohortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCohortStmt: %w", cerr)
+		}
+	}
+	if q.getCohortByCodeStmt != nil {
+		if cerr := q.getCohortByCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCohortByCodeStmt: %w", cerr)
+		}
+	}
+	if q.getConversationStmt != nil {
+		if cerr := q.getConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getConversationStmt: %w", cerr)
+		}
+	}
+	if q.getConversationParticipantStmt != nil {
+		if cerr := q.getConversationParticipantStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getConversationParticipantStmt: %w", cerr)
+		}
+	}
+	if q.getCourseStmt != nil {
+		if cerr := q.getCourseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseStmt: %w", cerr)
+		}
+	}
+	if q.getCourseAnalyticsSummaryStmt != nil {
+		if cerr := q.getCourseAnalyticsSummaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseAnalyticsSummaryStmt: %w", cerr)
+		}
+	}
+	if q.getCourseDraftStmt != nil {
+		if cerr := q.getCourseDraftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseDraftStmt: %w", cerr)
+		}
+	}
+	if q.getCourseForUpdateStmt != nil {
+		if cerr := q.getCourseForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getCourseReviewStmt != nil {
+		if cerr := q.getCourseReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseReviewStmt: %w", cerr)
+		}
+	}
+	if q.getCourseRevisionStmt != nil {
+		if cerr := q.getCourseRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseRevisionStmt: %w", cerr)
+		}
+	}
+	if q.getCourseRevisionByNumberStmt != nil {
+		if cerr := q.getCourseRevisionByNumberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseRevisionByNumberStmt: %w", cerr)
+		}
+	}
+	if q.getCourseStaffRoleStmt != nil {
+		if cerr := q.getCourseStaffRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCourseStaffRoleStmt: %w", cerr)
+		}
+	}
+	if q.getEnrollmentStmt != nil {
+		if cerr := q.getEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.getEnrollmentProgressStmt != nil {
+		if cerr := q.getEnrollmentProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEnrollmentProgressStmt: %w", cerr)
+		}
+	}
+	if q.getForumPostStmt != nil {
+		if cerr := q.getForumPostStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getForumPostStmt: %w", cerr)
+		}
+	}
+	if q.getForumThreadStmt != nil {
+		if cerr := q.getForumThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getForumThreadStmt: %w", cerr)
+		}
+	}
+	if q.getGamificationProfileStmt != nil {
+		if cerr := q.getGamificationProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getGamificationProfileStmt: %w", cerr)
+		}
+	}
+	if q.getJobStmt != nil {
+		if cerr := q.getJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getJobStmt: %w", cerr)
+		}
+	}
+	if q.getLatestUserEventIDStmt != nil {
+		if cerr := q.getLatestUserEventIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestUserEventIDStmt: %w", cerr)
+		}
+	}
+	if q.getLessonStmt != nil {
+		if cerr := q.getLessonStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLessonStmt: %w", cerr)
+		}
+	}
+	if q.getMessageAttachmentStmt != nil {
+		if cerr := q.getMessageAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageAttachmentStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationPreferenceStmt != nil {
+		if cerr := q.getNotificationPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationPreferenceStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationWebhookStmt != nil {
+		if cerr := q.getNotificationWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationWebhookStmt: %w", cerr)
+		}
+	}
+	if q.getOrganizationStmt != nil {
+		if cerr := q.getOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.getOrganizationBySlugStmt != nil {
+		if cerr := q.getOrganizationBySlugStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrganizationBySlugStmt: %w", cerr)
+		}
+	}
+	if q.getOrganizationMemberRoleStmt != nil {
+		if cerr := q.getOrganizationMemberRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrganizationMemberRoleStmt: %w", cerr)
+		}
+	}
+	if q.getPointEventStmt != nil {
+		if cerr := q.getPointEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPointEventStmt: %w", cerr)
+		}
+	}
+	if q.getStaffInvitationByTokenHashStmt != nil {
+		if cerr := q.getStaffInvitationByTokenHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStaffInvitationByTokenHashStmt: %w", cerr)
+		}
+	}
+	if q.getUserByEmailStmt != nil {
+		if cerr := q.getUserByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserByEmailStmt: %w", cerr)
+		}
+	}
+	if q.getUserByIDStmt != nil {
+		if cerr := q.getUserByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserByIDStmt: %w", cerr)
+		}
+	}
+	if q.getUserEventStmt != nil {
+		if cerr := q.getUserEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserEventStmt: %w", cerr)
+		}
+	}
+	if q.getUserInvitationByTokenHashStmt != nil {
+		if cerr := q.getUserInvitationByTokenHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserInvitationByTokenHashStmt: %w", cerr)
+		}
+	}
+	if q.isBannedFromForumStmt != nil {
+		if cerr := q.isBannedFromForumStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isBannedFromForumStmt: %w", cerr)
+		}
+	}
+	if q.killJobStmt != nil {
+		if cerr := q.killJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing killJobStmt: %w", cerr)
+		}
+	}
+	if q.listActiveCourseBadgeClassesStmt != nil {
+		if cerr := q.listActiveCourseBadgeClassesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveCourseBadgeClassesStmt: %w", cerr)
+		}
+	}
+	if q.listAdminIDsStmt != nil {
+		if cerr := q.listAdminIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAdminIDsStmt: %w", cerr)
+		}
+	}
+	if q.listAllUserEventsAfterStmt != nil {
+		if cerr := q.listAllUserEventsAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllUserEventsAfterStmt: %w", cerr)
+		}
+	}
+	if q.listAnnouncementReadsStmt != nil {
+		if cerr := q.listAnnouncementReadsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAnnouncementReadsStmt: %w", cerr)
+		}
+	}
+	if q.listAnnouncementRecipientsStmt != nil {
+		if cerr := q.listAnnouncementRecipientsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAnnouncementRecipientsStmt: %w", cerr)
+		}
+	}
+	if q.listAtRiskLearnersStmt != nil {
+		if cerr := q.listAtRiskLearnersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAtRiskLearnersStmt: %w", cerr)
+		}
+	}
+	if q.listBadgeClassesStmt != nil {
+		if cerr := q.listBadgeClassesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBadgeClassesStmt: %w", cerr)
+		}
+	}
+	if q.listCohortRosterStmt != nil {
+		if cerr := q.listCohortRosterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCohortRosterStmt: %w", cerr)
+		}
+	}
+	if q.listCohortsByCourseStmt != nil {
+		if cerr := q.listCohortsByCourseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCohortsByCourseStmt: %w", cerr)
+		}
+	}
+	if q.listConversationParticipantsStmt != nil {
+		if cerr := q.listConversationParticipantsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listConversationParticipantsStmt: %w", cerr)
+		}
+	}
+	if q.listCourseAnnouncementsForStaffStmt != nil {
+		if cerr := q.listCourseAnnouncementsForStaffStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseAnnouncementsForStaffStmt: %w", cerr)
+		}
+	}
+	if q.listCourseAnnouncementsForStudentStmt != nil {
+		if cerr := q.listCourseAnnouncementsForStudentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseAnnouncementsForStudentStmt: %w", cerr)
+		}
+	}
+	if q.listCourseCertificatesStmt != nil {
+		if cerr := q.listCourseCertificatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseCertificatesStmt: %w", cerr)
+		}
+	}
+	if q.listCourseCompletionsStmt != nil {
+		if cerr := q.listCourseCompletionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseCompletionsStmt: %w", cerr)
+		}
+	}
+	if q.listCourseDailyStatsStmt != nil {
+		if cerr := q.listCourseDailyStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseDailyStatsStmt: %w", cerr)
+		}
+	}
+	if q.listCourseRevisionsStmt != nil {
+		if cerr := q.listCourseRevisionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseRevisionsStmt: %w", cerr)
+		}
+	}
+	if q.listCourseStaffStmt != nil {
+		if cerr := q.listCourseStaffStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseStaffStmt: %w", cerr)
+		}
+	}
+	if q.listCourseStudentIDsStmt != nil {
+		if cerr := q.listCourseStudentIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCourseStudentIDsStmt: %w", cerr)
+		}
+	}
+	if q.listCoursesStmt != nil {
+		if cerr := q.listCoursesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCoursesStmt: %w", cerr)
+		}
+	}
+	if q.listDeadlineRemindersStmt != nil {
+		if cerr := q.listDeadlineRemindersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeadlineRemindersStmt: %w", cerr)
+		}
+	}
+	if q.listForumBansStmt != nil {
+		if cerr := q.listForumBansStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listForumBansStmt: %w", cerr)
+		}
+	}
+	if q.listForumPostsStmt != nil {
+		if cerr := q.listForumPostsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listForumPostsStmt: %w", cerr)
+		}
+	}
+	if q.listForumThreadsStmt != nil {
+		if cerr := q.listForumThreadsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listForumThreadsStmt: %w", cerr)
+		}
+	}
+	if q.listInboxStmt != nil {
+		if cerr := q.listInboxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listInboxStmt: %w", cerr)
+		}
+	}
+	if q.listJobSchedulesStmt != nil {
+		if cerr := q.listJobSchedulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJobSchedulesStmt: %w", cerr)
+		}
+	}
+	if q.listJobsStmt != nil {
+		if cerr := q.listJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJobsStmt: %w", cerr)
+		}
+	}
+	if q.listLessonIDsByCourseStmt != nil {
+		if cerr := q.listLessonIDsByCourseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLessonIDsByCourseStmt: %w", cerr)
+		}
+	}
+	if q.listLessonStatsStmt != nil {
+		if cerr := q.listLessonStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLessonStatsStmt: %w", cerr)
+		}
+	}
+	if q.listMessageAttachmentsStmt != nil {
+		if cerr := q.listMessageAttachmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMessageAttachmentsStmt: %w", cerr)
+		}
+	}
+	if q.listMessagesStmt != nil {
+		if cerr := q.listMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMessagesStmt: %w", cerr)
+		}
+	}
+	if q.listNotificationPreferencesStmt != nil {
+		if cerr := q.listNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNotificationPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.listNotificationsStmt != nil {
+		if cerr := q.listNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.listOrganizationMembersStmt != nil {
+		if cerr := q.listOrganizationMembersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOrganizationMembersStmt: %w", cerr)
+		}
+	}
+	if q.listOrganizationsStmt != nil {
+		if cerr := q.listOrganizationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOrganizationsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingStaffInvitationsStmt != nil {
+		if cerr := q.listPendingStaffInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingStaffInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingUserInvitationsStmt != nil {
+		if cerr := q.listPendingUserInvitationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingUserInvitationsStmt: %w", cerr)
+		}
+	}
+	if q.listRecentAchievementsStmt != nil {
+		if cerr := q.listRecentAchievementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listRecentAchievementsStmt: %w", cerr)
+		}
+	}
+	if q.listReportedCourseReviewsStmt != nil {
+		if cerr := q.listReportedCourseReviewsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReportedCourseReviewsStmt: %w", cerr)
+		}
+	}
+	if q.listTeacherApplicationsByStatusStmt != nil {
+		if cerr := q.listTeacherApplicationsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTeacherApplicationsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.listUserAchievementsStmt != nil {
+		if cerr := q.listUserAchievementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserAchievementsStmt: %w", cerr)
+		}
+	}
+	if q.listUserBadgeCredentialsStmt != nil {
+		if cerr := q.listUserBadgeCredentialsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserBadgeCredentialsStmt: %w", cerr)
+		}
+	}
+	if q.listUserCertificatesStmt != nil {
+		if cerr := q.listUserCertificatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserCertificatesStmt: %w", cerr)
+		}
+	}
+	if q.listUserEventsAfterStmt != nil {
+		if cerr := q.listUserEventsAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserEventsAfterStmt: %w", cerr)
+		}
+	}
+	if q.listUserOrganizationsStmt != nil {
+		if cerr := q.listUserOrganizationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserOrganizationsStmt: %w", cerr)
+		}
+	}
+	if q.listUserTeacherApplicationsStmt != nil {
+		if cerr := q.listUserTeacherApplicationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserTeacherApplicationsStmt: %w", cerr)
+		}
+	}
+	if q.listVisibleCourseReviewsStmt != nil {
+		if cerr := q.listVisibleCourseReviewsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listVisibleCourseReviewsStmt: %w", cerr)
+		}
+	}
+	if q.lockGamificationProfileStmt != nil {
+		if cerr := q.lockGamificationProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockGamificationProfileStmt: %w", cerr)
+		}
+	}
+	if q.markAllNotificationsReadStmt != nil {
+		if cerr := q.markAllNotificationsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllNotificationsReadStmt: %w", cerr)
+		}
+	}
+	if q.markAnnouncementPublishedStmt != nil {
+		if cerr := q.markAnnouncementPublishedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAnnouncementPublishedStmt: %w", cerr)
+		}
+	}
+	if q.markAnnouncementReadStmt != nil {
+		if cerr := q.markAnnouncementReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAnnouncementReadStmt: %w", cerr)
+		}
+	}
+	if q.markCertificateIssuedStmt != nil {
+		if cerr := q.markCertificateIssuedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markCertificateIssuedStmt: %w", cerr)
+		}
+	}
+	if q.markConversationReadStmt != nil {
+		if cerr := q.markConversationReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markConversationReadStmt: %w", cerr)
+		}
+	}
+	if q.markEnrollmentCompletedStmt != nil {
+		if cerr := q.markEnrollmentCompletedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markEnrollmentCompletedStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationDeliveryFailedStmt != nil {
+		if cerr := q.markNotificationDeliveryFailedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationDeliveryFailedStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationDeliverySentStmt != nil {
+		if cerr := q.markNotificationDeliverySentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationDeliverySentStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationReadStmt != nil {
+		if cerr := q.markNotificationReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationReadStmt: %w", cerr)
+		}
+	}
+	if q.markPointEventProcessedStmt != nil {
+		if cerr := q.markPointEventProcessedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markPointEventProcessedStmt: %w", cerr)
+		}
+	}
+	if q.organizationLeaderboardStmt != nil {
+		if cerr := q.organizationLeaderboardStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing organizationLeaderboardStmt: %w", cerr)
+		}
+	}
+	if q.pinEnrollmentStmt != nil {
+		if cerr := q.pinEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pinEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.pruneLearnerStatsStmt != nil {
+		if cerr := q.pruneLearnerStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pruneLearnerStatsStmt: %w", cerr)
+		}
+	}
+	if q.publishCourseRevisionStmt != nil {
+		if cerr := q.publishCourseRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing publishCourseRevisionStmt: %w", cerr)
+		}
+	}
+	if q.purgeFinishedJobsStmt != nil {
+		if cerr := q.purgeFinishedJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeFinishedJobsStmt: %w", cerr)
+		}
+	}
+	if q.recordLessonActivityStmt != nil {
+		if cerr := q.recordLessonActivityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordLessonActivityStmt: %w", cerr)
+		}
+	}
+	if q.recordPointEventStmt != nil {
+		if cerr := q.recordPointEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordPointEventStmt: %w", cerr)
+		}
+	}
+	if q.refreshCourseDailyStatsStmt != nil {
+		if cerr := q.refreshCourseDailyStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing refreshCourseDailyStatsStmt: %w", cerr)
+		}
+	}
+	if q.refreshLearnerStatsStmt != nil {
+		if cerr := q.refreshLearnerStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing refreshLearnerStatsStmt: %w", cerr)
+		}
+	}
+	if q.refreshLessonStatsStmt != nil {
+		if cerr := q.refreshLessonStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing refreshLessonStatsStmt: %w", cerr)
+		}
+	}
+	if q.removeCourseStaffStmt != nil {
+		if cerr := q.removeCourseStaffStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeCourseStaffStmt: %w", cerr)
+		}
+	}
+	if q.removeForumPostUpvoteStmt != nil {
+		if cerr := q.removeForumPostUpvoteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeForumPostUpvoteStmt: %w", cerr)
+		}
+	}
+	if q.removeFromCohortStmt != nil {
+		if cerr := q.removeFromCohortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeFromCohortStmt: %w", cerr)
+		}
+	}
+	if q.removeOrganizationMemberStmt != nil {
+		if cerr := q.removeOrganizationMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeOrganizationMemberStmt: %w", cerr)
+		}
+	}
+	if q.replyToCourseReviewStmt != nil {
+		if cerr := q.replyToCourseReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replyToCourseReviewStmt: %w", cerr)
+		}
+	}
+	if q.reportCourseReviewStmt != nil {
+		if cerr := q.reportCourseReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reportCourseReviewStmt: %w", cerr)
+		}
+	}
+	if q.requeueDeadJobStmt != nil {
+		if cerr := q.requeueDeadJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing requeueDeadJobStmt: %w", cerr)
+		}
+	}
+	if q.retryJobLaterStmt != nil {
+		if cerr := q.retryJobLaterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retryJobLaterStmt: %w", cerr)
+		}
+	}
+	if q.reviewTeacherApplicationStmt != nil {
+		if cerr := q.reviewTeacherApplicationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reviewTeacherApplicationStmt: %w", cerr)
+		}
+	}
+	if q.revokeBadgeCredentialStmt != nil {
+		if cerr := q.revokeBadgeCredentialStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeBadgeCredentialStmt: %w", cerr)
+		}
+	}
+	if q.revokeCertificateStmt != nil {
+		if cerr := q.revokeCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeCertificateStmt: %w", cerr)
+		}
+	}
+	if q.saveCertificateFileStmt != nil {
+		if cerr := q.saveCertificateFileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing saveCertificateFileStmt: %w", cerr)
+		}
+	}
+	if q.setAnalyticsWatermarkStmt != nil {
+		if cerr := q.setAnalyticsWatermarkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setAnalyticsWatermarkStmt: %w", cerr)
+		}
+	}
+	if q.setCourseAuthorStmt != nil {
+		if cerr := q.setCourseAuthorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setCourseAuthorStmt: %w", cerr)
+		}
+	}
+	if q.setCourseReviewHiddenStmt != nil {
+		if cerr := q.setCourseReviewHiddenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setCourseReviewHiddenStmt: %w", cerr)
+		}
+	}
+	if q.setForumPostHiddenStmt != nil {
+		if cerr := q.setForumPostHiddenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setForumPostHiddenStmt: %w", cerr)
+		}
+	}
+	if q.setForumThreadAnswerStmt != nil {
+		if cerr := q.setForumThreadAnswerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setForumThreadAnswerStmt: %w", cerr)
+		}
+	}
+	if q.setJobScheduleEnabledStmt != nil {
+		if cerr := q.setJobScheduleEnabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setJobScheduleEnabledStmt: %w", cerr)
+		}
+	}
+	if q.setUserRoleStmt != nil {
+		if cerr := q.setUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.softDeleteForumPostStmt != nil {
+		if cerr := q.softDeleteForumPostStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing softDeleteForumPostStmt: %w", cerr)
+		}
+	}
+	if q.touchConversationStmt != nil {
+		if cerr := q.touchConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchConversationStmt: %w", cerr)
+		}
+	}
+	if q.touchForumThreadStmt != nil {
+		if cerr := q.touchForumThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchForumThreadStmt: %w", cerr)
+		}
+	}
+	if q.unbanFromForumStmt != nil {
+		if cerr := q.unbanFromForumStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unbanFromForumStmt: %w", cerr)
+		}
+	}
+	if q.unpinEnrollmentStmt != nil {
+		if cerr := q.unpinEnrollmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unpinEnrollmentStmt: %w", cerr)
+		}
+	}
+	if q.updateAnnouncementStmt != nil {
+		if cerr := q.updateAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.updateBadgeClassStmt != nil {
+		if cerr := q.updateBadgeClassStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateBadgeClassStmt: %w", cerr)
+		}
+	}
+	if q.updateCohortStmt != nil {
+		if cerr := q.updateCohortStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCohortStmt: %w", cerr)
+		}
+	}
+	if q.updateCourseDraftStmt != nil {
+		if cerr := q.updateCourseDraftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCourseDraftStmt: %w", cerr)
+		}
+	}
+	if q.updateCourseStaffRoleStmt != nil {
+		if cerr := q.updateCourseStaffRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCourseStaffRoleStmt: %w", cerr)
+		}
+	}
+	if q.updateForumThreadFlagsStmt != nil {
+		if cerr := q.updateForumThreadFlagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateForumThreadFlagsStmt: %w", cerr)
+		}
+	}
+	if q.updateGamificationProgressStmt != nil {
+		if cerr := q.updateGamificationProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateGamificationProgressStmt: %w", cerr)
+		}
+	}
+	if q.updateGamificationSettingsStmt != nil {
+		if cerr := q.updateGamificationSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateGamificationSettingsStmt: %w", cerr)
+		}
+	}
+	if q.updateOrganizationStmt != nil {
+		if cerr := q.updateOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.updateOrganizationMemberRoleStmt != nil {
+		if cerr := q.updateOrganizationMemberRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateOrganizationMemberRoleStmt: %w", cerr)
+		}
+	}
+	if q.updatePublishedAnnouncementStmt != nil {
+		if cerr := q.updatePublishedAnnouncementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePublishedAnnouncementStmt: %w", cerr)
+		}
+	}
+	if q.updateUserPasswordStmt != nil {
+		if cerr := q.updateUserPasswordStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserPasswordStmt: %w", cerr)
+		}
+	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.upsertCertificateTemplateStmt != nil {
+		if cerr := q.upsertCertificateTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCertificateTemplateStmt: %w", cerr)
+		}
+	}
+	if q.upsertCourseReviewStmt != nil {
+		if cerr := q.upsertCourseReviewStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCourseReviewStmt: %w", cerr)
+		}
+	}
+	if q.upsertJobScheduleStmt != nil {
+		if cerr := q.upsertJobScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertJobScheduleStmt: %w", cerr)
+		}
+	}
+	if q.upsertNotificationPreferenceStmt != nil {
+		if cerr := q.upsertNotificationPreferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertNotificationPreferenceStmt: %w", cerr)
+		}
+	}
+	if q.upsertNotificationWebhookStmt != nil {
+		if cerr := q.upsertNotificationWebhookStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertNotificationWebhookStmt: %w", cerr)
+		}
+	}
+	if q.upvoteForumPostStmt != nil {
+		if cerr := q.upvoteForumPostStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upvoteForumPostStmt: %w", cerr)
 		}
 	}
 	return err
@@ -98,21 +1746,433 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                 DBTX
-	tx                 *sql.Tx
-	createCourseStmt   *sql.Stmt
-	createUserStmt     *sql.Stmt
-	getUserByEmailStmt *sql.Stmt
-	listCoursesStmt    *sql.Stmt
+	db                                    DBTX
+	tx                                    *sql.Tx
+	acceptStaffInvitationStmt             *sql.Stmt
+	acceptUserInvitationStmt              *sql.Stmt
+	addConversationParticipantStmt        *sql.Stmt
+	addCourseStaffStmt                    *sql.Stmt
+	addOrganizationMemberStmt             *sql.Stmt
+	advanceJobScheduleStmt                *sql.Stmt
+	awardAchievementStmt                  *sql.Stmt
+	banFromForumStmt                      *sql.Stmt
+	canMessageUserStmt                    *sql.Stmt
+	claimDueAnnouncementStmt              *sql.Stmt
+	claimDueJobScheduleStmt               *sql.Stmt
+	claimJobStmt                          *sql.Stmt
+	claimNotificationDeliveriesStmt       *sql.Stmt
+	completeJobStmt                       *sql.Stmt
+	completeLessonStmt                    *sql.Stmt
+	countInboxStmt                        *sql.Stmt
+	countJobsByStatusStmt                 *sql.Stmt
+	countNotificationsStmt                *sql.Stmt
+	countUnreadMessagesStmt               *sql.Stmt
+	countUserPointEventsStmt              *sql.Stmt
+	courseLeaderboardStmt                 *sql.Stmt
+	createAnnouncementStmt                *sql.Stmt
+	createBadgeClassStmt                  *sql.Stmt
+	createBadgeCredentialStmt             *sql.Stmt
+	createCertificateStmt                 *sql.Stmt
+	createCohortStmt                      *sql.Stmt
+	createConversationStmt                *sql.Stmt
+	createCourseStmt                      *sql.Stmt
+	createCourseDraftStmt                 *sql.Stmt
+	createCourseRevisionStmt              *sql.Stmt
+	createForumPostStmt                   *sql.Stmt
+	createForumThreadStmt                 *sql.Stmt
+	createLessonStmt                      *sql.Stmt
+	createMessageStmt                     *sql.Stmt
+	createMessageAttachmentStmt           *sql.Stmt
+	createNotificationStmt                *sql.Stmt
+	createNotificationDeliveryStmt        *sql.Stmt
+	createOrganizationStmt                *sql.Stmt
+	createStaffInvitationStmt             *sql.Stmt
+	createTeacherApplicationStmt          *sql.Stmt
+	createUserStmt                        *sql.Stmt
+	createUserEventStmt                   *sql.Stmt
+	createUserInvitationStmt              *sql.Stmt
+	deleteAnnouncementStmt                *sql.Stmt
+	deleteCertificateTemplateStmt         *sql.Stmt
+	deleteCourseDraftStmt                 *sql.Stmt
+	deleteExpiredStaffInvitationsStmt     *sql.Stmt
+	deleteExpiredUserInvitationsStmt      *sql.Stmt
+	deleteForumThreadStmt                 *sql.Stmt
+	deleteJobStmt                         *sql.Stmt
+	deleteNotificationWebhookStmt         *sql.Stmt
+	deleteUserEventsBeforeStmt            *sql.Stmt
+	deleteUserInvitationStmt              *sql.Stmt
+	enqueueJobStmt                        *sql.Stmt
+	enrollInCohortStmt                    *sql.Stmt
+	ensureGamificationProfileStmt         *sql.Stmt
+	ensureOrganizationMemberStmt          *sql.Stmt
+	findDirectConversationStmt            *sql.Stmt
+	getAnalyticsWatermarkStmt             *sql.Stmt
+	getAnnouncementStmt                   *sql.Stmt
+	getBadgeClassStmt                     *sql.Stmt
+	getBadgeCredentialStmt                *sql.Stmt
+	getCatalogVersionStmt                 *sql.Stmt
+	getCertificateStmt                    *sql.Stmt
+	getCertificateFileStmt                *sql.Stmt
+	getCertificateTemplateStmt            *sql.Stmt
+	getCohortStmt                         *sql.Stmt
+	getCohortByCodeStmt                   *sql.Stmt
+	getConversationStmt                   *sql.Stmt
+	getConversationParticipantStmt        *sql.Stmt
+	getCourseStmt                         *sql.Stmt
+	getCourseAnalyticsSummaryStmt         *sql.Stmt
+	getCourseDraftStmt                    *sql.Stmt
+	getCourseForUpdateStmt                *sql.Stmt
+	getCourseReviewStmt                   *sql.Stmt
+	getCourseRevisionStmt                 *sql.Stmt
+	getCourseRevisionByNumberStmt         *sql.Stmt
+	getCourseStaffRoleStmt                *sql.Stmt
+	getEnrollmentStmt                     *sql.Stmt
+	getEnrollmentProgressStmt             *sql.Stmt
+	getForumPostStmt                      *sql.Stmt
+	getForumThreadStmt                    *sql.Stmt
+	getGamificationProfileStmt            *sql.Stmt
+	getJobStmt                            *sql.Stmt
+	getLatestUserEventIDStmt              *sql.Stmt
+	getLessonStmt                         *sql.Stmt
+	getMessageAttachmentStmt              *sql.Stmt
+	getNotificationPreferenceStmt         *sql.Stmt
+	getNotificationWebhookStmt            *sql.Stmt
+	getOrganizationStmt                   *sql.Stmt
+	getOrganizationBySlugStmt             *sql.Stmt
+	getOrganizationMemberRoleStmt         *sql.Stmt
+	getPointEventStmt                     *sql.Stmt
+	getStaffInvitationByTokenHashStmt     *sql.Stmt
+	getUserByEmailStmt                    *sql.Stmt
+	getUserByIDStmt                       *sql.Stmt
+	getUserEventStmt                      *sql.Stmt
+	getUserInvitationByTokenHashStmt      *sql.Stmt
+	isBannedFromForumStmt                 *sql.Stmt
+	killJobStmt                           *sql.Stmt
+	listActiveCourseBadgeClassesStmt      *sql.Stmt
+	listAdminIDsStmt                      *sql.Stmt
+	listAllUserEventsAfterStmt            *sql.Stmt
+	listAnnouncementReadsStmt             *sql.Stmt
+	listAnnouncementRecipientsStmt        *sql.Stmt
+	listAtRiskLearnersStmt                *sql.Stmt
+	listBadgeClassesStmt                  *sql.Stmt
+	listCohortRosterStmt                  *sql.Stmt
+	listCohortsByCourseStmt               *sql.Stmt
+	listConversationParticipantsStmt      *sql.Stmt
+	listCourseAnnouncementsForStaffStmt   *sql.Stmt
+	listCourseAnnouncementsForStudentStmt *sql.Stmt
+	listCourseCertificatesStmt            *sql.Stmt
+	listCourseCompletionsStmt             *sql.Stmt
+	listCourseDailyStatsStmt              *sql.Stmt
+	listCourseRevisionsStmt               *sql.Stmt
+	listCourseStaffStmt                   *sql.Stmt
+	listCourseStudentIDsStmt              *sql.Stmt
+	listCoursesStmt                       *sql.Stmt
+	listDeadlineRemindersStmt             *sql.Stmt
+	listForumBansStmt                     *sql.Stmt
+	listForumPostsStmt                    *sql.Stmt
+	listForumThreadsStmt                  *sql.Stmt
+	listInboxStmt                         *sql.Stmt
+	listJobSchedulesStmt                  *sql.Stmt
+	listJobsStmt                          *sql.Stmt
+	listLessonIDsByCourseStmt             *sql.Stmt
+	listLessonStatsStmt                   *sql.Stmt
+	listMessageAttachmentsStmt            *sql.Stmt
+	listMessagesStmt                      *sql.Stmt
+	listNotificationPreferencesStmt       *sql.Stmt
+	listNotificationsStmt                 *sql.Stmt
+	listOrganizationMembersStmt           *sql.Stmt
+	listOrganizationsStmt                 *sql.Stmt
+	listPendingStaffInvitationsStmt       *sql.Stmt
+	listPendingUserInvitationsStmt        *sql.Stmt
+	listRecentAchievementsStmt            *sql.Stmt
+	listReportedCourseReviewsStmt         *sql.Stmt
+	listTeacherApplicationsByStatusStmt   *sql.Stmt
+	listUserAchievementsStmt              *sql.Stmt
+	listUserBadgeCredentialsStmt          *sql.Stmt
+	listUserCertificatesStmt              *sql.Stmt
+	listUserEventsAfterStmt               *sql.Stmt
+	listUserOrganizationsStmt             *sql.Stmt
+	listUserTeacherApplicationsStmt       *sql.Stmt
+	listVisibleCourseReviewsStmt          *sql.Stmt
+	lockGamificationProfileStmt           *sql.Stmt
+	markAllNotificationsReadStmt          *sql.Stmt
+	markAnnouncementPublishedStmt         *sql.Stmt
+	markAnnouncementReadStmt              *sql.Stmt
+	markCertificateIssuedStmt             *sql.Stmt
+	markConversationReadStmt              *sql.Stmt
+	markEnrollmentCompletedStmt           *sql.Stmt
+	markNotificationDeliveryFailedStmt    *sql.Stmt
+	markNotificationDeliverySentStmt      *sql.Stmt
+	markNotificationReadStmt              *sql.Stmt
+	markPointEventProcessedStmt           *sql.Stmt
+	organizationLeaderboardStmt           *sql.Stmt
+	pinEnrollmentStmt                     *sql.Stmt
+	pruneLearnerStatsStmt                 *sql.Stmt
+	publishCourseRevisionStmt             *sql.Stmt
+	purgeFinishedJobsStmt                 *sql.Stmt
+	recordLessonActivityStmt              *sql.Stmt
+	recordPointEventStmt                  *sql.Stmt
+	refreshCourseDailyStatsStmt           *sql.Stmt
+	refreshLearnerStatsStmt               *sql.Stmt
+	refreshLessonStatsStmt                *sql.Stmt
+	removeCourseStaffStmt                 *sql.Stmt
+	removeForumPostUpvoteStmt             *sql.Stmt
+	removeFromCohortStmt                  *sql.Stmt
+	removeOrganizationMemberStmt          *sql.Stmt
+	replyToCourseReviewStmt               *sql.Stmt
+	reportCourseReviewStmt                *sql.Stmt
+	requeueDeadJobStmt                    *sql.Stmt
+	retryJobLaterStmt                     *sql.Stmt
+	reviewTeacherApplicationStmt          *sql.Stmt
+	revokeBadgeCredentialStmt             *sql.Stmt
+	revokeCertificateStmt                 *sql.Stmt
+	saveCertificateFileStmt               *sql.Stmt
+	setAnalyticsWatermarkStmt             *sql.Stmt
+	setCourseAuthorStmt                   *sql.Stmt
+	setCourseReviewHiddenStmt             *sql.Stmt
+	setForumPostHiddenStmt                *sql.Stmt
+	setForumThreadAnswerStmt              *sql.Stmt
+	setJobScheduleEnabledStmt             *sql.Stmt
+	setUserRoleStmt                       *sql.Stmt
+	softDeleteForumPostStmt               *sql.Stmt
+	touchConversationStmt                 *sql.Stmt
+	touchForumThreadStmt                  *sql.Stmt
+	unbanFromForumStmt                    *sql.Stmt
+	unpinEnrollmentStmt                   *sql.Stmt
+	updateAnnouncementStmt                *sql.Stmt
+	updateBadgeClassStmt                  *sql.Stmt
+	updateCohortStmt                      *sql.Stmt
+	updateCourseDraftStmt                 *sql.Stmt
+	updateCourseStaffRoleStmt             *sql.Stmt
+	updateForumThreadFlagsStmt            *sql.Stmt
+	updateGamificationProgressStmt        *sql.Stmt
+	updateGamificationSettingsStmt        *sql.Stmt
+	updateOrganizationStmt                *sql.Stmt
+	updateOrganizationMemberRoleStmt      *sql.Stmt
+	updatePublishedAnnouncementStmt       *sql.Stmt
+	updateUserPasswordStmt                *sql.Stmt
+	updateUserRoleStmt                    *sql.Stmt
+	upsertCertificateTemplateStmt         *sql.Stmt
+	upsertCourseReviewStmt                *sql.Stmt
+	upsertJobScheduleStmt                 *sql.Stmt
+	upsertNotificationPreferenceStmt      *sql.Stmt
+	upsertNotificationWebhookStmt         *sql.Stmt
+	upvoteForumPostStmt                   *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                 tx,
-		tx:                 tx,
-		createCourseStmt:   q.createCourseStmt,
-		createUserStmt:     q.createUserStmt,
-		getUserByEmailStmt: q.getUserByEmailStmt,
-		listCoursesStmt:    q.listCoursesStmt,
+		db:                                    tx,
+		tx:                                    tx,
+		acceptStaffInvitationStmt:             q.acceptStaffInvitationStmt,
+		acceptUserInvitationStmt:              q.acceptUserInvitationStmt,
+		addConversationParticipantStmt:        q.addConversationParticipantStmt,
+		addCourseStaffStmt:                    q.addCourseStaffStmt,
+		addOrganizationMemberStmt:             q.addOrganizationMemberStmt,
+		advanceJobScheduleStmt:                q.advanceJobScheduleStmt,
+		awardAchievementStmt:                  q.awardAchievementStmt,
+		banFromForumStmt:                      q.banFromForumStmt,
+		canMessageUserStmt:                    q.canMessageUserStmt,
+		claimDueAnnouncementStmt:              q.claimDueAnnouncementStmt,
+		claimDueJobScheduleStmt:               q.claimDueJobScheduleStmt,
+		claimJobStmt:                          q.claimJobStmt,
+		claimNotificationDeliveriesStmt:       q.claimNotificationDeliveriesStmt,
+		completeJobStmt:                       q.completeJobStmt,
+		completeLessonStmt:                    q.completeLessonStmt,
+		countInboxStmt:                        q.countInboxStmt,
+		countJobsByStatusStmt:                 q.countJobsByStatusStmt,
+		countNotificationsStmt:                q.countNotificationsStmt,
+		countUnreadMessagesStmt:               q.countUnreadMessagesStmt,
+		countUserPointEventsStmt:              q.countUserPointEventsStmt,
+		courseLeaderboardStmt:                 q.courseLeaderboardStmt,
+		createAnnouncementStmt:                q.createAnnouncementStmt,
+		createBadgeClassStmt:                  q.createBadgeClassStmt,
+		createBadgeCredentialStmt:             q.createBadgeCredentialStmt,
+		createCertificateStmt:                 q.createCertificateStmt,
+		createCohortStmt:                      q.createCohortStmt,
+		createConversationStmt:                q.createConversationStmt,
+		createCourseStmt:                      q.createCourseStmt,
+		createCourseDraftStmt:                 q.createCourseDraftStmt,
+		createCourseRevisionStmt:              q.createCourseRevisionStmt,
+		createForumPostStmt:                   q.createForumPostStmt,
+		createForumThreadStmt:                 q.createForumThreadStmt,
+		createLessonStmt:                      q.createLessonStmt,
+		createMessageStmt:                     q.createMessageStmt,
+		createMessageAttachmentStmt:           q.createMessageAttachmentStmt,
+		createNotificationStmt:                q.createNotificationStmt,
+		createNotificationDeliveryStmt:        q.createNotificationDeliveryStmt,
+		createOrganizationStmt:                q.createOrganizationStmt,
+		createStaffInvitationStmt:             q.createStaffInvitationStmt,
+		createTeacherApplicationStmt:          q.createTeacherApplicationStmt,
+		createUserStmt:                        q.createUserStmt,
+		createUserEventStmt:                   q.createUserEventStmt,
+		createUserInvitationStmt:              q.createUserInvitationStmt,
+		deleteAnnouncementStmt:                q.deleteAnnouncementStmt,
+		deleteCertificateTemplateStmt:         q.deleteCertificateTemplateStmt,
+		deleteCourseDraftStmt:                 q.deleteCourseDraftStmt,
+		deleteExpiredStaffInvitationsStmt:     q.deleteExpiredStaffInvitationsStmt,
+		deleteExpiredUserInvitationsStmt:      q.deleteExpiredUserInvitationsStmt,
+		deleteForumThreadStmt:                 q.deleteForumThreadStmt,
+		deleteJobStmt:                         q.deleteJobStmt,
+		deleteNotificationWebhookStmt:         q.deleteNotificationWebhookStmt,
+		deleteUserEventsBeforeStmt:            q.deleteUserEventsBeforeStmt,
+		deleteUserInvitationStmt:              q.deleteUserInvitationStmt,
+		enqueueJobStmt:                        q.enqueueJobStmt,
+		enrollInCohortStmt:                    q.enrollInCohortStmt,
+		ensureGamificationProfileStmt:         q.ensureGamificationProfileStmt,
+		ensureOrganizationMemberStmt:          q.ensureOrganizationMemberStmt,
+		findDirectConversationStmt:            q.findDirectConversationStmt,
+		getAnalyticsWatermarkStmt:             q.getAnalyticsWatermarkStmt,
+		getAnnouncementStmt:                   q.getAnnouncementStmt,
+		getBadgeClassStmt:                     q.getBadgeClassStmt,
+		getBadgeCredentialStmt:                q.getBadgeCredentialStmt,
+		getCatalogVersionStmt:                 q.getCatalogVersionStmt,
+		getCertificateStmt:                    q.getCertificateStmt,
+		getCertificateFileStmt:                q.getCertificateFileStmt,
+		getCertificateTemplateStmt:            q.getCertificateTemplateStmt,
+		getCohortStmt:                         q.getCohortStmt,
+		getCohortByCodeStmt:                   q.getCohortByCodeStmt,
+		getConversationStmt:                   q.getConversationStmt,
+		getConversationParticipantStmt:        q.getConversationParticipantStmt,
+		getCourseStmt:                         q.getCourseStmt,
+		getCourseAnalyticsSummaryStmt:         q.getCourseAnalyticsSummaryStmt,
+		getCourseDraftStmt:                    q.getCourseDraftStmt,
+		getCourseForUpdateStmt:                q.getCourseForUpdateStmt,
+		getCourseReviewStmt:                   q.getCourseReviewStmt,
+		getCourseRevisionStmt:                 q.getCourseRevisionStmt,
+		getCourseRevisionByNumberStmt:         q.getCourseRevisionByNumberStmt,
+		getCourseStaffRoleStmt:                q.getCourseStaffRoleStmt,
+		getEnrollmentStmt:                     q.getEnrollmentStmt,
+		getEnrollmentProgressStmt:             q.getEnrollmentProgressStmt,
+		getForumPostStmt:                      q.getForumPostStmt,
+		getForumThreadStmt:                    q.getForumThreadStmt,
+		getGamificationProfileStmt:            q.getGamificationProfileStmt,
+		getJobStmt:                            q.getJobStmt,
+		getLatestUserEventIDStmt:              q.getLatestUserEventIDStmt,
+		getLessonStmt:                         q.getLessonStmt,
+		getMessageAttachmentStmt:              q.getMessageAttachmentStmt,
+		getNotificationPreferenceStmt:         q.getNotificationPreferenceStmt,
+		getNotificationWebhookStmt:            q.getNotificationWebhookStmt,
+		getOrganizationStmt:                   q.getOrganizationStmt,
+		getOrganizationBySlugStmt:             q.getOrganizationBySlugStmt,
+		getOrganizationMemberRoleStmt:         q.getOrganizationMemberRoleStmt,
+		getPointEventStmt:                     q.getPointEventStmt,
+		getStaffInvitationByTokenHashStmt:     q.getStaffInvitationByTokenHashStmt,
+		getUserByEmailStmt:                    q.getUserByEmailStmt,
+		getUserByIDStmt:                       q.getUserByIDStmt,
+		getUserEventStmt:                      q.getUserEventStmt,
+		getUserInvitationByTokenHashStmt:      q.getUserInvitationByTokenHashStmt,
+		isBannedFromForumStmt:                 q.isBannedFromForumStmt,
+		killJobStmt:                           q.killJobStmt,
+		listActiveCourseBadgeClassesStmt:      q.listActiveCourseBadgeClassesStmt,
+		listAdminIDsStmt:                      q.listAdminIDsStmt,
+		listAllUserEventsAfterStmt:            q.listAllUserEventsAfterStmt,
+		listAnnouncementReadsStmt:             q.listAnnouncementReadsStmt,
+		listAnnouncementRecipientsStmt:        q.listAnnouncementRecipientsStmt,
+		listAtRiskLearnersStmt:                q.listAtRiskLearnersStmt,
+		listBadgeClassesStmt:                  q.listBadgeClassesStmt,
+		listCohortRosterStmt:                  q.listCohortRosterStmt,
+		listCohortsByCourseStmt:               q.listCohortsByCourseStmt,
+		listConversationParticipantsStmt:      q.listConversationParticipantsStmt,
+		listCourseAnnouncementsForStaffStmt:   q.listCourseAnnouncementsForStaffStmt,
+		listCourseAnnouncementsForStudentStmt: q.listCourseAnnouncementsForStudentStmt,
+		listCourseCertificatesStmt:            q.listCourseCertificatesStmt,
+		listCourseCompletionsStmt:             q.listCourseCompletionsStmt,
+		listCourseDailyStatsStmt:              q.listCourseDailyStatsStmt,
+		listCourseRevisionsStmt:               q.listCourseRevisionsStmt,
+		listCourseStaffStmt:                   q.listCourseStaffStmt,
+		listCourseStudentIDsStmt:              q.listCourseStudentIDsStmt,
+		listCoursesStmt:                       q.listCoursesStmt,
+		listDeadlineRemindersStmt:             q.listDeadlineRemindersStmt,
+		listForumBansStmt:                     q.listForumBansStmt,
+		listForumPostsStmt:                    q.listForumPostsStmt,
+		listForumThreadsStmt:                  q.listForumThreadsStmt,
+		listInboxStmt:                         q.listInboxStmt,
+		listJobSchedulesStmt:                  q.listJobSchedulesStmt,
+		listJobsStmt:                          q.listJobsStmt,
+		listLessonIDsByCourseStmt:             q.listLessonIDsByCourseStmt,
+		listLessonStatsStmt:                   q.listLessonStatsStmt,
+		listMessageAttachmentsStmt:            q.listMessageAttachmentsStmt,
+		listMessagesStmt:                      q.listMessagesStmt,
+		listNotificationPreferencesStmt:       q.listNotificationPreferencesStmt,
+		listNotificationsStmt:                 q.listNotificationsStmt,
+		listOrganizationMembersStmt:           q.listOrganizationMembersStmt,
+		listOrganizationsStmt:                 q.listOrganizationsStmt,
+		listPendingStaffInvitationsStmt:       q.listPendingStaffInvitationsStmt,
+		listPendingUserInvitationsStmt:        q.listPendingUserInvitationsStmt,
+		listRecentAchievementsStmt:            q.listRecentAchievementsStmt,
+		listReportedCourseReviewsStmt:         q.listReportedCourseReviewsStmt,
+		listTeacherApplicationsByStatusStmt:   q.listTeacherApplicationsByStatusStmt,
+		listUserAchievementsStmt:              q.listUserAchievementsStmt,
+		listUserBadgeCredentialsStmt:          q.listUserBadgeCredentialsStmt,
+		listUserCertificatesStmt:              q.listUserCertificatesStmt,
+		listUserEventsAfterStmt:               q.listUserEventsAfterStmt,
+		listUserOrganizationsStmt:             q.listUserOrganizationsStmt,
+		listUserTeacherApplicationsStmt:       q.listUserTeacherApplicationsStmt,
+		listVisibleCourseReviewsStmt:          q.listVisibleCourseReviewsStmt,
+		lockGamificationProfileStmt:           q.lockGamificationProfileStmt,
+		markAllNotificationsReadStmt:          q.markAllNotificationsReadStmt,
+		markAnnouncementPublishedStmt:         q.markAnnouncementPublishedStmt,
+		markAnnouncementReadStmt:              q.markAnnouncementReadStmt,
+		markCertificateIssuedStmt:             q.markCertificateIssuedStmt,
+		markConversationReadStmt:              q.markConversationReadStmt,
+		markEnrollmentCompletedStmt:           q.markEnrollmentCompletedStmt,
+		markNotificationDeliveryFailedStmt:    q.markNotificationDeliveryFailedStmt,
+		markNotificationDeliverySentStmt:      q.markNotificationDeliverySentStmt,
+		markNotificationReadStmt:              q.markNotificationReadStmt,
+		markPointEventProcessedStmt:           q.markPointEventProcessedStmt,
+		organizationLeaderboardStmt:           q.organizationLeaderboardStmt,
+		pinEnrollmentStmt:                     q.pinEnrollmentStmt,
+		pruneLearnerStatsStmt:                 q.pruneLearnerStatsStmt,
+		publishCourseRevisionStmt:             q.publishCourseRevisionStmt,
+		purgeFinishedJobsStmt:                 q.purgeFinishedJobsStmt,
+		recordLessonActivityStmt:              q.recordLessonActivityStmt,
+		recordPointEventStmt:                  q.recordPointEventStmt,
+		refreshCourseDailyStatsStmt:           q.refreshCourseDailyStatsStmt,
+		refreshLearnerStatsStmt:               q.refreshLearnerStatsStmt,
+		refreshLessonStatsStmt:                q.refreshLessonStatsStmt,
+		removeCourseStaffStmt:                 q.removeCourseStaffStmt,
+		removeForumPostUpvoteStmt:             q.removeForumPostUpvoteStmt,
+		removeFromCohortStmt:                  q.removeFromCohortStmt,
+		removeOrganizationMemberStmt:          q.removeOrganizationMemberStmt,
+		replyToCourseReviewStmt:               q.replyToCourseReviewStmt,
+		reportCourseReviewStmt:                q.reportCourseReviewStmt,
+		requeueDeadJobStmt:                    q.requeueDeadJobStmt,
+		retryJobLaterStmt:                     q.retryJobLaterStmt,
+		reviewTeacherApplicationStmt:          q.reviewTeacherApplicationStmt,
+		revokeBadgeCredentialStmt:             q.revokeBadgeCredentialStmt,
+		revokeCertificateStmt:                 q.revokeCertificateStmt,
+		saveCertificateFileStmt:               q.saveCertificateFileStmt,
+		setAnalyticsWatermarkStmt:             q.setAnalyticsWatermarkStmt,
+		setCourseAuthorStmt:                   q.setCourseAuthorStmt,
+		setCourseReviewHiddenStmt:             q.setCourseReviewHiddenStmt,
+		setForumPostHiddenStmt:                q.setForumPostHiddenStmt,
+		setForumThreadAnswerStmt:              q.setForumThreadAnswerStmt,
+		setJobScheduleEnabledStmt:             q.setJobScheduleEnabledStmt,
+		setUserRoleStmt:                       q.setUserRoleStmt,
+		softDeleteForumPostStmt:               q.softDeleteForumPostStmt,
+		touchConversationStmt:                 q.touchConversationStmt,
+		touchForumThreadStmt:                  q.touchForumThreadStmt,
+		unbanFromForumStmt:                    q.unbanFromForumStmt,
+		unpinEnrollmentStmt:                   q.unpinEnrollmentStmt,
+		updateAnnouncementStmt:                q.updateAnnouncementStmt,
+		updateBadgeClassStmt:                  q.updateBadgeClassStmt,
+		updateCohortStmt:                      q.updateCohortStmt,
+		updateCourseDraftStmt:                 q.updateCourseDraftStmt,
+		updateCourseStaffRoleStmt:             q.updateCourseStaffRoleStmt,
+		updateForumThreadFlagsStmt:            q.updateForumThreadFlagsStmt,
+		updateGamificationProgressStmt:        q.updateGamificationProgressStmt,
+		updateGamificationSettingsStmt:        q.updateGamificationSettingsStmt,
+		updateOrganizationStmt:                q.updateOrganizationStmt,
+		updateOrganizationMemberRoleStmt:      q.updateOrganizationMemberRoleStmt,
+		updatePublishedAnnouncementStmt:       q.updatePublishedAnnouncementStmt,
+		updateUserPasswordStmt:                q.updateUserPasswordStmt,
+		updateUserRoleStmt:                    q.updateUserRoleStmt,
+		upsertCertificateTemplateStmt:         q.upsertCertificateTemplateStmt,
+		upsertCourseReviewStmt:                q.upsertCourseReviewStmt,
+		upsertJobScheduleStmt:                 q.upsertJobScheduleStmt,
+		upsertNotificationPreferenceStmt:      q.upsertNotificationPreferenceStmt,
+		upsertNotificationWebhookStmt:         q.upsertNotificationWebhookStmt,
+		upvoteForumPostStmt:                   q.upvoteForumPostStmt,
 	}
 }
@@ -6,16 +6,452 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type AnalyticsRollup struct {
+	Name           string    `json:"name"`
+	ProcessedUntil time.Time `json:"processed_until"`
+}
+
+type Announcement struct {
+	ID          int32         `json:"id"`
+	CourseID    int32         `json:"course_id"`
+	CohortID    sql.NullInt32 `json:"cohort_id"`
+	AuthorID    sql.NullInt32 `json:"author_id"`
+	Title       string        `json:"title"`
+	Body        string        `json:"body"`
+	BodyHtml    string        `json:"body_html"`
+	SendEmail   bool          `json:"send_email"`
+	PublishAt   time.Time     `json:"publish_at"`
+	PublishedAt sql.NullTime  `json:"published_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type AnnouncementRead struct {
+	AnnouncementID int32     `json:"announcement_id"`
+	UserID         int32     `json:"user_id"`
+	ReadAt         time.Time `json:"read_at"`
+}
+
+type BadgeClass struct {
+	ID             int32          `json:"id"`
+	OrganizationID int32          `json:"organization_id"`
+	CourseID       int32          `json:"course_id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Criteria       string         `json:"criteria"`
+	ImageUrl       sql.NullString `json:"image_url"`
+	Active         bool           `json:"active"`
+	CreatedBy      sql.NullInt32  `json:"created_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type BadgeCredential struct {
+	ID               string          `json:"id"`
+	BadgeClassID     int32           `json:"badge_class_id"`
+	UserID           int32           `json:"user_id"`
+	Credential       json.RawMessage `json:"credential"`
+	Jwt              string          `json:"jwt"`
+	KeyID            string          `json:"key_id"`
+	IssuedAt         time.Time       `json:"issued_at"`
+	RevokedAt        sql.NullTime    `json:"revoked_at"`
+	RevokedBy        sql.NullInt32   `json:"revoked_by"`
+	RevocationReason sql.NullString  `json:"revocation_reason"`
+}
+
+type Certificate struct {
+	ID               string         `json:"id"`
+	UserID           int32          `json:"user_id"`
+	CourseID         int32          `json:"course_id"`
+	RecipientName    string         `json:"recipient_name"`
+	CourseTitle      string         `json:"course_title"`
+	InstructorName   string         `json:"instructor_name"`
+	CompletedAt      time.Time      `json:"completed_at"`
+	Status           string         `json:"status"`
+	PdfSha256        sql.NullString `json:"pdf_sha256"`
+	IssuedAt         sql.NullTime   `json:"issued_at"`
+	RevokedAt        sql.NullTime   `json:"revoked_at"`
+	RevokedBy        sql.NullInt32  `json:"revoked_by"`
+	RevocationReason sql.NullString `json:"revocation_reason"`
+	CreatedAt        time.Time      `json:"created_at"`
+	Manifest         sql.NullString `json:"manifest"`
+	KeyID            sql.NullString `json:"key_id"`
+}
+
+type CertificateFile struct {
+	CertificateID string    `json:"certificate_id"`
+	Content       []byte    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CertificateTemplate struct {
+	CourseID       int32          `json:"course_id"`
+	Title          string         `json:"title"`
+	Body           string         `json:"body"`
+	SignatoryName  sql.NullString `json:"signatory_name"`
+	SignatoryTitle sql.NullString `json:"signatory_title"`
+	AccentColor    sql.NullString `json:"accent_color"`
+	UpdatedBy      sql.NullInt32  `json:"updated_by"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type Cohort struct {
+	ID             int32         `json:"id"`
+	CourseID       int32         `json:"course_id"`
+	Name           string        `json:"name"`
+	StartDate      time.Time     `json:"start_date"`
+	EndDate        time.Time     `json:"end_date"`
+	InstructorID   sql.NullInt32 `json:"instructor_id"`
+	EnrollmentCode string        `json:"enrollment_code"`
+	CreatedAt      time.Time     `json:"created_at"`
+	OrganizationID int32         `json:"organization_id"`
+}
+
+type Conversation struct {
+	ID            int32         `json:"id"`
+	Subject       string        `json:"subject"`
+	IsGroup       bool          `json:"is_group"`
+	CreatedBy     sql.NullInt32 `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+	LastMessageAt time.Time     `json:"last_message_at"`
+}
+
+type ConversationParticipant struct {
+	ConversationID    int32         `json:"conversation_id"`
+	UserID            int32         `json:"user_id"`
+	JoinedAt          time.Time     `json:"joined_at"`
+	LastReadMessageID sql.NullInt32 `json:"last_read_message_id"`
+	LastReadAt        sql.NullTime  `json:"last_read_at"`
+}
+
 type Course struct {
+	ID                  int32          `json:"id"`
+	Title               string         `json:"title"`
+	Description         sql.NullString `json:"description"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	AuthorID            sql.NullInt32  `json:"author_id"`
+	PublishedRevisionID sql.NullInt32  `json:"published_revision_id"`
+	Version             int32          `json:"version"`
+	RatingCount         int32          `json:"rating_count"`
+	RatingSum           int32          `json:"rating_sum"`
+	RatingUpdatedAt     sql.NullTime   `json:"rating_updated_at"`
+	OrganizationID      int32          `json:"organization_id"`
+}
+
+type CourseDailyStat struct {
+	CourseID       int32     `json:"course_id"`
+	Day            time.Time `json:"day"`
+	Enrollments    int32     `json:"enrollments"`
+	Completions    int32     `json:"completions"`
+	ActiveLearners int32     `json:"active_learners"`
+}
+
+type CourseDraft struct {
+	CourseID    int32           `json:"course_id"`
+	Title       string          `json:"title"`
+	Description sql.NullString  `json:"description"`
+	Lessons     json.RawMessage `json:"lessons"`
+	UpdatedBy   sql.NullInt32   `json:"updated_by"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Version     int32           `json:"version"`
+}
+
+type CourseReview struct {
 	ID          int32          `json:"id"`
-	Title       string         `json:"title"`
-	Description sql.NullString `json:"description"`
+	CourseID    int32          `json:"course_id"`
+	UserID      int32          `json:"user_id"`
+	Rating      int32          `json:"rating"`
+	Body        string         `json:"body"`
+	Hidden      bool           `json:"hidden"`
+	ModeratedBy sql.NullInt32  `json:"moderated_by"`
+	ReplyBody   sql.NullString `json:"reply_body"`
+	RepliedBy   sql.NullInt32  `json:"replied_by"`
+	RepliedAt   sql.NullTime   `json:"replied_at"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
-	AuthorID    sql.NullInt32  `json:"author_id"`
+}
+
+type CourseReviewReport struct {
+	ReviewID  int32     `json:"review_id"`
+	UserID    int32     `json:"user_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CourseRevision struct {
+	ID          int32           `json:"id"`
+	CourseID    int32           `json:"course_id"`
+	Number      int32           `json:"number"`
+	Title       string          `json:"title"`
+	Description sql.NullString  `json:"description"`
+	Lessons     json.RawMessage `json:"lessons"`
+	CreatedBy   sql.NullInt32   `json:"created_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type CourseStaff struct {
+	CourseID int32     `json:"course_id"`
+	UserID   int32     `json:"user_id"`
+	Role     string    `json:"role"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+type CourseStaffInvitation struct {
+	ID         int32         `json:"id"`
+	CourseID   int32         `json:"course_id"`
+	Email      string        `json:"email"`
+	Role       string        `json:"role"`
+	TokenHash  string        `json:"token_hash"`
+	InvitedBy  sql.NullInt32 `json:"invited_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	AcceptedAt sql.NullTime  `json:"accepted_at"`
+}
+
+type Enrollment struct {
+	ID                int32         `json:"id"`
+	UserID            int32         `json:"user_id"`
+	CourseID          int32         `json:"course_id"`
+	CohortID          sql.NullInt32 `json:"cohort_id"`
+	EnrolledAt        time.Time     `json:"enrolled_at"`
+	StartedRevisionID sql.NullInt32 `json:"started_revision_id"`
+	PinnedRevisionID  sql.NullInt32 `json:"pinned_revision_id"`
+	CompletedAt       sql.NullTime  `json:"completed_at"`
+}
+
+type ForumBan struct {
+	CourseID  int32         `json:"course_id"`
+	UserID    int32         `json:"user_id"`
+	BannedBy  sql.NullInt32 `json:"banned_by"`
+	Reason    string        `json:"reason"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type ForumPost struct {
+	ID        int32         `json:"id"`
+	ThreadID  int32         `json:"thread_id"`
+	ParentID  sql.NullInt32 `json:"parent_id"`
+	AuthorID  int32         `json:"author_id"`
+	Body      string        `json:"body"`
+	BodyHtml  string        `json:"body_html"`
+	Hidden    bool          `json:"hidden"`
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type ForumPostVote struct {
+	PostID    int32     `json:"post_id"`
+	UserID    int32     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ForumThread struct {
+	ID             int32         `json:"id"`
+	CourseID       int32         `json:"course_id"`
+	LessonID       sql.NullInt32 `json:"lesson_id"`
+	CohortID       sql.NullInt32 `json:"cohort_id"`
+	AuthorID       int32         `json:"author_id"`
+	Title          string        `json:"title"`
+	Body           string        `json:"body"`
+	BodyHtml       string        `json:"body_html"`
+	Pinned         bool          `json:"pinned"`
+	Locked         bool          `json:"locked"`
+	Hidden         bool          `json:"hidden"`
+	AcceptedPostID sql.NullInt32 `json:"accepted_post_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	LastActivityAt time.Time     `json:"last_activity_at"`
+}
+
+type GamificationProfile struct {
+	UserID            int32        `json:"user_id"`
+	Points            int32        `json:"points"`
+	CurrentStreak     int32        `json:"current_streak"`
+	LongestStreak     int32        `json:"longest_streak"`
+	LastActiveOn      sql.NullTime `json:"last_active_on"`
+	Timezone          string       `json:"timezone"`
+	LeaderboardOptOut bool         `json:"leaderboard_opt_out"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+type Job struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Priority    int32           `json:"priority"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedBy    sql.NullString  `json:"locked_by"`
+	LockedUntil sql.NullTime    `json:"locked_until"`
+	LastError   sql.NullString  `json:"last_error"`
+	UniqueKey   sql.NullString  `json:"unique_key"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  sql.NullTime    `json:"finished_at"`
+}
+
+type JobSchedule struct {
+	Name      string          `json:"name"`
+	CronExpr  string          `json:"cron_expr"`
+	Kind      string          `json:"kind"`
+	Payload   json.RawMessage `json:"payload"`
+	Enabled   bool            `json:"enabled"`
+	NextRunAt time.Time       `json:"next_run_at"`
+	LastRunAt sql.NullTime    `json:"last_run_at"`
+}
+
+type LearnerStat struct {
+	CourseID         int32        `json:"course_id"`
+	UserID           int32        `json:"user_id"`
+	EnrolledAt       time.Time    `json:"enrolled_at"`
+	CompletedAt      sql.NullTime `json:"completed_at"`
+	LessonsCompleted int32        `json:"lessons_completed"`
+	TimeSpentSeconds int64        `json:"time_spent_seconds"`
+	LastActiveAt     sql.NullTime `json:"last_active_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type Lesson struct {
+	ID        int32     `json:"id"`
+	CourseID  int32     `json:"course_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LessonActivity struct {
+	ID         int64     `json:"id"`
+	UserID     int32     `json:"user_id"`
+	CourseID   int32     `json:"course_id"`
+	LessonID   int32     `json:"lesson_id"`
+	Seconds    int32     `json:"seconds"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type LessonCompletion struct {
+	UserID      int32     `json:"user_id"`
+	LessonID    int32     `json:"lesson_id"`
+	CourseID    int32     `json:"course_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type LessonStat struct {
+	LessonID         int32     `json:"lesson_id"`
+	CourseID         int32     `json:"course_id"`
+	Completions      int32     `json:"completions"`
+	Learners         int32     `json:"learners"`
+	TimeSpentSeconds int64     `json:"time_spent_seconds"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type Message struct {
+	ID             int32     `json:"id"`
+	ConversationID int32     `json:"conversation_id"`
+	SenderID       int32     `json:"sender_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type MessageAttachment struct {
+	ID          int32     `json:"id"`
+	MessageID   int32     `json:"message_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int32     `json:"size_bytes"`
+	Data        []byte    `json:"data"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int32           `json:"id"`
+	UserID    int32           `json:"user_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Link      string          `json:"link"`
+	Data      json.RawMessage `json:"data"`
+	InApp     bool            `json:"in_app"`
+	DedupeKey sql.NullString  `json:"dedupe_key"`
+	ReadAt    sql.NullTime    `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type NotificationDelivery struct {
+	ID             int32          `json:"id"`
+	NotificationID int32          `json:"notification_id"`
+	UserID         int32          `json:"user_id"`
+	Channel        string         `json:"channel"`
+	Digest         bool           `json:"digest"`
+	Status         string         `json:"status"`
+	Attempts       int32          `json:"attempts"`
+	LastError      sql.NullString `json:"last_error"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	SentAt         sql.NullTime   `json:"sent_at"`
+}
+
+type NotificationPreference struct {
+	UserID    int32     `json:"user_id"`
+	EventType string    `json:"event_type"`
+	InApp     bool      `json:"in_app"`
+	Email     bool      `json:"email"`
+	Webhook   bool      `json:"webhook"`
+	Digest    bool      `json:"digest"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NotificationWebhook struct {
+	UserID    int32     `json:"user_id"`
+	Url       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Organization struct {
+	ID           int32          `json:"id"`
+	Slug         string         `json:"slug"`
+	Name         string         `json:"name"`
+	LogoUrl      sql.NullString `json:"logo_url"`
+	PrimaryColor sql.NullString `json:"primary_color"`
+	AccentColor  sql.NullString `json:"accent_color"`
+	SupportEmail sql.NullString `json:"support_email"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int32     `json:"organization_id"`
+	UserID         int32     `json:"user_id"`
+	Role           string    `json:"role"`
+	JoinedAt       time.Time `json:"joined_at"`
+}
+
+type PointEvent struct {
+	ID          int64         `json:"id"`
+	UserID      int32         `json:"user_id"`
+	CourseID    sql.NullInt32 `json:"course_id"`
+	Event       string        `json:"event"`
+	Points      int32         `json:"points"`
+	SourceKey   string        `json:"source_key"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+	ProcessedAt sql.NullTime  `json:"processed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type TeacherApplication struct {
+	ID         int32          `json:"id"`
+	UserID     int32          `json:"user_id"`
+	Motivation string         `json:"motivation"`
+	Status     string         `json:"status"`
+	ReviewedBy sql.NullInt32  `json:"reviewed_by"`
+	ReviewNote sql.NullString `json:"review_note"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
 }
 
 type User struct {
@@ -26,3 +462,29 @@ type User struct {
 	Role      string       `json:"role"`
 	CreatedAt sql.NullTime `json:"created_at"`
 }
+
+type UserAchievement struct {
+	UserID         int32     `json:"user_id"`
+	AchievementKey string    `json:"achievement_key"`
+	EarnedAt       time.Time `json:"earned_at"`
+}
+
+type UserEvent struct {
+	ID        int64           `json:"id"`
+	UserID    int32           `json:"user_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type UserInvitation struct {
+	ID         int32         `json:"id"`
+	Email      string        `json:"email"`
+	Role       string        `json:"role"`
+	TokenHash  string        `json:"token_hash"`
+	InvitedBy  sql.NullInt32 `json:"invited_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	AcceptedAt sql.NullTime  `json:"accepted_at"`
+	AcceptedBy sql.NullInt32 `json:"accepted_by"`
+}
@@ -0,0 +1,36 @@
+// Package events diffuse en temps réel les événements destinés aux utilisateurs connectés.
+//
+// Les événements sont écrits dans la table user_events, dans la transaction qui les produit ;
+// un trigger émet alors un NOTIFY que chaque réplica relaie à ses propres abonnés.
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+const (
+	MessageCreated        = "message.created"
+	EnrollmentCreated     = "enrollment.created"
+	EnrollmentRemoved     = "enrollment.removed"
+	AnnouncementPublished = "announcement.published"
+	GradeReleased         = "grade.released"
+	NotificationCreated   = "notification.created"
+)
+
+// Publish enregistre un événement pour chaque destinataire. Appelé avec des requêtes liées à une
+// transaction, rien n'est diffusé si celle-ci est annulée.
+func Publish(ctx context.Context, queries db.Querier, userIDs []int32, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := queries.CreateUserEvent(ctx, db.CreateUserEventParams{UserID: userID, Type: eventType, Payload: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
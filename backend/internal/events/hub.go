@@ -0,0 +1,236 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"online-learning-platform-backend/internal/db"
+)
+
+const (
+	notifyChannel    = "user_events"
+	subscriberBuffer = 64
+	catchUpBatch     = 500
+	retention        = 7 * 24 * time.Hour
+
+	pingInterval      = 90 * time.Second
+	minReconnectDelay = 2 * time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// Subscription reçoit les événements d'un utilisateur. Le canal est fermé si le client ne suit
+// pas le rythme : il se reconnecte alors avec Last-Event-ID et rejoue ce qu'il a manqué.
+type Subscription struct {
+	C      <-chan db.UserEvent
+	ch     chan db.UserEvent
+	userID int32
+}
+
+// Hub répartit les notifications Postgres entre les connexions ouvertes sur ce réplica.
+type Hub struct {
+	queries db.Querier
+
+	mu          sync.Mutex
+	subscribers map[int32]map[*Subscription]struct{}
+	closed      bool
+}
+
+func NewHub(queries db.Querier) *Hub {
+	return &Hub{queries: queries, subscribers: make(map[int32]map[*Subscription]struct{})}
+}
+
+func (h *Hub) Subscribe(userID int32) *Subscription {
+	ch := make(chan db.UserEvent, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, userID: userID}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return sub
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// remove suppose h.mu verrouillé.
+func (h *Hub) remove(sub *Subscription) {
+	subs, ok := h.subscribers[sub.userID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.ch)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.userID)
+	}
+}
+
+// Close termine tous les flux ouverts, par exemple à l'arrêt du serveur : les clients se
+// reconnectent (sur un autre réplica) avec Last-Event-ID. Les abonnements suivants sont fermés d'emblée.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subs := range h.subscribers {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+}
+
+func (h *Hub) hasSubscribers(userID int32) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userID]) > 0
+}
+
+func (h *Hub) deliver(event db.UserEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers[event.UserID] {
+		select {
+		case sub.ch <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// Run écoute le canal Postgres jusqu'à l'annulation du contexte, sur une connexion dédiée hors
+// du pool. Après une coupure, la connexion est rétablie et les événements manqués relus depuis
+// le dernier identifiant vu.
+func (h *Hub) Run(ctx context.Context, dsn string) error {
+	// -1 : point de départ inconnu tant que la base n'a pas répondu ; rien n'est alors rejoué.
+	lastID := int64(-1)
+	delay := minReconnectDelay
+	lastCleanup := time.Now()
+	for {
+		connected, err := h.listen(ctx, dsn, &lastID, &lastCleanup)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			delay = minReconnectDelay
+		}
+		slog.Warn("events: écoute Postgres", "error", err, "retry_in", delay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen tient une connexion LISTEN jusqu'à une erreur ; connected indique qu'elle a été établie.
+func (h *Hub) listen(ctx context.Context, dsn string, lastID *int64, lastCleanup *time.Time) (bool, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return false, err
+	}
+	*lastID = h.catchUp(ctx, *lastID)
+
+	for {
+		// Réveil périodique pour vérifier la connexion et purger le journal.
+		waitCtx, cancel := context.WithTimeout(ctx, pingInterval)
+		n, err := conn.WaitForNotification(waitCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return true, nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			if err := conn.Ping(ctx); err != nil {
+				return true, err
+			}
+			if time.Since(*lastCleanup) >= time.Hour {
+				*lastCleanup = time.Now()
+				if _, err := h.queries.DeleteUserEventsBefore(ctx, time.Now().Add(-retention)); err != nil {
+					slog.Error("events: purge du journal", "error", err)
+				}
+			}
+			continue
+		}
+		if err != nil {
+			return true, err
+		}
+
+		id, userID, ok := parseNotification(n.Payload)
+		if !ok {
+			continue
+		}
+		if id > *lastID {
+			*lastID = id
+		}
+		if !h.hasSubscribers(userID) {
+			continue
+		}
+		event, err := h.queries.GetUserEvent(ctx, id)
+		if err != nil {
+			slog.Error("events: lecture de l'événement", "event_id", id, "error", err)
+			continue
+		}
+		h.deliver(event)
+	}
+}
+
+func (h *Hub) catchUp(ctx context.Context, lastID int64) int64 {
+	if lastID < 0 {
+		latest, err := h.queries.GetLatestUserEventID(ctx)
+		if err != nil {
+			return lastID
+		}
+		return latest
+	}
+	for {
+		batch, err := h.queries.ListAllUserEventsAfter(ctx, db.ListAllUserEventsAfterParams{AfterID: lastID, Limit: catchUpBatch})
+		if err != nil {
+			slog.Error("events: rattrapage après reconnexion", "error", err)
+			return lastID
+		}
+		for _, event := range batch {
+			h.deliver(event)
+			lastID = event.ID
+		}
+		if len(batch) < catchUpBatch {
+			return lastID
+		}
+	}
+}
+
+func parseNotification(extra string) (int64, int32, bool) {
+	rawID, rawUser, found := strings.Cut(extra, ":")
+	if !found {
+		return 0, 0, false
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	userID, err := strconv.ParseInt(rawUser, 10, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	return id, int32(userID), true
+}
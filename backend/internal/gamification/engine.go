@@ -0,0 +1,158 @@
+package gamification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// JobApply applique un événement au profil de son auteur (points, série, succès).
+const JobApply = "gamification.apply"
+
+// Event décrit une action qui rapporte des points. SourceKey identifie l'action : l'enregistrer
+// deux fois (leçon terminée à nouveau, réponse acceptée puis re-acceptée) ne compte qu'une fois.
+type Event struct {
+	UserID     int32
+	CourseID   int32 // 0 hors cours
+	Type       string
+	SourceKey  string
+	OccurredAt time.Time
+}
+
+// Record enregistre l'événement et programme son application ; à appeler dans la transaction
+// de l'action elle-même. Les points sont fixés par le worker, selon le barème en vigueur.
+func Record(ctx context.Context, queries db.Querier, e Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	id, err := queries.RecordPointEvent(ctx, db.RecordPointEventParams{
+		UserID:     e.UserID,
+		CourseID:   sql.NullInt32{Int32: e.CourseID, Valid: e.CourseID > 0},
+		Event:      e.Type,
+		SourceKey:  e.SourceKey,
+		OccurredAt: e.OccurredAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = jobs.Enqueue(ctx, queries, JobApply, map[string]int64{"point_event_id": id},
+		jobs.Options{UniqueKey: fmt.Sprintf("%s:%d", JobApply, id)})
+	return err
+}
+
+// Engine applique les événements enregistrés, hors des requêtes HTTP.
+type Engine struct {
+	queries repository.Store
+	rules   *Rules
+}
+
+func NewEngine(queries repository.Store, rules *Rules) *Engine {
+	return &Engine{queries: queries, rules: rules}
+}
+
+// Register déclare l'application des événements auprès du worker.
+func (e *Engine) Register(w *jobs.Worker) {
+	w.Handle(JobApply, func(ctx context.Context, job db.Job) error {
+		var payload struct {
+			PointEventID int64 `json:"point_event_id"`
+		}
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return e.Apply(ctx, payload.PointEventID)
+	})
+}
+
+// Apply crédite les points d'un événement, prolonge la série du jour (dans le fuseau de
+// l'utilisateur) et décerne les succès atteints. Sans effet sur un événement déjà appliqué.
+func (e *Engine) Apply(ctx context.Context, eventID int64) error {
+	return e.queries.InTx(ctx, func(qtx db.Querier) error {
+		event, err := qtx.GetPointEvent(ctx, eventID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && event.ProcessedAt.Valid) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := qtx.EnsureGamificationProfile(ctx, event.UserID); err != nil {
+			return err
+		}
+		// Verrou sur le profil : deux événements du même utilisateur s'appliquent l'un après l'autre.
+		profile, err := qtx.LockGamificationProfile(ctx, event.UserID)
+		if err != nil {
+			return err
+		}
+		points := e.rules.Points[event.Event]
+		n, err := qtx.MarkPointEventProcessed(ctx, db.MarkPointEventProcessedParams{ID: event.ID, Points: points})
+		if err != nil || n == 0 {
+			return err
+		}
+		streak := Streak{Current: profile.CurrentStreak, Longest: profile.LongestStreak, LastActiveOn: profile.LastActiveOn}
+		streak = streak.Record(LocalDay(event.OccurredAt, Location(profile.Timezone)))
+		profile.Points += points
+		err = qtx.UpdateGamificationProgress(ctx, db.UpdateGamificationProgressParams{
+			UserID:        event.UserID,
+			Points:        profile.Points,
+			CurrentStreak: streak.Current,
+			LongestStreak: streak.Longest,
+			LastActiveOn:  streak.LastActiveOn,
+		})
+		if err != nil {
+			return err
+		}
+		for _, a := range e.rules.Achievements {
+			reached, err := e.reached(ctx, qtx, a, event, profile.Points, streak)
+			if err != nil {
+				return err
+			}
+			if !reached {
+				continue
+			}
+			n, err := qtx.AwardAchievement(ctx, db.AwardAchievementParams{UserID: event.UserID, AchievementKey: a.Key})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				continue
+			}
+			err = notifications.Notify(ctx, qtx, []int32{event.UserID}, notifications.Notification{
+				Type:  notifications.AchievementUnlocked,
+				Title: fmt.Sprintf("Succès débloqué : %s", a.Name),
+				Body:  a.Description,
+				Link:  "/profile",
+				Data:  map[string]any{"achievement": a.Key},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// reached évalue un succès après application de event ; les succès comptant des événements
+// ne sont réévalués que sur un événement de leur type.
+func (e *Engine) reached(ctx context.Context, q db.Querier, a Achievement, event db.PointEvent, points int32, streak Streak) (bool, error) {
+	switch {
+	case a.Event != "":
+		if a.Event != event.Event {
+			return false, nil
+		}
+		count, err := q.CountUserPointEvents(ctx, db.CountUserPointEventsParams{UserID: event.UserID, Event: a.Event})
+		return count >= a.Count, err
+	case a.Streak > 0:
+		return streak.Current >= a.Streak, nil
+	default:
+		return points >= a.Points, nil
+	}
+}
@@ -0,0 +1,141 @@
+// Package gamification attribue des points aux événements d'apprentissage, tient les séries de
+// jours actifs et décerne les succès décrits dans la configuration (rules.yaml).
+package gamification
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Événements qui rapportent des points.
+const (
+	EventLessonCompleted     = "lesson.completed"
+	EventCourseCompleted     = "course.completed"
+	EventQuizPassed          = "quiz.passed"
+	EventForumAnswerAccepted = "forum.answer_accepted"
+)
+
+// Events liste les événements connus ; une règle qui en cite un autre est refusée.
+var Events = []string{EventLessonCompleted, EventCourseCompleted, EventQuizPassed, EventForumAnswerAccepted}
+
+//go:embed rules.yaml
+var defaultRules []byte
+
+// Rules : barème par événement et succès à débloquer.
+type Rules struct {
+	Points       map[string]int32 `yaml:"points"`
+	Achievements []Achievement    `yaml:"achievements"`
+}
+
+// Achievement est débloqué au premier des critères atteint : count événements du type event,
+// une série de streak jours, ou points au total. Un seul critère par succès.
+type Achievement struct {
+	Key         string `yaml:"key" json:"key"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Event       string `yaml:"event" json:"event,omitempty"`
+	Count       int64  `yaml:"count" json:"count,omitempty"`
+	Streak      int32  `yaml:"streak" json:"streak,omitempty"`
+	Points      int32  `yaml:"points" json:"points,omitempty"`
+}
+
+// DefaultRules renvoie les règles embarquées.
+func DefaultRules() (*Rules, error) {
+	return ParseRules(defaultRules)
+}
+
+// LoadRules lit GAMIFICATION_RULES_FILE, ou les règles embarquées à défaut.
+func LoadRules() (*Rules, error) {
+	path := os.Getenv("GAMIFICATION_RULES_FILE")
+	if path == "" {
+		return DefaultRules()
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("règles de gamification: %w", err)
+	}
+	return ParseRules(raw)
+}
+
+// ParseRules lit des règles YAML ; les clés inconnues sont refusées pour attraper les fautes de frappe.
+func ParseRules(raw []byte) (*Rules, error) {
+	var rules Rules
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("règles de gamification: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("règles de gamification: %w", err)
+	}
+	return &rules, nil
+}
+
+func knownEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate vérifie le barème et que chaque succès a une clé unique et un seul critère.
+func (r *Rules) Validate() error {
+	for event, points := range r.Points {
+		if !knownEvent(event) {
+			return fmt.Errorf("événement inconnu %q", event)
+		}
+		if points < 0 {
+			return fmt.Errorf("points négatifs pour %q", event)
+		}
+	}
+	seen := map[string]bool{}
+	for _, a := range r.Achievements {
+		if a.Key == "" || a.Name == "" {
+			return errors.New("succès sans clé ou sans nom")
+		}
+		if seen[a.Key] {
+			return fmt.Errorf("succès %q défini deux fois", a.Key)
+		}
+		seen[a.Key] = true
+		criteria := 0
+		if a.Event != "" || a.Count != 0 {
+			if !knownEvent(a.Event) || a.Count <= 0 {
+				return fmt.Errorf("succès %q : event connu et count positif attendus", a.Key)
+			}
+			criteria++
+		}
+		if a.Streak != 0 {
+			if a.Streak < 0 {
+				return fmt.Errorf("succès %q : streak négatif", a.Key)
+			}
+			criteria++
+		}
+		if a.Points != 0 {
+			if a.Points < 0 {
+				return fmt.Errorf("succès %q : points négatifs", a.Key)
+			}
+			criteria++
+		}
+		if criteria != 1 {
+			return fmt.Errorf("succès %q : un seul critère attendu (event/count, streak ou points)", a.Key)
+		}
+	}
+	return nil
+}
+
+// Achievement renvoie le succès de clé key.
+func (r *Rules) Achievement(key string) (Achievement, bool) {
+	for _, a := range r.Achievements {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
@@ -0,0 +1,64 @@
+package gamification
+
+import (
+	"database/sql"
+	"time"
+	_ "time/tzdata" // fuseaux des profils même sans base IANA sur la machine
+)
+
+// Location renvoie le fuseau name, UTC s'il est inconnu.
+func Location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalDay renvoie le jour calendaire de t dans loc, à minuit UTC comme une colonne DATE.
+func LocalDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Streak : série de jours consécutifs avec au moins un événement.
+type Streak struct {
+	Current      int32
+	Longest      int32
+	LastActiveOn sql.NullTime
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// Record compte une activité le jour day (voir LocalDay). Un jour déjà compté, ou antérieur au
+// dernier jour actif (événement traité en retard), ne change rien ; un jour manqué remet la série à 1.
+func (s Streak) Record(day time.Time) Streak {
+	if s.LastActiveOn.Valid {
+		switch gap := daysBetween(s.LastActiveOn.Time, day); {
+		case gap <= 0:
+			return s
+		case gap == 1:
+			s.Current++
+		default:
+			s.Current = 1
+		}
+	} else {
+		s.Current = 1
+	}
+	if s.Current > s.Longest {
+		s.Longest = s.Current
+	}
+	s.LastActiveOn = sql.NullTime{Time: day, Valid: true}
+	return s
+}
+
+// At renvoie la série en cours le jour today : elle tient encore si le dernier jour actif est
+// aujourd'hui ou hier, sinon elle est rompue.
+func (s Streak) At(today time.Time) int32 {
+	if !s.LastActiveOn.Valid || daysBetween(s.LastActiveOn.Time, today) > 1 {
+		return 0
+	}
+	return s.Current
+}
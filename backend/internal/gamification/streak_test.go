@@ -0,0 +1,66 @@
+package gamification
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreakRecord(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	var s Streak
+	for _, step := range []struct {
+		day              int
+		current, longest int32
+	}{
+		{1, 1, 1},
+		{1, 1, 1}, // même jour
+		{2, 2, 2},
+		{3, 3, 3},
+		{2, 3, 3}, // événement traité en retard
+		{5, 1, 3}, // jour manqué
+		{6, 2, 3},
+	} {
+		s = s.Record(day(step.day))
+		if s.Current != step.current || s.Longest != step.longest {
+			t.Fatalf("jour %d : série %d/%d, attendu %d/%d", step.day, s.Current, s.Longest, step.current, step.longest)
+		}
+	}
+	if got := s.At(day(7)); got != 2 {
+		t.Fatalf("série le lendemain : %d", got)
+	}
+	if got := s.At(day(8)); got != 0 {
+		t.Fatalf("série après un jour manqué : %d", got)
+	}
+}
+
+func TestLocalDay(t *testing.T) {
+	// 23h30 UTC le 1er mars : déjà le 2 à Paris, encore le 1er à New York.
+	at := time.Date(2026, time.March, 1, 23, 30, 0, 0, time.UTC)
+	if got := LocalDay(at, Location("Europe/Paris")); got.Day() != 2 {
+		t.Fatalf("Paris : %v", got)
+	}
+	if got := LocalDay(at, Location("America/New_York")); got.Day() != 1 {
+		t.Fatalf("New York : %v", got)
+	}
+	if Location("Mars/Olympus") != time.UTC {
+		t.Fatal("fuseau inconnu : UTC attendu")
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	if _, err := DefaultRules(); err != nil {
+		t.Fatal(err)
+	}
+	for name, raw := range map[string]string{
+		"événement inconnu": "points:\n  lesson.started: 5\n",
+		"deux critères":     "achievements:\n  - {key: a, name: A, streak: 3, points: 10}\n",
+		"sans critère":      "achievements:\n  - {key: a, name: A}\n",
+		"clé en double":     "achievements:\n  - {key: a, name: A, streak: 3}\n  - {key: a, name: B, streak: 4}\n",
+		"champ inconnu":     "achievements:\n  - {key: a, name: A, streek: 3}\n",
+		"count sans event":  "achievements:\n  - {key: a, name: A, count: 3}\n",
+	} {
+		if _, err := ParseRules([]byte(raw)); err == nil {
+			t.Errorf("%s : erreur attendue", name)
+		}
+	}
+}
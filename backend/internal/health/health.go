@@ -0,0 +1,19 @@
+// Package health vérifie qu'une instance peut servir du trafic : base joignable et schéma
+// déployé au moins jusqu'à la dernière migration connue du binaire.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"online-learning-platform-backend/internal/migrate"
+)
+
+// Check renvoie une erreur si la base est injoignable ou si des migrations du plan embarqué manquent.
+func Check(ctx context.Context, dbConn *sql.DB, migrator *migrate.Migrator) error {
+	if err := dbConn.PingContext(ctx); err != nil {
+		return fmt.Errorf("base de données injoignable: %w", err)
+	}
+	return migrator.Check(ctx)
+}
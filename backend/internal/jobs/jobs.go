@@ -0,0 +1,86 @@
+// Package jobs fournit une file de tâches de fond persistée dans Postgres : réservation avec
+// FOR UPDATE SKIP LOCKED, nouvelles tentatives avec backoff exponentiel, dead-letter, tâches
+// planifiées (cron) et déduplication par clé.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+const defaultMaxAttempts = 5
+
+// Handler traite une tâche ; une erreur (ou un panic) déclenche une nouvelle tentative.
+type Handler func(ctx context.Context, job db.Job) error
+
+type Options struct {
+	// Priority : les tâches de priorité haute passent en premier.
+	Priority    int32
+	MaxAttempts int32
+	// RunAt diffère l'exécution ; zéro signifie dès que possible.
+	RunAt time.Time
+	// UniqueKey : tant qu'une tâche de même clé est en attente ou en cours, Enqueue ne fait rien.
+	UniqueKey string
+}
+
+// Enqueue ajoute une tâche. Avec des requêtes liées à une transaction, elle n'existe qu'une fois
+// celle-ci validée. Renvoie 0 si une tâche de même UniqueKey est déjà en attente.
+func Enqueue(ctx context.Context, queries db.Querier, kind string, payload any, opts Options) (int64, error) {
+	data, err := jsonPayload(payload)
+	if err != nil {
+		return 0, err
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.RunAt.IsZero() {
+		opts.RunAt = time.Now()
+	}
+	job, err := queries.EnqueueJob(ctx, db.EnqueueJobParams{
+		Kind:        kind,
+		Payload:     data,
+		Priority:    opts.Priority,
+		MaxAttempts: opts.MaxAttempts,
+		RunAt:       opts.RunAt,
+		UniqueKey:   sql.NullString{String: opts.UniqueKey, Valid: opts.UniqueKey != ""},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return job.ID, nil
+}
+
+func jsonPayload(payload any) (json.RawMessage, error) {
+	switch p := payload.(type) {
+	case nil:
+		return json.RawMessage("{}"), nil
+	case json.RawMessage:
+		return p, nil
+	}
+	return json.Marshal(payload)
+}
+
+// Decode lit le payload d'une tâche.
+func Decode(job db.Job, v any) error {
+	return json.Unmarshal(job.Payload, v)
+}
+
+// backoff : 10 s, 20 s, 40 s… plafonné à une heure.
+func backoff(attempts int32) time.Duration {
+	delay := 10 * time.Second
+	for i := int32(0); i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
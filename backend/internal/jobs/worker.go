@@ -0,0 +1,251 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	pollInterval     = time.Second
+	scheduleInterval = 15 * time.Second
+	// lease borne la durée d'une tâche ; passé ce délai (plus une marge) un autre worker la reprend,
+	// ce qui compte pour un essai : une tâche qui fait tomber son worker finit en dead-letter.
+	lease      = 5 * time.Minute
+	leaseGrace = time.Minute
+	retention  = 7 * 24 * time.Hour
+
+	purgeKind = "jobs.purge"
+)
+
+type schedule struct {
+	name    string
+	spec    string
+	kind    string
+	payload any
+	cron    cron.Schedule
+}
+
+// Worker exécute les tâches avec un pool de goroutines et enfile les tâches planifiées.
+// Plusieurs instances peuvent tourner en parallèle sur la même base.
+type Worker struct {
+	queries     repository.Store
+	id          string
+	concurrency int
+	handlers    map[string]Handler
+	schedules   []schedule
+}
+
+func NewWorker(queries repository.Store, concurrency int) *Worker {
+	host, _ := os.Hostname()
+	w := &Worker{
+		queries:     queries,
+		id:          fmt.Sprintf("%s-%d", host, os.Getpid()),
+		concurrency: concurrency,
+		handlers:    make(map[string]Handler),
+	}
+	w.Handle(purgeKind, func(ctx context.Context, job db.Job) error {
+		_, err := queries.PurgeFinishedJobs(ctx, time.Now().Add(-retention))
+		return err
+	})
+	w.Schedule(purgeKind, "@daily", purgeKind, nil)
+	return w
+}
+
+// Handle associe un type de tâche à son traitement. À appeler avant Run.
+func (w *Worker) Handle(kind string, h Handler) {
+	w.handlers[kind] = h
+}
+
+// Schedule enfile une tâche kind selon une expression cron standard (5 champs ou @daily, @every 1m…).
+func (w *Worker) Schedule(name, spec, kind string, payload any) {
+	parsed, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(fmt.Sprintf("jobs: planification %q invalide: %v", name, err))
+	}
+	w.schedules = append(w.schedules, schedule{name: name, spec: spec, kind: kind, payload: payload, cron: parsed})
+}
+
+// Run bloque jusqu'à l'annulation du contexte puis attend la fin des tâches en cours.
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.runScheduler(ctx)
+	}()
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *Worker) loop(ctx context.Context) {
+	for {
+		worked, err := w.runNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("jobs: réservation impossible", "error", err)
+		}
+		if worked {
+			continue
+		}
+		// Petite gigue pour que les goroutines n'interrogent pas la base au même instant.
+		wait := pollInterval + time.Duration(rand.Int63n(int64(pollInterval/2)))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (w *Worker) runNext(ctx context.Context) (bool, error) {
+	if ctx.Err() != nil {
+		return false, nil
+	}
+	lockedBy := sql.NullString{String: w.id, Valid: true}
+	job, err := w.queries.ClaimJob(ctx, db.ClaimJobParams{
+		LockedBy:    lockedBy,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(lease + leaseGrace), Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// La tâche en cours va au bout même si l'arrêt est demandé : seul le bail la borne.
+	jobCtx, cancel := context.WithTimeout(context.Background(), lease)
+	runErr := w.execute(jobCtx, job)
+	cancel()
+
+	// Attempts compte déjà cet essai : ClaimJob l'incrémente, y compris à la reprise d'un bail expiré.
+	switch {
+	case runErr == nil:
+		err = w.queries.CompleteJob(context.Background(), db.CompleteJobParams{ID: job.ID, LockedBy: lockedBy})
+	case job.Attempts >= job.MaxAttempts:
+		slog.Error("jobs: tâche abandonnée", "kind", job.Kind, "job_id", job.ID, "attempts", job.Attempts, "error", runErr)
+		err = w.queries.KillJob(context.Background(), db.KillJobParams{
+			ID: job.ID, LockedBy: lockedBy, LastError: sql.NullString{String: runErr.Error(), Valid: true},
+		})
+	default:
+		err = w.queries.RetryJobLater(context.Background(), db.RetryJobLaterParams{
+			ID:        job.ID,
+			LockedBy:  lockedBy,
+			LastError: sql.NullString{String: runErr.Error(), Valid: true},
+			RunAt:     time.Now().Add(backoff(job.Attempts - 1)),
+		})
+	}
+	return true, err
+}
+
+func (w *Worker) execute(ctx context.Context, job db.Job) (err error) {
+	handler, ok := w.handlers[job.Kind]
+	if !ok {
+		return fmt.Errorf("aucun traitement pour le type %q", job.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// registerSchedules enregistre les planifications connues ; une expression inchangée garde sa prochaine échéance.
+func (w *Worker) registerSchedules(ctx context.Context) error {
+	for _, s := range w.schedules {
+		data, err := jsonPayload(s.payload)
+		if err != nil {
+			return err
+		}
+		if err := w.queries.UpsertJobSchedule(ctx, db.UpsertJobScheduleParams{
+			Name:      s.name,
+			CronExpr:  s.spec,
+			Kind:      s.kind,
+			Payload:   data,
+			NextRunAt: s.cron.Next(time.Now()),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Worker) runScheduler(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	registered := false
+	for {
+		// Réessayé à chaque tour tant que la base est injoignable.
+		if !registered {
+			if err := w.registerSchedules(ctx); err != nil {
+				slog.Error("jobs: enregistrement des planifications", "error", err)
+			} else {
+				registered = true
+			}
+		}
+		for registered {
+			fired, err := w.fireNextSchedule(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("jobs: planification", "error", err)
+				}
+				break
+			}
+			if !fired {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fireNextSchedule enfile la tâche d'une planification échue. Les échéances manquées pendant un
+// arrêt ne sont pas rattrapées une à une : la prochaine est calculée à partir de maintenant.
+func (w *Worker) fireNextSchedule(ctx context.Context) (bool, error) {
+	fired := false
+	err := w.queries.InTx(ctx, func(qtx db.Querier) error {
+		due, err := qtx.ClaimDueJobSchedule(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		parsed, err := cron.ParseStandard(due.CronExpr)
+		if err != nil {
+			return fmt.Errorf("planification %q: %w", due.Name, err)
+		}
+		var payload any = due.Payload
+		if _, err := Enqueue(ctx, qtx, due.Kind, payload, Options{UniqueKey: "schedule:" + due.Name}); err != nil {
+			return err
+		}
+		if err := qtx.AdvanceJobSchedule(ctx, db.AdvanceJobScheduleParams{Name: due.Name, NextRunAt: parsed.Next(time.Now())}); err != nil {
+			return err
+		}
+		fired = true
+		return nil
+	})
+	return fired, err
+}
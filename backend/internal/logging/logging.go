@@ -0,0 +1,128 @@
+// Package logging configure le logger structuré (log/slog) de l'application : niveau et format
+// selon l'environnement, identifiant de requête tiré du contexte et masquage des champs sensibles.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys : tout attribut dont la clé contient l'un de ces mots est masqué.
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+type ctxKey struct{}
+
+// Setup crée le logger et l'installe comme logger par défaut (slog et log).
+//
+//	APP_ENV=production : JSON, niveau info ; sinon texte, niveau debug.
+//	LOG_LEVEL (debug, info, warn, error) et LOG_FORMAT (json, text) surchargent ces valeurs.
+func Setup() *slog.Logger {
+	production := os.Getenv("APP_ENV") == "production"
+
+	level := slog.LevelDebug
+	if production {
+		level = slog.LevelInfo
+	}
+	if raw := os.Getenv("LOG_LEVEL"); raw != "" {
+		var parsed slog.Level
+		if err := parsed.UnmarshalText([]byte(raw)); err == nil {
+			level = parsed
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+	format := os.Getenv("LOG_FORMAT")
+	if format == "" && production {
+		format = "json"
+	}
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	logger := slog.New(contextHandler{handler})
+	slog.SetDefault(logger)
+	return logger
+}
+
+// WithRequestID rattache l'identifiant de requête au contexte ; les logs *Context le reprennent.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// contextHandler ajoute request_id, et trace_id/span_id si un span est actif, à chaque
+// enregistrement émis avec un contexte de requête.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// RedactQuery masque les paramètres sensibles d'une query string (access_token du flux SSE…).
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return redacted
+	}
+	changed := false
+	for key := range values {
+		if isSensitive(key) {
+			values[key] = []string{redacted}
+			changed = true
+		}
+	}
+	if !changed {
+		return rawQuery
+	}
+	return values.Encode()
+}
@@ -0,0 +1,98 @@
+// Package metrics expose les métriques Prometheus de l'API : latence HTTP par route et statut,
+// état du pool database/sql et compteurs métier.
+package metrics
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "olp"
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Durée des requêtes HTTP par méthode, route et statut.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	Logins = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Connexions réussies.",
+	})
+	FailedLogins = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Tentatives de connexion refusées (e-mail inconnu ou mot de passe invalide).",
+	})
+	Registrations = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Comptes créés.",
+	})
+	Enrollments = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "enrollments_total",
+		Help:      "Inscriptions à une cohorte.",
+	})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestDuration, Logins, FailedLogins, Registrations, Enrollments,
+	)
+}
+
+// RegisterDB ajoute les statistiques du pool de connexions (go_sql_*{db_name="..."}).
+func RegisterDB(dbConn *sql.DB, name string) {
+	registry.MustRegister(collectors.NewDBStatsCollector(dbConn, name))
+}
+
+// Middleware mesure chaque requête. La route est le motif gin (/courses/:id) pour borner la
+// cardinalité ; les URL sans route sont regroupées sous "unmatched".
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler sert /metrics au format d'exposition Prometheus, réservé aux porteurs de token
+// (Authorization: Bearer) : routes, trafic et état du pool ne sont pas publics. Sans token
+// configuré, /metrics répond 404.
+func Handler(token string) gin.HandlerFunc {
+	h := gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		h(c)
+	}
+}
@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Usage décrit la sous-commande migrate, commune au serveur et à cmd/olp.
+const Usage = `  status                 migrations déployées, en attente ou modifiées
+  up [--to <migration>]  déploie les migrations en attente (jusqu'à <migration> incluse)
+  down [n]               annule les n dernières migrations (1 par défaut)
+  down --to <migration>  annule toutes les migrations placées après <migration>
+  verify                 rejoue les scripts verify des migrations déployées
+`
+
+// ErrUsage : arguments de la sous-commande migrate invalides.
+var ErrUsage = errors.New("arguments invalides")
+
+// Command est une sous-commande migrate analysée, prête à être exécutée.
+type Command struct {
+	Action string
+	To     string
+	Steps  int
+}
+
+// ParseCommand lit « status », « up [--to x] », « down [n] [--to x] » ou « verify ». Les
+// arguments sont vérifiés avant toute connexion à la base.
+func ParseCommand(args []string) (Command, error) {
+	if len(args) == 0 {
+		return Command{}, ErrUsage
+	}
+	cmd := Command{Action: args[0], Steps: 1}
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&cmd.To, "to", "", "")
+	if err := flags.Parse(args[1:]); err != nil {
+		return cmd, ErrUsage
+	}
+	switch cmd.Action {
+	case "status", "verify", "up":
+		if flags.NArg() > 0 {
+			return cmd, ErrUsage
+		}
+	case "down":
+		if flags.NArg() > 1 {
+			return cmd, ErrUsage
+		}
+		if flags.NArg() == 1 {
+			steps, err := strconv.Atoi(flags.Arg(0))
+			if err != nil || steps < 1 {
+				return cmd, ErrUsage
+			}
+			cmd.Steps = steps
+		}
+	default:
+		return cmd, ErrUsage
+	}
+	return cmd, nil
+}
+
+// Run exécute la commande et écrit son compte rendu dans out.
+func (cmd Command) Run(ctx context.Context, m *Migrator, out io.Writer) error {
+	switch cmd.Action {
+	case "status":
+		return printStatus(ctx, m, out)
+	case "up":
+		applied, err := m.Up(ctx, cmd.To)
+		fmt.Fprintf(out, "%d migration(s) déployée(s)\n", len(applied))
+		return err
+	case "down":
+		reverted, err := m.Down(ctx, cmd.Steps, cmd.To)
+		fmt.Fprintf(out, "%d migration(s) annulée(s)\n", len(reverted))
+		return err
+	case "verify":
+		if err := m.Verify(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Toutes les migrations déployées sont vérifiées")
+		return nil
+	}
+	return ErrUsage
+}
+
+func printStatus(ctx context.Context, m *Migrator, out io.Writer) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	pending := 0
+	for _, s := range statuses {
+		state, when := "en attente", ""
+		switch {
+		case s.Unknown:
+			state = "inconnue du binaire"
+		case s.Modified:
+			state = "modifiée depuis le déploiement"
+		case s.Deployed:
+			state = "déployée"
+		default:
+			pending++
+		}
+		if s.Deployed {
+			when = s.DeployedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, state, when, s.Note)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nProjet %s : %d migration(s) au plan, %d en attente\n", m.Plan().Project, len(m.Plan().Changes), pending)
+	return nil
+}
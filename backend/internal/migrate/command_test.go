@@ -0,0 +1,22 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	cmd, err := ParseCommand([]string{"down", "3"})
+	if err != nil || cmd.Action != "down" || cmd.Steps != 3 {
+		t.Fatalf("down 3 : %+v, %v", cmd, err)
+	}
+	cmd, err = ParseCommand([]string{"up", "--to", "forums"})
+	if err != nil || cmd.To != "forums" {
+		t.Fatalf("up --to forums : %+v, %v", cmd, err)
+	}
+	for _, args := range [][]string{nil, {"redo"}, {"down", "0"}, {"down", "x"}, {"status", "en-trop"}, {"up", "--force"}} {
+		if _, err := ParseCommand(args); !errors.Is(err, ErrUsage) {
+			t.Errorf("%q : erreur %v, attendu ErrUsage", args, err)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+// Package migrate déploie et annule le schéma embarqué (package migrations) sans dépendre de
+// l'outil sqitch : le plan fixe l'ordre, chaque migration est déployée puis vérifiée dans une
+// transaction, et le registre schema_migrations garde la trace de ce qui est en base.
+//
+// Une base déjà déployée avec sqitch est reprise telle quelle : tant que schema_migrations
+// n'existe pas, le registre sqitch (sqitch.changes) fait foi, et le premier Up l'importe.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Un seul migrateur à la fois, quel que soit le nombre d'instances qui démarrent.
+const lockKey = "online-learning-platform:migrate"
+
+const createRegistry = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    change      TEXT PRIMARY KEY,
+    script_hash TEXT NOT NULL,
+    note        TEXT NOT NULL DEFAULT '',
+    deployed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deployed_by TEXT NOT NULL DEFAULT CURRENT_USER
+)`
+
+// Migrator applique le plan sur une base.
+type Migrator struct {
+	db   *sql.DB
+	plan Plan
+}
+
+// New charge le plan et les scripts de fsys (migrations.FS en production).
+func New(dbConn *sql.DB, fsys fs.FS) (*Migrator, error) {
+	plan, err := LoadPlan(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("plan de migration: %w", err)
+	}
+	return &Migrator{db: dbConn, plan: plan}, nil
+}
+
+// Plan renvoie le plan embarqué.
+func (m *Migrator) Plan() Plan {
+	return m.plan
+}
+
+// Deployed décrit une migration enregistrée en base.
+type Deployed struct {
+	Change     string
+	ScriptHash string
+	DeployedAt time.Time
+}
+
+// ChangeStatus : état d'une migration du plan, ou d'une migration inconnue du binaire (Unknown).
+type ChangeStatus struct {
+	Change
+	Deployed   bool
+	DeployedAt time.Time
+	// Modified : le script deploy a changé depuis son déploiement.
+	Modified bool
+	// Unknown : présente en base mais absente du plan (base plus récente que le binaire).
+	Unknown bool
+}
+
+// Status compare le plan au registre, dans l'ordre du plan puis les migrations inconnues.
+func (m *Migrator) Status(ctx context.Context) ([]ChangeStatus, error) {
+	deployed, err := m.deployed(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]Deployed{}
+	for _, d := range deployed {
+		byName[d.Change] = d
+	}
+	statuses := make([]ChangeStatus, 0, len(m.plan.Changes))
+	for _, c := range m.plan.Changes {
+		s := ChangeStatus{Change: c}
+		if d, ok := byName[c.Name]; ok {
+			s.Deployed = true
+			s.DeployedAt = d.DeployedAt
+			s.Modified = d.ScriptHash != "" && d.ScriptHash != c.Hash
+			delete(byName, c.Name)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, d := range deployed {
+		if _, ok := byName[d.Change]; ok {
+			statuses = append(statuses, ChangeStatus{Change: Change{Name: d.Change}, Deployed: true, DeployedAt: d.DeployedAt, Unknown: true})
+		}
+	}
+	return statuses, nil
+}
+
+// OutdatedError : des migrations du plan ne sont pas déployées.
+type OutdatedError struct {
+	Pending []string
+}
+
+func (e *OutdatedError) Error() string {
+	return fmt.Sprintf("schéma en retard : %d migration(s) en attente (%s)", len(e.Pending), strings.Join(e.Pending, ", "))
+}
+
+// Check renvoie une *OutdatedError si une migration du plan manque en base. Une base en avance
+// sur le binaire (migrations inconnues) est acceptée : c'est le cas pendant un déploiement progressif.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, s := range statuses {
+		if !s.Deployed {
+			pending = append(pending, s.Name)
+		}
+	}
+	if len(pending) > 0 {
+		return &OutdatedError{Pending: pending}
+	}
+	return nil
+}
+
+// Up déploie les migrations en attente jusqu'à target inclus ("" : tout le plan) et renvoie
+// celles qui ont été appliquées. Chaque migration est vérifiée avant d'être enregistrée.
+func (m *Migrator) Up(ctx context.Context, target string) ([]string, error) {
+	last := len(m.plan.Changes) - 1
+	if target != "" {
+		if last = m.plan.index(target); last < 0 {
+			return nil, fmt.Errorf("migration %q absente du plan", target)
+		}
+	}
+	var applied []string
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		if err := m.ensureRegistry(ctx, conn); err != nil {
+			return err
+		}
+		deployed, err := m.deployedSet(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, c := range m.plan.Changes[:last+1] {
+			if deployed[c.Name] {
+				continue
+			}
+			start := time.Now()
+			if err := m.deploy(ctx, conn, c); err != nil {
+				return fmt.Errorf("déploiement de %q: %w", c.Name, err)
+			}
+			slog.InfoContext(ctx, "migration déployée", "change", c.Name, "duration", time.Since(start))
+			applied = append(applied, c.Name)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down annule les migrations déployées, de la plus récente à la plus ancienne : les steps
+// dernières, ou toutes celles placées après target si target est renseigné.
+func (m *Migrator) Down(ctx context.Context, steps int, target string) ([]string, error) {
+	keep := -1
+	if target != "" {
+		if keep = m.plan.index(target); keep < 0 {
+			return nil, fmt.Errorf("migration %q absente du plan", target)
+		}
+	}
+	var reverted []string
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		if err := m.ensureRegistry(ctx, conn); err != nil {
+			return err
+		}
+		deployed, err := m.deployedSet(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for name := range deployed {
+			if m.plan.index(name) < 0 {
+				return fmt.Errorf("la base contient la migration %q, inconnue de ce binaire : annulez-la avec le binaire qui l'a déployée", name)
+			}
+		}
+		for i := len(m.plan.Changes) - 1; i > keep; i-- {
+			c := m.plan.Changes[i]
+			if !deployed[c.Name] {
+				continue
+			}
+			if target == "" && len(reverted) >= steps {
+				break
+			}
+			if err := m.revert(ctx, conn, c); err != nil {
+				return fmt.Errorf("annulation de %q: %w", c.Name, err)
+			}
+			slog.InfoContext(ctx, "migration annulée", "change", c.Name)
+			reverted = append(reverted, c.Name)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Verify rejoue le script verify de chaque migration déployée, sans rien modifier.
+func (m *Migrator) Verify(ctx context.Context) error {
+	deployed, err := m.deployed(ctx, m.db)
+	if err != nil {
+		return err
+	}
+	known := map[string]bool{}
+	for _, d := range deployed {
+		known[d.Change] = true
+	}
+	var failed []string
+	for _, c := range m.plan.Changes {
+		if !known[c.Name] {
+			continue
+		}
+		if err := m.verify(ctx, c); err != nil {
+			slog.ErrorContext(ctx, "vérification en échec", "change", c.Name, "error", err)
+			failed = append(failed, c.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("vérification en échec pour %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (m *Migrator) deploy(ctx context.Context, conn *sql.Conn, c Change) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, transactional(c.Deploy)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, transactional(c.Verify)); err != nil {
+		return fmt.Errorf("vérification: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (change, script_hash, note) VALUES ($1, $2, $3)`,
+		c.Name, c.Hash, c.Note,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, c Change) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, transactional(c.Revert)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE change = $1`, c.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) verify(ctx context.Context, c Change) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, transactional(c.Verify))
+	return err
+}
+
+// locked exécute fn sur une connexion dédiée, sous verrou consultatif.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, lockKey); err != nil {
+		return fmt.Errorf("verrou de migration: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, lockKey)
+	return fn(conn)
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ensureRegistry crée schema_migrations et, à la première exécution sur une base déployée par
+// sqitch, y recopie le registre sqitch.
+func (m *Migrator) ensureRegistry(ctx context.Context, conn *sql.Conn) error {
+	exists, err := tableExists(ctx, conn, "public.schema_migrations")
+	if err != nil || exists {
+		return err
+	}
+	imported, err := m.sqitchDeployed(ctx, conn)
+	if err != nil {
+		return err
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, createRegistry); err != nil {
+		return err
+	}
+	for _, d := range imported {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (change, script_hash, deployed_at, deployed_by) VALUES ($1, $2, $3, 'sqitch')`,
+			d.Change, d.ScriptHash, d.DeployedAt,
+		); err != nil {
+			return err
+		}
+	}
+	if len(imported) > 0 {
+		slog.InfoContext(ctx, "registre sqitch importé", "changes", len(imported))
+	}
+	return tx.Commit()
+}
+
+// deployed lit schema_migrations, à défaut le registre sqitch, à défaut rien (base vierge).
+func (m *Migrator) deployed(ctx context.Context, q queryer) ([]Deployed, error) {
+	exists, err := tableExists(ctx, q, "public.schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return m.sqitchDeployed(ctx, q)
+	}
+	return scanDeployed(q.QueryContext(ctx, `SELECT change, script_hash, deployed_at FROM schema_migrations ORDER BY deployed_at, change`))
+}
+
+func (m *Migrator) deployedSet(ctx context.Context, q queryer) (map[string]bool, error) {
+	deployed, err := m.deployed(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(deployed))
+	for _, d := range deployed {
+		set[d.Change] = true
+	}
+	return set, nil
+}
+
+func (m *Migrator) sqitchDeployed(ctx context.Context, q queryer) ([]Deployed, error) {
+	exists, err := tableExists(ctx, q, "sqitch.changes")
+	if err != nil || !exists {
+		return nil, err
+	}
+	return scanDeployed(q.QueryContext(ctx,
+		`SELECT change, script_hash, committed_at FROM sqitch.changes WHERE project = $1 ORDER BY committed_at`,
+		m.plan.Project,
+	))
+}
+
+func scanDeployed(rows *sql.Rows, err error) ([]Deployed, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Deployed
+	for rows.Next() {
+		var d Deployed
+		var hash sql.NullString
+		if err := rows.Scan(&d.Change, &hash, &d.DeployedAt); err != nil {
+			return nil, err
+		}
+		d.ScriptHash = hash.String
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+func tableExists(ctx context.Context, q queryer, name string) (bool, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return exists, err
+}
@@ -0,0 +1,141 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Change est une migration du plan avec ses trois scripts.
+type Change struct {
+	Name     string
+	Requires []string
+	Note     string
+	Deploy   string
+	Revert   string
+	Verify   string
+	// Hash : SHA-1 du script deploy, comme le script_hash de sqitch.
+	Hash string
+}
+
+// Plan est la liste ordonnée des migrations connues du binaire.
+type Plan struct {
+	Project string
+	Changes []Change
+}
+
+// LoadPlan lit sqitch.plan et les scripts de chaque migration dans fsys. Une migration sans l'un
+// de ses trois scripts, ou qui dépend d'une migration placée après elle, rend le plan invalide.
+func LoadPlan(fsys fs.FS) (Plan, error) {
+	raw, err := fs.ReadFile(fsys, "sqitch.plan")
+	if err != nil {
+		return Plan{}, err
+	}
+	plan, err := parsePlan(raw)
+	if err != nil {
+		return plan, err
+	}
+	seen := map[string]bool{}
+	for i := range plan.Changes {
+		c := &plan.Changes[i]
+		if seen[c.Name] {
+			return plan, fmt.Errorf("migration %q déclarée deux fois", c.Name)
+		}
+		for _, dep := range c.Requires {
+			if !seen[dep] {
+				return plan, fmt.Errorf("migration %q: dépendance %q absente ou placée après", c.Name, dep)
+			}
+		}
+		seen[c.Name] = true
+		for _, script := range []struct {
+			dir string
+			dst *string
+		}{{"deploy", &c.Deploy}, {"revert", &c.Revert}, {"verify", &c.Verify}} {
+			body, err := fs.ReadFile(fsys, script.dir+"/"+c.Name+".sql")
+			if err != nil {
+				return plan, fmt.Errorf("migration %q: %w", c.Name, err)
+			}
+			*script.dst = string(body)
+		}
+		sum := sha1.Sum([]byte(c.Deploy))
+		c.Hash = hex.EncodeToString(sum[:])
+	}
+	return plan, nil
+}
+
+// parsePlan lit la syntaxe sqitch : « nom [dépendances] date auteur <email> # note ».
+// Les tags (@v1) et les conflits (!nom) sont ignorés.
+func parsePlan(raw []byte) (Plan, error) {
+	var plan Plan
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "%project="):
+			plan.Project = strings.TrimPrefix(line, "%project=")
+			continue
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, "%"), strings.HasPrefix(line, "@"):
+			continue
+		}
+		var c Change
+		if i := strings.Index(line, " # "); i >= 0 {
+			c.Note = strings.TrimSpace(line[i+3:])
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		c.Name = fields[0]
+		if len(fields) > 1 && strings.HasPrefix(fields[1], "[") {
+			rest := strings.Join(fields[1:], " ")
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return plan, fmt.Errorf("plan: dépendances mal fermées pour %q", c.Name)
+			}
+			for _, dep := range strings.Fields(rest[1:end]) {
+				if !strings.HasPrefix(dep, "!") {
+					c.Requires = append(c.Requires, dep)
+				}
+			}
+		}
+		plan.Changes = append(plan.Changes, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return plan, err
+	}
+	if plan.Project == "" || len(plan.Changes) == 0 {
+		return plan, fmt.Errorf("plan incomplet")
+	}
+	return plan, nil
+}
+
+// Last renvoie la dernière migration du plan.
+func (p Plan) Last() Change {
+	return p.Changes[len(p.Changes)-1]
+}
+
+func (p Plan) index(name string) int {
+	for i, c := range p.Changes {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// transactional retire les BEGIN/COMMIT/ROLLBACK des scripts sqitch : le migrateur exécute
+// deploy, verify et l'écriture du registre dans sa propre transaction.
+func transactional(script string) string {
+	lines := strings.Split(script, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		switch strings.ToUpper(strings.TrimSpace(line)) {
+		case "BEGIN;", "COMMIT;", "ROLLBACK;":
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
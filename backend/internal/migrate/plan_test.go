@@ -0,0 +1,72 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"online-learning-platform-backend/migrations"
+)
+
+// Le plan embarqué doit rester chargeable : chaque migration a ses trois scripts et ses
+// dépendances la précèdent.
+func TestEmbeddedPlan(t *testing.T) {
+	plan, err := LoadPlan(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Project != "online-learning-platform" {
+		t.Fatalf("projet %q", plan.Project)
+	}
+	for _, c := range plan.Changes {
+		for name, script := range map[string]string{"deploy": c.Deploy, "revert": c.Revert, "verify": c.Verify} {
+			if strings.Contains(script, "XXX") {
+				t.Errorf("%s/%s.sql est encore un squelette", name, c.Name)
+			}
+		}
+	}
+}
+
+func TestLoadPlanRejectsForwardDependency(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sqitch.plan": {Data: []byte("%project=p\n\na [b] 2026-01-01T00:00:00Z X <x@example.com> # a\nb 2026-01-01T00:00:00Z X <x@example.com> # b\n")},
+	}
+	for _, name := range []string{"a", "b"} {
+		for _, dir := range []string{"deploy", "revert", "verify"} {
+			fsys[dir+"/"+name+".sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+		}
+	}
+	if _, err := LoadPlan(fsys); err == nil {
+		t.Fatal("une dépendance placée après la migration doit être refusée")
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	plan, err := parsePlan([]byte(`%syntax-version=1.0.0
+%project=demo
+
+users 2025-05-23T18:23:26Z A <a@example.com> # Table users
+@v1.0 2025-05-24T00:00:00Z A <a@example.com> # tag
+courses [users !legacy] 2025-05-23T20:13:46Z A <a@example.com> # Cours # avec dièse
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("%d migrations, attendu 2", len(plan.Changes))
+	}
+	courses := plan.Changes[1]
+	if courses.Name != "courses" || len(courses.Requires) != 1 || courses.Requires[0] != "users" {
+		t.Fatalf("migration mal lue: %+v", courses)
+	}
+	if courses.Note != "Cours # avec dièse" {
+		t.Fatalf("note %q", courses.Note)
+	}
+}
+
+func TestTransactional(t *testing.T) {
+	got := transactional("-- Deploy x\n\nBEGIN;\n\nCREATE TABLE t (id INT);\n\nCOMMIT;\n")
+	if strings.Contains(got, "BEGIN") || strings.Contains(got, "COMMIT") || !strings.Contains(got, "CREATE TABLE t") {
+		t.Fatalf("script mal nettoyé: %q", got)
+	}
+}
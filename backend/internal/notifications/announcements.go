@@ -0,0 +1,67 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/jobs"
+)
+
+// QueueAnnouncement enfile la notification des destinataires d'une annonce qui vient de paraître ;
+// à appeler dans la transaction qui la publie. Un cours peut compter des milliers d'inscrits :
+// leurs notifications sont créées par le worker (FanOutAnnouncement), pas par la requête.
+func QueueAnnouncement(ctx context.Context, queries db.Querier, a db.Announcement) error {
+	_, err := jobs.Enqueue(ctx, queries, JobFanOutAnnouncement, map[string]int32{"announcement_id": a.ID},
+		jobs.Options{UniqueKey: fmt.Sprintf("%s:%d", JobFanOutAnnouncement, a.ID)})
+	return err
+}
+
+// courseUpdate : charge utile de JobFanOutCourseUpdate.
+type courseUpdate struct {
+	CourseID int32  `json:"course_id"`
+	Revision int32  `json:"revision"`
+	Title    string `json:"title"`
+}
+
+// QueueCourseUpdate enfile la notification des étudiants d'un cours republié, voir QueueAnnouncement.
+func QueueCourseUpdate(ctx context.Context, queries db.Querier, courseID, revision int32, title string) error {
+	_, err := jobs.Enqueue(ctx, queries, JobFanOutCourseUpdate, courseUpdate{CourseID: courseID, Revision: revision, Title: title},
+		jobs.Options{UniqueKey: fmt.Sprintf("%s:%d:%d", JobFanOutCourseUpdate, courseID, revision)})
+	return err
+}
+
+// FanOutAnnouncement prévient les destinataires d'une annonce qui vient de paraître : tout le
+// cours, ou seulement la cohorte visée. L'e-mail n'est proposé que si l'auteur l'a demandé.
+// Appelée par le worker, voir QueueAnnouncement.
+func FanOutAnnouncement(ctx context.Context, queries db.Querier, a db.Announcement) error {
+	recipients, err := queries.ListAnnouncementRecipients(ctx, db.ListAnnouncementRecipientsParams{
+		CourseID: a.CourseID,
+		CohortID: a.CohortID.Int32,
+	})
+	if err != nil {
+		return err
+	}
+	payload := map[string]any{"announcement_id": a.ID, "course_id": a.CourseID, "title": a.Title}
+	if err := events.Publish(ctx, queries, recipients, events.AnnouncementPublished, payload); err != nil {
+		return err
+	}
+	return Notify(ctx, queries, recipients, Notification{
+		Type:      CourseAnnouncement,
+		Title:     a.Title,
+		Body:      Excerpt(a.Body, 200),
+		Link:      fmt.Sprintf("/courses/%d/announcements", a.CourseID),
+		Data:      payload,
+		InAppOnly: !a.SendEmail,
+	})
+}
+
+// Excerpt coupe un texte à max caractères pour les aperçus (fil, e-mails).
+func Excerpt(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "…"
+}
@@ -0,0 +1,330 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/repository"
+)
+
+const (
+	dispatchBatch = 100
+	maxAttempts   = 5
+	reminderDays  = 3
+
+	// Types de tâches exécutées par le worker (internal/jobs).
+	JobSendPending          = "notifications.send_pending"
+	JobSendDigests          = "notifications.send_digests"
+	JobDeadlineReminders    = "notifications.deadline_reminders"
+	JobPublishAnnouncements = "announcements.publish_due"
+	JobFanOutAnnouncement   = "notifications.fan_out_announcement"
+	JobFanOutCourseUpdate   = "notifications.fan_out_course_update"
+)
+
+// Dispatcher publie les annonces programmées arrivées à échéance, envoie les e-mails et webhooks
+// en attente, et chaque jour à DigestHour (UTC) les digests et les rappels d'échéance. Le travail
+// passe par la file de tâches : plusieurs instances peuvent tourner, les envois sont réservés avec
+// SKIP LOCKED et les rappels dédoublonnés.
+type Dispatcher struct {
+	queries    repository.Store
+	mailer     Mailer
+	client     *http.Client
+	baseURL    string
+	DigestHour int
+}
+
+func NewDispatcher(queries repository.Store, mailer Mailer) *Dispatcher {
+	baseURL := os.Getenv("FRONTEND_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:5173"
+	}
+	return &Dispatcher{
+		queries:    queries,
+		mailer:     mailer,
+		client:     webhookClient(),
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		DigestHour: 7,
+	}
+}
+
+// Register déclare les traitements et planifications du dispatcher auprès du worker.
+func (d *Dispatcher) Register(w *jobs.Worker) {
+	w.Handle(JobSendPending, func(ctx context.Context, job db.Job) error { return d.sendPending(ctx) })
+	w.Handle(JobSendDigests, func(ctx context.Context, job db.Job) error { return d.sendDigests(ctx) })
+	w.Handle(JobDeadlineReminders, func(ctx context.Context, job db.Job) error { return d.queueDeadlineReminders(ctx) })
+	w.Handle(JobPublishAnnouncements, func(ctx context.Context, job db.Job) error { return d.publishDueAnnouncements(ctx) })
+	w.Handle(JobFanOutAnnouncement, func(ctx context.Context, job db.Job) error {
+		var payload struct {
+			AnnouncementID int32 `json:"announcement_id"`
+		}
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return d.fanOutAnnouncement(ctx, payload.AnnouncementID)
+	})
+	w.Handle(JobFanOutCourseUpdate, func(ctx context.Context, job db.Job) error {
+		var payload courseUpdate
+		if err := jobs.Decode(job, &payload); err != nil {
+			return err
+		}
+		return d.fanOutCourseUpdate(ctx, payload)
+	})
+
+	// Filet de sécurité : Notify enfile déjà un envoi immédiat, ceci rattrape les nouvelles tentatives.
+	w.Schedule(JobSendPending, "@every 1m", JobSendPending, nil)
+	w.Schedule(JobPublishAnnouncements, "@every 30s", JobPublishAnnouncements, nil)
+	// DigestHour est en UTC quel que soit le fuseau du serveur.
+	daily := fmt.Sprintf("CRON_TZ=UTC 0 %d * * *", d.DigestHour)
+	w.Schedule(JobDeadlineReminders, daily, JobDeadlineReminders, nil)
+	w.Schedule(JobSendDigests, daily, JobSendDigests, nil)
+}
+
+func (d *Dispatcher) link(path string) string {
+	if path == "" || strings.HasPrefix(path, "http") {
+		return path
+	}
+	return d.baseURL + path
+}
+
+func (d *Dispatcher) publishDueAnnouncements(ctx context.Context) error {
+	for {
+		published, err := d.publishNextAnnouncement(ctx)
+		if err != nil {
+			return fmt.Errorf("publication d'une annonce programmée: %w", err)
+		}
+		if !published {
+			return nil
+		}
+	}
+}
+
+// publishNextAnnouncement réserve une annonce échue et enfile la notification de ses destinataires
+// dans la même transaction.
+func (d *Dispatcher) publishNextAnnouncement(ctx context.Context) (bool, error) {
+	published := false
+	err := d.queries.InTx(ctx, func(qtx db.Querier) error {
+		announcement, err := qtx.ClaimDueAnnouncement(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := QueueAnnouncement(ctx, qtx, announcement); err != nil {
+			return err
+		}
+		published = true
+		return nil
+	})
+	return published, err
+}
+
+// fanOutAnnouncement prévient les destinataires d'une annonce publiée ; rien si elle a été
+// supprimée entre-temps.
+func (d *Dispatcher) fanOutAnnouncement(ctx context.Context, announcementID int32) error {
+	return d.queries.InTx(ctx, func(qtx db.Querier) error {
+		announcement, err := qtx.GetAnnouncement(ctx, announcementID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !announcement.PublishedAt.Valid {
+			return nil
+		}
+		return FanOutAnnouncement(ctx, qtx, announcement)
+	})
+}
+
+// fanOutCourseUpdate prévient les étudiants inscrits au moment de l'envoi qu'une version a été publiée.
+func (d *Dispatcher) fanOutCourseUpdate(ctx context.Context, update courseUpdate) error {
+	return d.queries.InTx(ctx, func(qtx db.Querier) error {
+		students, err := qtx.ListCourseStudentIDs(ctx, update.CourseID)
+		if err != nil {
+			return err
+		}
+		return Notify(ctx, qtx, students, Notification{
+			Type:  CourseUpdated,
+			Title: fmt.Sprintf("Le cours « %s » a été mis à jour", update.Title),
+			Body:  fmt.Sprintf("La version %d du cours est disponible.", update.Revision),
+			Link:  fmt.Sprintf("/courses/%d", update.CourseID),
+			Data:  map[string]any{"course_id": update.CourseID, "revision": update.Revision},
+		})
+	})
+}
+
+// sendPending envoie les e-mails et webhooks immédiats ; un envoi en échec est retenté par la
+// planification JobSendPending selon son propre backoff.
+func (d *Dispatcher) sendPending(ctx context.Context) error {
+	for {
+		batch, err := d.queries.ClaimNotificationDeliveries(ctx, db.ClaimNotificationDeliveriesParams{Digest: false, Limit: dispatchBatch})
+		if err != nil {
+			return fmt.Errorf("réservation des envois: %w", err)
+		}
+		for _, delivery := range batch {
+			var err error
+			switch delivery.Channel {
+			case ChannelEmail:
+				body := delivery.Body
+				if delivery.Link != "" {
+					body += "\n\n" + d.link(delivery.Link)
+				}
+				err = d.mailer.Send(delivery.Email, delivery.Title, body)
+			case ChannelWebhook:
+				err = d.postWebhook(ctx, delivery)
+			default:
+				err = fmt.Errorf("canal inconnu %q", delivery.Channel)
+			}
+			d.record(ctx, delivery.ID, delivery.Attempts, err)
+		}
+		if len(batch) < dispatchBatch {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) record(ctx context.Context, id, attempts int32, sendErr error) {
+	var err error
+	if sendErr == nil {
+		err = d.queries.MarkNotificationDeliverySent(ctx, id)
+	} else {
+		// Nouvel essai après 1, 2, 4, 8 minutes, puis abandon.
+		err = d.queries.MarkNotificationDeliveryFailed(ctx, db.MarkNotificationDeliveryFailedParams{
+			ID:            id,
+			LastError:     sql.NullString{String: sendErr.Error(), Valid: true},
+			NextAttemptAt: time.Now().Add(time.Minute << attempts),
+			GiveUp:        attempts+1 >= maxAttempts,
+		})
+	}
+	if err != nil {
+		slog.Error("notifications: suivi de l'envoi", "delivery_id", id, "error", err)
+	}
+}
+
+// postWebhook signe le corps avec le secret de l'utilisateur (HMAC-SHA256, en-tête X-OLP-Signature).
+func (d *Dispatcher) postWebhook(ctx context.Context, delivery db.ClaimNotificationDeliveriesRow) error {
+	hook, err := d.queries.GetNotificationWebhook(ctx, delivery.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("aucun webhook configuré")
+	}
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]any{
+		"type":       delivery.Type,
+		"title":      delivery.Title,
+		"body":       delivery.Body,
+		"link":       d.link(delivery.Link),
+		"data":       delivery.Data,
+		"created_at": delivery.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(hook.Secret))
+	mac.Write(payload)
+
+	reqCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, hook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-OLP-Event", delivery.Type)
+	req.Header.Set("X-OLP-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: réponse %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendDigests regroupe en un seul e-mail par utilisateur les notifications différées.
+func (d *Dispatcher) sendDigests(ctx context.Context) error {
+	for {
+		batch, err := d.queries.ClaimNotificationDeliveries(ctx, db.ClaimNotificationDeliveriesParams{Digest: true, Limit: 500})
+		if err != nil {
+			return fmt.Errorf("réservation du digest: %w", err)
+		}
+		// Les lignes sont triées par utilisateur.
+		for start := 0; start < len(batch); {
+			end := start
+			for end < len(batch) && batch[end].UserID == batch[start].UserID {
+				end++
+			}
+			group := batch[start:end]
+			sendErr := d.mailer.Send(group[0].Email, d.digestSubject(len(group)), d.digestBody(group))
+			for _, delivery := range group {
+				d.record(ctx, delivery.ID, delivery.Attempts, sendErr)
+			}
+			start = end
+		}
+		if len(batch) < 500 {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) digestSubject(count int) string {
+	if count == 1 {
+		return "Votre résumé quotidien : 1 nouvelle notification"
+	}
+	return fmt.Sprintf("Votre résumé quotidien : %d nouvelles notifications", count)
+}
+
+func (d *Dispatcher) digestBody(group []db.ClaimNotificationDeliveriesRow) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Bonjour %s,\n\nVoici ce qui s'est passé depuis votre dernier résumé :\n", group[0].Name)
+	for _, delivery := range group {
+		fmt.Fprintf(&b, "\n- %s", delivery.Title)
+		if delivery.Body != "" {
+			fmt.Fprintf(&b, "\n  %s", delivery.Body)
+		}
+		if delivery.Link != "" {
+			fmt.Fprintf(&b, "\n  %s", d.link(delivery.Link))
+		}
+	}
+	b.WriteString("\n\nVous pouvez modifier la fréquence de ces e-mails dans vos préférences de notification.\n")
+	return b.String()
+}
+
+func (d *Dispatcher) queueDeadlineReminders(ctx context.Context) error {
+	rows, err := d.queries.ListDeadlineReminders(ctx, reminderDays)
+	if err != nil {
+		return fmt.Errorf("rappels d'échéance: %w", err)
+	}
+	for _, row := range rows {
+		err := Notify(ctx, d.queries, []int32{row.UserID}, Notification{
+			Type:      DeadlineReminder,
+			Title:     fmt.Sprintf("La cohorte « %s » se termine dans %d jours", row.CohortName, reminderDays),
+			Body:      fmt.Sprintf("Il vous reste jusqu'au %s pour terminer le cours « %s ».", row.EndDate.Format("02/01/2006"), row.CourseTitle),
+			Link:      fmt.Sprintf("/courses/%d", row.CourseID),
+			Data:      map[string]any{"course_id": row.CourseID, "cohort_id": row.CohortID, "end_date": row.EndDate.Format("2006-01-02")},
+			DedupeKey: fmt.Sprintf("deadline:%d:%s", row.CohortID, row.EndDate.Format("2006-01-02")),
+		})
+		if err != nil {
+			slog.Error("notifications: rappel d'échéance", "cohort_id", row.CohortID, "user_id", row.UserID, "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Package notifications enregistre les notifications des utilisateurs et les achemine vers les
+// canaux choisis dans leurs préférences : fil in-app, e-mail (immédiat ou digest quotidien) et webhook.
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/jobs"
+)
+
+const (
+	AssignmentCreated  = "assignment.created"
+	GradePosted        = "grade.posted"
+	ForumReply         = "forum.reply"
+	CourseUpdated      = "course.updated"
+	DeadlineReminder   = "deadline.reminder"
+	CourseAnnouncement = "course.announcement"
+
+	TeacherApplicationSubmitted = "teacher_application.submitted"
+	TeacherApplicationReviewed  = "teacher_application.reviewed"
+
+	CertificateIssued  = "certificate.issued"
+	CertificateRevoked = "certificate.revoked"
+	BadgeAwarded       = "badge.awarded"
+
+	AchievementUnlocked = "achievement.unlocked"
+)
+
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Types liste les événements paramétrables, dans l'ordre d'affichage des préférences.
+var Types = []string{AssignmentCreated, GradePosted, ForumReply, CourseUpdated, DeadlineReminder, CourseAnnouncement,
+	TeacherApplicationSubmitted, TeacherApplicationReviewed, CertificateIssued, CertificateRevoked, BadgeAwarded,
+	AchievementUnlocked}
+
+type Preference struct {
+	EventType string `json:"event_type"`
+	InApp     bool   `json:"in_app"`
+	Email     bool   `json:"email"`
+	Webhook   bool   `json:"webhook"`
+	Digest    bool   `json:"digest"`
+}
+
+func IsKnownType(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultPreference : tout arrive dans le fil, et par e-mail via le digest quotidien pour ne pas
+// inonder les étudiants. Les rappels d'échéance et les annonces envoyées par e-mail à la demande
+// de l'enseignant partent immédiatement.
+func DefaultPreference(eventType string) Preference {
+	return Preference{
+		EventType: eventType,
+		InApp:     true,
+		Email:     true,
+		Digest:    eventType != DeadlineReminder && eventType != CourseAnnouncement,
+	}
+}
+
+func PreferenceFor(ctx context.Context, queries db.Querier, userID int32, eventType string) (Preference, error) {
+	saved, err := queries.GetNotificationPreference(ctx, db.GetNotificationPreferenceParams{UserID: userID, EventType: eventType})
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultPreference(eventType), nil
+	}
+	if err != nil {
+		return Preference{}, err
+	}
+	return Preference{EventType: saved.EventType, InApp: saved.InApp, Email: saved.Email, Webhook: saved.Webhook, Digest: saved.Digest}, nil
+}
+
+// Notification décrit ce qui est envoyé. Avec DedupeKey, un même destinataire ne reçoit la
+// notification qu'une fois, même si elle est produite plusieurs fois (tâches planifiées).
+// InAppOnly limite l'envoi au fil, quelles que soient les préférences.
+type Notification struct {
+	Type      string
+	Title     string
+	Body      string
+	Link      string
+	Data      any
+	DedupeKey string
+	InAppOnly bool
+}
+
+// Notify crée la notification de chaque destinataire et programme ses envois externes. Appelé
+// avec des requêtes liées à une transaction, rien n'est envoyé si celle-ci est annulée.
+func Notify(ctx context.Context, queries db.Querier, userIDs []int32, n Notification) error {
+	data := json.RawMessage("{}")
+	if n.Data != nil {
+		encoded, err := json.Marshal(n.Data)
+		if err != nil {
+			return err
+		}
+		data = encoded
+	}
+	immediate := false
+	for _, userID := range userIDs {
+		pref, err := PreferenceFor(ctx, queries, userID, n.Type)
+		if err != nil {
+			return err
+		}
+		if n.InAppOnly {
+			pref.Email, pref.Webhook = false, false
+		}
+		if pref.Webhook {
+			if _, err := queries.GetNotificationWebhook(ctx, userID); errors.Is(err, sql.ErrNoRows) {
+				pref.Webhook = false
+			} else if err != nil {
+				return err
+			}
+		}
+		if !pref.InApp && !pref.Email && !pref.Webhook {
+			continue
+		}
+		var dedupe sql.NullString
+		if n.DedupeKey != "" {
+			dedupe = sql.NullString{String: n.DedupeKey + ":" + strconv.Itoa(int(userID)), Valid: true}
+		}
+		created, err := queries.CreateNotification(ctx, db.CreateNotificationParams{
+			UserID:    userID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			Link:      n.Link,
+			Data:      data,
+			InApp:     pref.InApp,
+			DedupeKey: dedupe,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if pref.InApp {
+			if err := events.Publish(ctx, queries, []int32{userID}, events.NotificationCreated, created); err != nil {
+				return err
+			}
+		}
+		if pref.Email {
+			if err := queries.CreateNotificationDelivery(ctx, db.CreateNotificationDeliveryParams{
+				NotificationID: created.ID, UserID: userID, Channel: ChannelEmail, Digest: pref.Digest,
+			}); err != nil {
+				return err
+			}
+		}
+		if pref.Webhook {
+			if err := queries.CreateNotificationDelivery(ctx, db.CreateNotificationDeliveryParams{
+				NotificationID: created.ID, UserID: userID, Channel: ChannelWebhook,
+			}); err != nil {
+				return err
+			}
+		}
+		if (pref.Email && !pref.Digest) || pref.Webhook {
+			immediate = true
+		}
+	}
+	if immediate {
+		// Une seule tâche en attente suffit : elle envoie tout ce qui est dû.
+		if _, err := jobs.Enqueue(ctx, queries, JobSendPending, nil, jobs.Options{UniqueKey: JobSendPending}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
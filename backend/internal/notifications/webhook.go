@@ -0,0 +1,81 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrWebhookURL : l'URL d'un webhook doit être en https et ne désigner que des adresses publiques,
+// pour que les envois du serveur ne puissent pas viser son réseau interne (SSRF).
+var ErrWebhookURL = errors.New("webhook: URL refusée, https vers une adresse publique uniquement")
+
+// sharedAddressSpace (100.64.0.0/10, RFC 6598) : adresses du NAT opérateur, internes comme les privées.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicAddress refuse les adresses de bouclage, privées, locales au lien (dont 169.254.169.254,
+// les métadonnées des hébergeurs), non spécifiées et multicast.
+func publicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// ValidateWebhookURL vérifie une URL de webhook à l'enregistrement : https, et un hôte dont toutes
+// les adresses sont publiques. La même règle est réappliquée à chaque connexion (webhookClient),
+// le DNS pouvant changer entre-temps.
+func ValidateWebhookURL(ctx context.Context, raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.User != nil {
+		return nil, ErrWebhookURL
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return nil, ErrWebhookURL
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return nil, ErrWebhookURL
+		}
+	}
+	return parsed, nil
+}
+
+// webhookClient n'ouvre de connexion que vers des adresses publiques : le contrôle a lieu sur
+// l'adresse effectivement composée, après résolution, redirections comprises. Pas de proxy,
+// dont l'adresse interne serait refusée.
+func webhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("webhook: adresse %s refusée", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return ErrWebhookURL
+			}
+			if len(via) >= 3 {
+				return errors.New("webhook: trop de redirections")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,311 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// SetNow remplace l'horloge du store, pour dater les lignes créées dans le passé.
+func (s *Store) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+func (s *Store) GetLesson(ctx context.Context, id int32) (db.Lesson, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, l := range s.lessons {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return db.Lesson{}, sql.ErrNoRows
+}
+
+func (s *Store) CompleteLesson(ctx context.Context, arg db.CompleteLessonParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, lc := range s.lessonCompletions {
+		if lc.UserID == arg.UserID && lc.LessonID == arg.LessonID {
+			return nil
+		}
+	}
+	s.lessonCompletions = append(s.lessonCompletions, db.LessonCompletion{UserID: arg.UserID, LessonID: arg.LessonID, CourseID: arg.CourseID, CompletedAt: s.now()})
+	return nil
+}
+
+// RecordLessonActivity reproduit la limite de la requête : pas plus de secondes que le temps écoulé
+// depuis l'envoi précédent sur la leçon, à ClockSkew près.
+func (s *Store) RecordLessonActivity(ctx context.Context, arg db.RecordLessonActivityParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seconds := arg.Seconds
+	var last time.Time
+	for _, la := range s.lessonActivity {
+		if la.UserID == arg.UserID && la.LessonID == arg.LessonID && la.RecordedAt.After(last) {
+			last = la.RecordedAt
+		}
+	}
+	if !last.IsZero() {
+		elapsed := int32(s.now().Sub(last).Seconds())
+		if elapsed < max(arg.Seconds-arg.ClockSkew, 1) {
+			return 0, nil
+		}
+		seconds = min(seconds, elapsed)
+	}
+	s.lessonActivity = append(s.lessonActivity, db.LessonActivity{
+		ID:         int64(len(s.lessonActivity) + 1),
+		UserID:     arg.UserID,
+		CourseID:   arg.CourseID,
+		LessonID:   arg.LessonID,
+		Seconds:    seconds,
+		RecordedAt: s.now(),
+	})
+	return 1, nil
+}
+
+func (s *Store) GetAnalyticsWatermark(ctx context.Context, name string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.analyticsRollups {
+		if r.Name == name {
+			return r.ProcessedUntil, nil
+		}
+	}
+	return time.Time{}, sql.ErrNoRows
+}
+
+func (s *Store) SetAnalyticsWatermark(ctx context.Context, arg db.SetAnalyticsWatermarkParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.analyticsRollups {
+		if r.Name == arg.Name {
+			s.analyticsRollups[i].ProcessedUntil = arg.ProcessedUntil
+			return nil
+		}
+	}
+	s.analyticsRollups = append(s.analyticsRollups, db.AnalyticsRollup{Name: arg.Name, ProcessedUntil: arg.ProcessedUntil})
+	return nil
+}
+
+// Agrégats : recalculés entièrement à chaque appel, since est ignoré ; le résultat est celui
+// qu'atteignent les requêtes incrémentales.
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+type courseDay struct {
+	courseID int32
+	day      time.Time
+}
+
+func (s *Store) RefreshCourseDailyStats(ctx context.Context, since time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := map[courseDay]*db.CourseDailyStat{}
+	get := func(courseID int32, at time.Time) *db.CourseDailyStat {
+		key := courseDay{courseID, utcDay(at)}
+		if stats[key] == nil {
+			stats[key] = &db.CourseDailyStat{CourseID: courseID, Day: key.day}
+		}
+		return stats[key]
+	}
+	for _, e := range s.enrollments {
+		get(e.CourseID, e.EnrolledAt).Enrollments++
+		if e.CompletedAt.Valid {
+			get(e.CourseID, e.CompletedAt.Time).Completions++
+		}
+	}
+	active := map[courseDay]map[int32]bool{}
+	touch := func(courseID, userID int32, at time.Time) {
+		get(courseID, at)
+		key := courseDay{courseID, utcDay(at)}
+		if active[key] == nil {
+			active[key] = map[int32]bool{}
+		}
+		active[key][userID] = true
+	}
+	for _, lc := range s.lessonCompletions {
+		touch(lc.CourseID, lc.UserID, lc.CompletedAt)
+	}
+	for _, la := range s.lessonActivity {
+		touch(la.CourseID, la.UserID, la.RecordedAt)
+	}
+	s.courseDailyStats = s.courseDailyStats[:0]
+	for key, stat := range stats {
+		stat.ActiveLearners = int32(len(active[key]))
+		s.courseDailyStats = append(s.courseDailyStats, *stat)
+	}
+	return int64(len(stats)), nil
+}
+
+func (s *Store) RefreshLessonStats(ctx context.Context, since time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := map[int32]*db.LessonStat{}
+	learners := map[int32]map[int32]bool{}
+	get := func(lessonID, courseID int32) *db.LessonStat {
+		if stats[lessonID] == nil {
+			stats[lessonID] = &db.LessonStat{LessonID: lessonID, CourseID: courseID, UpdatedAt: s.now()}
+			learners[lessonID] = map[int32]bool{}
+		}
+		return stats[lessonID]
+	}
+	for _, lc := range s.lessonCompletions {
+		if s.enrolled(lc.UserID, lc.CourseID) {
+			get(lc.LessonID, lc.CourseID).Completions++
+		}
+	}
+	for _, la := range s.lessonActivity {
+		get(la.LessonID, la.CourseID).TimeSpentSeconds += int64(la.Seconds)
+		learners[la.LessonID][la.UserID] = true
+	}
+	s.lessonStats = s.lessonStats[:0]
+	for lessonID, stat := range stats {
+		stat.Learners = int32(len(learners[lessonID]))
+		s.lessonStats = append(s.lessonStats, *stat)
+	}
+	return int64(len(stats)), nil
+}
+
+// PruneLearnerStats : sans effet, RefreshLearnerStats repart des inscriptions.
+func (s *Store) PruneLearnerStats(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (s *Store) enrolled(userID, courseID int32) bool {
+	for _, e := range s.enrollments {
+		if e.UserID == userID && e.CourseID == courseID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) RefreshLearnerStats(ctx context.Context, since time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.learnerStats = s.learnerStats[:0]
+	for _, e := range s.enrollments {
+		stat := db.LearnerStat{CourseID: e.CourseID, UserID: e.UserID, EnrolledAt: e.EnrolledAt, CompletedAt: e.CompletedAt, UpdatedAt: s.now()}
+		seen := func(at time.Time) {
+			if !stat.LastActiveAt.Valid || at.After(stat.LastActiveAt.Time) {
+				stat.LastActiveAt = sql.NullTime{Time: at, Valid: true}
+			}
+		}
+		for _, lc := range s.lessonCompletions {
+			if lc.CourseID == e.CourseID && lc.UserID == e.UserID {
+				stat.LessonsCompleted++
+				seen(lc.CompletedAt)
+			}
+		}
+		for _, la := range s.lessonActivity {
+			if la.CourseID == e.CourseID && la.UserID == e.UserID {
+				stat.TimeSpentSeconds += int64(la.Seconds)
+				seen(la.RecordedAt)
+			}
+		}
+		s.learnerStats = append(s.learnerStats, stat)
+	}
+	return int64(len(s.learnerStats)), nil
+}
+
+func (s *Store) ListCourseDailyStats(ctx context.Context, arg db.ListCourseDailyStatsParams) ([]db.CourseDailyStat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.CourseDailyStat
+	for _, stat := range s.courseDailyStats {
+		if stat.CourseID == arg.CourseID && !stat.Day.Before(arg.Since) {
+			items = append(items, stat)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Day.Before(items[j].Day) })
+	return items, nil
+}
+
+func (s *Store) GetCourseAnalyticsSummary(ctx context.Context, arg db.GetCourseAnalyticsSummaryParams) (db.GetCourseAnalyticsSummaryRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var row db.GetCourseAnalyticsSummaryRow
+	for _, stat := range s.learnerStats {
+		if stat.CourseID != arg.CourseID {
+			continue
+		}
+		row.Enrolled++
+		if stat.CompletedAt.Valid {
+			row.Completed++
+		}
+		if stat.LastActiveAt.Valid && !stat.LastActiveAt.Time.Before(arg.WeekStart) {
+			row.ActiveWeek++
+		}
+		if stat.LastActiveAt.Valid && !stat.LastActiveAt.Time.Before(arg.MonthStart) {
+			row.ActiveMonth++
+		}
+		row.LessonsCompleted += int64(stat.LessonsCompleted)
+		row.TimeSpentSeconds += stat.TimeSpentSeconds
+	}
+	return row, nil
+}
+
+func (s *Store) ListLessonStats(ctx context.Context, courseID int32) ([]db.LessonStat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.LessonStat
+	for _, stat := range s.lessonStats {
+		if stat.CourseID == courseID {
+			items = append(items, stat)
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) ListAtRiskLearners(ctx context.Context, arg db.ListAtRiskLearnersParams) ([]db.ListAtRiskLearnersRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lastSeen := func(stat db.LearnerStat) time.Time {
+		if stat.LastActiveAt.Valid {
+			return stat.LastActiveAt.Time
+		}
+		return stat.EnrolledAt
+	}
+	var stats []db.LearnerStat
+	for _, stat := range s.learnerStats {
+		if stat.CourseID == arg.CourseID && !stat.CompletedAt.Valid && lastSeen(stat).Before(arg.InactiveSince) {
+			stats = append(stats, stat)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if !lastSeen(stats[i]).Equal(lastSeen(stats[j])) {
+			return lastSeen(stats[i]).Before(lastSeen(stats[j]))
+		}
+		return stats[i].UserID < stats[j].UserID
+	})
+	var items []db.ListAtRiskLearnersRow
+	for _, stat := range stats {
+		if len(items) == int(arg.Limit) {
+			break
+		}
+		row := db.ListAtRiskLearnersRow{
+			UserID:           stat.UserID,
+			Name:             s.userName(stat.UserID),
+			EnrolledAt:       stat.EnrolledAt,
+			LessonsCompleted: stat.LessonsCompleted,
+			TimeSpentSeconds: stat.TimeSpentSeconds,
+			LastActiveAt:     stat.LastActiveAt,
+		}
+		for _, u := range s.users {
+			if u.ID == stat.UserID {
+				row.Email = u.Email
+			}
+		}
+		items = append(items, row)
+	}
+	return items, nil
+}
@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// Inscriptions : juste assez pour inscrire un étudiant, le retirer, suivre sa progression et clore
+// son inscription.
+
+func (s *Store) EnrollInCohort(ctx context.Context, arg db.EnrollInCohortParams) (db.Enrollment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.enrollments {
+		if e.UserID == arg.UserID && e.CourseID == arg.CourseID {
+			s.enrollments[i].CohortID = arg.CohortID
+			return s.enrollments[i], nil
+		}
+	}
+	e := db.Enrollment{ID: int32(len(s.enrollments) + 1), UserID: arg.UserID, CourseID: arg.CourseID, CohortID: arg.CohortID, EnrolledAt: s.now()}
+	s.enrollments = append(s.enrollments, e)
+	return e, nil
+}
+
+func (s *Store) RemoveFromCohort(ctx context.Context, arg db.RemoveFromCohortParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.enrollments[:0]
+	for _, e := range s.enrollments {
+		if e.CohortID.Valid && e.CohortID == arg.CohortID && e.UserID == arg.UserID {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	removed := int64(len(s.enrollments) - len(kept))
+	s.enrollments = kept
+	return removed, nil
+}
+
+func (s *Store) MarkEnrollmentCompleted(ctx context.Context, id int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.enrollments {
+		if e.ID == id && !e.CompletedAt.Valid {
+			s.enrollments[i].CompletedAt = sql.NullTime{Time: s.now(), Valid: true}
+		}
+	}
+	return nil
+}
+
+// GetEnrollmentProgress ne compte, comme la requête, que les leçons de la révision visible.
+func (s *Store) GetEnrollmentProgress(ctx context.Context, arg db.GetEnrollmentProgressParams) (db.GetEnrollmentProgressRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.enrollments {
+		if e.UserID != arg.UserID || e.CourseID != arg.CourseID {
+			continue
+		}
+		row := db.GetEnrollmentProgressRow{ID: e.ID, CompletedAt: e.CompletedAt, RevisionID: e.PinnedRevisionID}
+		if !row.RevisionID.Valid {
+			for _, c := range s.courses {
+				if c.ID == e.CourseID {
+					row.RevisionID = c.PublishedRevisionID
+				}
+			}
+		}
+		inRevision := map[int32]bool{}
+		for _, r := range s.revisions {
+			if row.RevisionID.Valid && r.ID == row.RevisionID.Int32 {
+				var lessons []struct {
+					ID int32 `json:"id"`
+				}
+				if err := json.Unmarshal(r.Lessons, &lessons); err != nil {
+					return row, err
+				}
+				for _, l := range lessons {
+					inRevision[l.ID] = true
+				}
+				row.TotalLessons = int32(len(lessons))
+			}
+		}
+		for _, lc := range s.lessonCompletions {
+			if lc.UserID == e.UserID && lc.CourseID == e.CourseID && inRevision[lc.LessonID] {
+				row.CompletedLessons++
+			}
+		}
+		return row, nil
+	}
+	return db.GetEnrollmentProgressRow{}, sql.ErrNoRows
+}
+
+func (s *Store) ListCourseCompletions(ctx context.Context, courseID int32) ([]db.ListCourseCompletionsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListCourseCompletionsRow
+	for _, e := range s.enrollments {
+		if e.CourseID == courseID && e.CompletedAt.Valid {
+			items = append(items, db.ListCourseCompletionsRow{UserID: e.UserID, CompletedAt: e.CompletedAt.Time})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CompletedAt.Before(items[j].CompletedAt) })
+	return items, nil
+}
+
+func (s *Store) CreateBadgeClass(ctx context.Context, arg db.CreateBadgeClassParams) (db.BadgeClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	class := db.BadgeClass{
+		ID:             int32(len(s.badgeClasses) + 1),
+		OrganizationID: arg.OrganizationID,
+		CourseID:       arg.CourseID,
+		Name:           arg.Name,
+		Description:    arg.Description,
+		Criteria:       arg.Criteria,
+		ImageUrl:       arg.ImageUrl,
+		Active:         true,
+		CreatedBy:      arg.CreatedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.badgeClasses = append(s.badgeClasses, class)
+	return class, nil
+}
+
+func (s *Store) GetBadgeClass(ctx context.Context, id int32) (db.BadgeClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.badgeClasses {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return db.BadgeClass{}, sql.ErrNoRows
+}
+
+func (s *Store) ListBadgeClasses(ctx context.Context, organizationID int32) ([]db.BadgeClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.BadgeClass
+	for _, b := range s.badgeClasses {
+		if b.OrganizationID == organizationID {
+			items = append(items, b)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func (s *Store) ListActiveCourseBadgeClasses(ctx context.Context, courseID int32) ([]db.BadgeClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.BadgeClass
+	for _, b := range s.badgeClasses {
+		if b.CourseID == courseID && b.Active {
+			items = append(items, b)
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) UpdateBadgeClass(ctx context.Context, arg db.UpdateBadgeClassParams) (db.BadgeClass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, b := range s.badgeClasses {
+		if b.ID == arg.ID {
+			b.Name, b.Description, b.Criteria, b.ImageUrl, b.Active = arg.Name, arg.Description, arg.Criteria, arg.ImageUrl, arg.Active
+			b.UpdatedAt = s.now()
+			s.badgeClasses[i] = b
+			return b, nil
+		}
+	}
+	return db.BadgeClass{}, sql.ErrNoRows
+}
+
+func (s *Store) CreateBadgeCredential(ctx context.Context, arg db.CreateBadgeCredentialParams) (db.BadgeCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.badgeCredentials {
+		if c.BadgeClassID == arg.BadgeClassID && c.UserID == arg.UserID {
+			return db.BadgeCredential{}, sql.ErrNoRows
+		}
+	}
+	cred := db.BadgeCredential{
+		ID:           arg.ID,
+		BadgeClassID: arg.BadgeClassID,
+		UserID:       arg.UserID,
+		Credential:   append(json.RawMessage(nil), arg.Credential...),
+		Jwt:          arg.Jwt,
+		KeyID:        arg.KeyID,
+		IssuedAt:     s.now(),
+	}
+	s.badgeCredentials = append(s.badgeCredentials, cred)
+	return cred, nil
+}
+
+func (s *Store) GetBadgeCredential(ctx context.Context, id string) (db.BadgeCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.badgeCredentials {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return db.BadgeCredential{}, sql.ErrNoRows
+}
+
+func (s *Store) ListUserBadgeCredentials(ctx context.Context, userID int32) ([]db.ListUserBadgeCredentialsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListUserBadgeCredentialsRow
+	for _, c := range s.badgeCredentials {
+		if c.UserID != userID {
+			continue
+		}
+		row := db.ListUserBadgeCredentialsRow{ID: c.ID, BadgeClassID: c.BadgeClassID, Jwt: c.Jwt, IssuedAt: c.IssuedAt, RevokedAt: c.RevokedAt}
+		for _, b := range s.badgeClasses {
+			if b.ID == c.BadgeClassID {
+				row.BadgeName, row.BadgeDescription, row.BadgeImageUrl, row.CourseID = b.Name, b.Description, b.ImageUrl, b.CourseID
+			}
+		}
+		items = append(items, row)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].IssuedAt.After(items[j].IssuedAt) })
+	return items, nil
+}
+
+func (s *Store) RevokeBadgeCredential(ctx context.Context, arg db.RevokeBadgeCredentialParams) (db.BadgeCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.badgeCredentials {
+		if c.ID == arg.ID && !c.RevokedAt.Valid {
+			c.RevokedAt = sql.NullTime{Time: s.now(), Valid: true}
+			c.RevokedBy, c.RevocationReason = arg.RevokedBy, arg.RevocationReason
+			s.badgeCredentials[i] = c
+			return c, nil
+		}
+	}
+	return db.BadgeCredential{}, sql.ErrNoRows
+}
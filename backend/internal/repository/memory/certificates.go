@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+func (s *Store) CreateCertificate(ctx context.Context, arg db.CreateCertificateParams) (db.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.certificates {
+		if c.UserID == arg.UserID && c.CourseID == arg.CourseID {
+			return db.Certificate{}, sql.ErrNoRows
+		}
+	}
+	cert := db.Certificate{ID: arg.ID, UserID: arg.UserID, CourseID: arg.CourseID, CompletedAt: arg.CompletedAt, Status: "pending", CreatedAt: s.now()}
+	found := 0
+	for _, u := range s.users {
+		if u.ID == arg.UserID {
+			cert.RecipientName = u.Name
+			found++
+		}
+	}
+	for _, c := range s.courses {
+		if c.ID == arg.CourseID {
+			cert.CourseTitle = c.Title
+			found++
+		}
+	}
+	if found != 2 {
+		return db.Certificate{}, sql.ErrNoRows
+	}
+	for _, m := range s.staff {
+		if m.CourseID == arg.CourseID && m.Role == "owner" {
+			for _, u := range s.users {
+				if u.ID == m.UserID {
+					cert.InstructorName = u.Name
+				}
+			}
+		}
+	}
+	s.certificates = append(s.certificates, cert)
+	return cert, nil
+}
+
+func (s *Store) GetCertificate(ctx context.Context, id string) (db.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.certificates {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return db.Certificate{}, sql.ErrNoRows
+}
+
+func (s *Store) listCertificates(keep func(db.Certificate) bool) []db.Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.Certificate
+	for _, c := range s.certificates {
+		if keep(c) {
+			items = append(items, c)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CreatedAt.After(items[j].CreatedAt) })
+	return items
+}
+
+func (s *Store) ListUserCertificates(ctx context.Context, userID int32) ([]db.Certificate, error) {
+	return s.listCertificates(func(c db.Certificate) bool { return c.UserID == userID }), nil
+}
+
+func (s *Store) ListCourseCertificates(ctx context.Context, courseID int32) ([]db.Certificate, error) {
+	return s.listCertificates(func(c db.Certificate) bool { return c.CourseID == courseID }), nil
+}
+
+func (s *Store) SaveCertificateFile(ctx context.Context, arg db.SaveCertificateFileParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.certificateFiles == nil {
+		s.certificateFiles = map[string][]byte{}
+	}
+	s.certificateFiles[arg.CertificateID] = append([]byte(nil), arg.Content...)
+	return nil
+}
+
+func (s *Store) GetCertificateFile(ctx context.Context, certificateID string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.certificateFiles[certificateID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return content, nil
+}
+
+func (s *Store) MarkCertificateIssued(ctx context.Context, arg db.MarkCertificateIssuedParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.certificates {
+		if c.ID == arg.ID && c.Status == "pending" {
+			c.Status, c.PdfSha256, c.Manifest, c.KeyID = "issued", arg.PdfSha256, arg.Manifest, arg.KeyID
+			c.IssuedAt = sql.NullTime{Time: s.now(), Valid: true}
+			s.certificates[i] = c
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) RevokeCertificate(ctx context.Context, arg db.RevokeCertificateParams) (db.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.certificates {
+		if c.ID == arg.ID && !c.RevokedAt.Valid {
+			c.RevokedAt = sql.NullTime{Time: s.now(), Valid: true}
+			c.RevokedBy, c.RevocationReason = arg.RevokedBy, arg.RevocationReason
+			s.certificates[i] = c
+			return c, nil
+		}
+	}
+	return db.Certificate{}, sql.ErrNoRows
+}
+
+func (s *Store) GetCertificateTemplate(ctx context.Context, courseID int32) (db.CertificateTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.certificateTemplates {
+		if t.CourseID == courseID {
+			return t, nil
+		}
+	}
+	return db.CertificateTemplate{}, sql.ErrNoRows
+}
+
+func (s *Store) UpsertCertificateTemplate(ctx context.Context, arg db.UpsertCertificateTemplateParams) (db.CertificateTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := db.CertificateTemplate{
+		CourseID:       arg.CourseID,
+		Title:          arg.Title,
+		Body:           arg.Body,
+		SignatoryName:  arg.SignatoryName,
+		SignatoryTitle: arg.SignatoryTitle,
+		AccentColor:    arg.AccentColor,
+		UpdatedBy:      arg.UpdatedBy,
+		UpdatedAt:      s.now(),
+	}
+	for i, existing := range s.certificateTemplates {
+		if existing.CourseID == arg.CourseID {
+			s.certificateTemplates[i] = t
+			return t, nil
+		}
+	}
+	s.certificateTemplates = append(s.certificateTemplates, t)
+	return t, nil
+}
+
+func (s *Store) DeleteCertificateTemplate(ctx context.Context, courseID int32) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, t := range s.certificateTemplates {
+		if t.CourseID == courseID {
+			s.certificateTemplates = append(s.certificateTemplates[:i], s.certificateTemplates[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// File de tâches : les tâches sont enregistrées pour les assertions (Jobs), jamais exécutées.
+
+func (s *Store) EnqueueJob(ctx context.Context, arg db.EnqueueJobParams) (db.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if arg.UniqueKey.Valid {
+		for _, j := range s.jobs {
+			if j.UniqueKey == arg.UniqueKey {
+				return db.Job{}, sql.ErrNoRows
+			}
+		}
+	}
+	now := s.now()
+	job := db.Job{
+		ID:          int64(len(s.jobs) + 1),
+		Kind:        arg.Kind,
+		Payload:     append(json.RawMessage(nil), arg.Payload...),
+		Status:      "queued",
+		Priority:    arg.Priority,
+		MaxAttempts: arg.MaxAttempts,
+		RunAt:       arg.RunAt,
+		UniqueKey:   arg.UniqueKey,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.jobs = append(s.jobs, job)
+	return job, nil
+}
+
+// Jobs renvoie les tâches enfilées de type kind, dans l'ordre.
+func (s *Store) Jobs(kind string) []db.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.Job
+	for _, j := range s.jobs {
+		if j.Kind == kind {
+			items = append(items, j)
+		}
+	}
+	return items
+}
@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+func (s *Store) GetEnrollment(ctx context.Context, arg db.GetEnrollmentParams) (db.Enrollment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.enrollments {
+		if e.UserID == arg.UserID && e.CourseID == arg.CourseID {
+			return e, nil
+		}
+	}
+	return db.Enrollment{}, sql.ErrNoRows
+}
+
+// Gamification : source_key unique comme en base ; les classements reproduisent l'agrégation SQL.
+
+func (s *Store) RecordPointEvent(ctx context.Context, arg db.RecordPointEventParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.pointEvents {
+		if e.SourceKey == arg.SourceKey {
+			return 0, sql.ErrNoRows
+		}
+	}
+	e := db.PointEvent{
+		ID:         int64(len(s.pointEvents) + 1),
+		UserID:     arg.UserID,
+		CourseID:   arg.CourseID,
+		Event:      arg.Event,
+		SourceKey:  arg.SourceKey,
+		OccurredAt: arg.OccurredAt,
+		CreatedAt:  s.now(),
+	}
+	s.pointEvents = append(s.pointEvents, e)
+	return e.ID, nil
+}
+
+func (s *Store) GetPointEvent(ctx context.Context, id int64) (db.PointEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.pointEvents {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return db.PointEvent{}, sql.ErrNoRows
+}
+
+func (s *Store) MarkPointEventProcessed(ctx context.Context, arg db.MarkPointEventProcessedParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.pointEvents {
+		if e.ID == arg.ID && !e.ProcessedAt.Valid {
+			s.pointEvents[i].Points = arg.Points
+			s.pointEvents[i].ProcessedAt = sql.NullTime{Time: s.now(), Valid: true}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) CountUserPointEvents(ctx context.Context, arg db.CountUserPointEventsParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for _, e := range s.pointEvents {
+		if e.UserID == arg.UserID && e.Event == arg.Event && e.ProcessedAt.Valid {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *Store) EnsureGamificationProfile(ctx context.Context, userID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.gamificationProfiles {
+		if p.UserID == userID {
+			return nil
+		}
+	}
+	s.gamificationProfiles = append(s.gamificationProfiles, db.GamificationProfile{UserID: userID, Timezone: "UTC", UpdatedAt: s.now()})
+	return nil
+}
+
+func (s *Store) GetGamificationProfile(ctx context.Context, userID int32) (db.GamificationProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.gamificationProfiles {
+		if p.UserID == userID {
+			return p, nil
+		}
+	}
+	return db.GamificationProfile{}, sql.ErrNoRows
+}
+
+func (s *Store) LockGamificationProfile(ctx context.Context, userID int32) (db.GamificationProfile, error) {
+	return s.GetGamificationProfile(ctx, userID)
+}
+
+func (s *Store) UpdateGamificationProgress(ctx context.Context, arg db.UpdateGamificationProgressParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, p := range s.gamificationProfiles {
+		if p.UserID == arg.UserID {
+			p.Points, p.CurrentStreak, p.LongestStreak, p.LastActiveOn = arg.Points, arg.CurrentStreak, arg.LongestStreak, arg.LastActiveOn
+			p.UpdatedAt = s.now()
+			s.gamificationProfiles[i] = p
+		}
+	}
+	return nil
+}
+
+func (s *Store) UpdateGamificationSettings(ctx context.Context, arg db.UpdateGamificationSettingsParams) (db.GamificationProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, p := range s.gamificationProfiles {
+		if p.UserID == arg.UserID {
+			p.Timezone, p.LeaderboardOptOut, p.UpdatedAt = arg.Timezone, arg.LeaderboardOptOut, s.now()
+			s.gamificationProfiles[i] = p
+			return p, nil
+		}
+	}
+	p := db.GamificationProfile{UserID: arg.UserID, Timezone: arg.Timezone, LeaderboardOptOut: arg.LeaderboardOptOut, UpdatedAt: s.now()}
+	s.gamificationProfiles = append(s.gamificationProfiles, p)
+	return p, nil
+}
+
+func (s *Store) AwardAchievement(ctx context.Context, arg db.AwardAchievementParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.userAchievements {
+		if a.UserID == arg.UserID && a.AchievementKey == arg.AchievementKey {
+			return 0, nil
+		}
+	}
+	s.userAchievements = append(s.userAchievements, db.UserAchievement{UserID: arg.UserID, AchievementKey: arg.AchievementKey, EarnedAt: s.now()})
+	return 1, nil
+}
+
+func (s *Store) ListUserAchievements(ctx context.Context, userID int32) ([]db.UserAchievement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.UserAchievement
+	for _, a := range s.userAchievements {
+		if a.UserID == userID {
+			items = append(items, a)
+		}
+	}
+	return items, nil
+}
+
+// optedOut et userName supposent s.mu tenu.
+func (s *Store) optedOut(userID int32) bool {
+	for _, p := range s.gamificationProfiles {
+		if p.UserID == userID {
+			return p.LeaderboardOptOut
+		}
+	}
+	return false
+}
+
+func (s *Store) userName(userID int32) string {
+	for _, u := range s.users {
+		if u.ID == userID {
+			return u.Name
+		}
+	}
+	return ""
+}
+
+type leaderboardRow struct {
+	userID int32
+	points int64
+}
+
+// leaderboard somme les points des événements retenus par keep, hors utilisateurs retirés des classements.
+func (s *Store) leaderboard(since time.Time, limit int32, keep func(db.PointEvent) bool) []leaderboardRow {
+	totals := map[int32]int64{}
+	for _, e := range s.pointEvents {
+		if !e.OccurredAt.Before(since) && keep(e) && !s.optedOut(e.UserID) {
+			totals[e.UserID] += int64(e.Points)
+		}
+	}
+	rows := make([]leaderboardRow, 0, len(totals))
+	for userID, points := range totals {
+		rows = append(rows, leaderboardRow{userID, points})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].points != rows[j].points {
+			return rows[i].points > rows[j].points
+		}
+		return rows[i].userID < rows[j].userID
+	})
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func (s *Store) CourseLeaderboard(ctx context.Context, arg db.CourseLeaderboardParams) ([]db.CourseLeaderboardRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.CourseLeaderboardRow
+	for _, row := range s.leaderboard(arg.Since, arg.Limit, func(e db.PointEvent) bool {
+		return e.CourseID.Valid && e.CourseID.Int32 == arg.CourseID
+	}) {
+		items = append(items, db.CourseLeaderboardRow{UserID: row.userID, Name: s.userName(row.userID), Points: row.points})
+	}
+	return items, nil
+}
+
+func (s *Store) OrganizationLeaderboard(ctx context.Context, arg db.OrganizationLeaderboardParams) ([]db.OrganizationLeaderboardRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orgCourses := map[int32]bool{}
+	for _, c := range s.courses {
+		if c.OrganizationID == arg.OrganizationID {
+			orgCourses[c.ID] = true
+		}
+	}
+	var items []db.OrganizationLeaderboardRow
+	for _, row := range s.leaderboard(arg.Since, arg.Limit, func(e db.PointEvent) bool {
+		return e.CourseID.Valid && orgCourses[e.CourseID.Int32]
+	}) {
+		items = append(items, db.OrganizationLeaderboardRow{UserID: row.userID, Name: s.userName(row.userID), Points: row.points})
+	}
+	return items, nil
+}
+
+func (s *Store) ListRecentAchievements(ctx context.Context, arg db.ListRecentAchievementsParams) ([]db.ListRecentAchievementsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListRecentAchievementsRow
+	for i := len(s.userAchievements) - 1; i >= 0 && len(items) < int(arg.Limit); i-- {
+		a := s.userAchievements[i]
+		member := false
+		for _, m := range s.members {
+			member = member || (m.OrganizationID == arg.OrganizationID && m.UserID == a.UserID)
+		}
+		if !member || s.optedOut(a.UserID) {
+			continue
+		}
+		items = append(items, db.ListRecentAchievementsRow{UserID: a.UserID, Name: s.userName(a.UserID), AchievementKey: a.AchievementKey, EarnedAt: a.EarnedAt})
+	}
+	return items, nil
+}
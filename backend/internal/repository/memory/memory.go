@@ -0,0 +1,449 @@
+// Package memory fournit un repository.Store en mémoire pour les tests de handlers.
+//
+// Seules les requêtes utilisées par les parcours testés (comptes, invitations et candidatures,
+// établissements, catalogue, révisions publiées, équipe pédagogique, certificats et badges) sont implémentées ; appeler une autre méthode de db.Querier panique, ce qui signale tout de
+// suite un test qui sort du périmètre du fake.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+// Store reproduit les contraintes de la base utiles aux handlers (unicité de l'email, upsert de
+// l'équipe, propriétaire non supprimable) sans chercher à imiter le reste de Postgres.
+type Store struct {
+	// Querier reste nil : les requêtes non implémentées paniquent.
+	db.Querier
+
+	mu        sync.Mutex
+	now       func() time.Time
+	users     []db.User
+	courses   []db.Course
+	lessons   []db.Lesson
+	revisions []db.CourseRevision
+	staff     []db.CourseStaff
+
+	invitations   []db.UserInvitation
+	applications  []db.TeacherApplication
+	notifications []db.Notification
+
+	organizations []db.Organization
+	members       []db.OrganizationMember
+
+	certificates         []db.Certificate
+	certificateFiles     map[string][]byte
+	certificateTemplates []db.CertificateTemplate
+	jobs                 []db.Job
+
+	enrollments      []db.Enrollment
+	badgeClasses     []db.BadgeClass
+	badgeCredentials []db.BadgeCredential
+
+	gamificationProfiles []db.GamificationProfile
+	pointEvents          []db.PointEvent
+	userAchievements     []db.UserAchievement
+
+	lessonCompletions []db.LessonCompletion
+	lessonActivity    []db.LessonActivity
+	courseDailyStats  []db.CourseDailyStat
+	lessonStats       []db.LessonStat
+	learnerStats      []db.LearnerStat
+	analyticsRollups  []db.AnalyticsRollup
+}
+
+var _ repository.Store = (*Store)(nil)
+
+// New renvoie un Store vide, hormis l'établissement par défaut que crée la migration organizations.
+func New() *Store {
+	now := time.Now()
+	return &Store{
+		now:           time.Now,
+		organizations: []db.Organization{{ID: 1, Slug: "default", Name: "Établissement par défaut", CreatedAt: now, UpdatedAt: now}},
+	}
+}
+
+type snapshot struct {
+	users     []db.User
+	courses   []db.Course
+	lessons   []db.Lesson
+	revisions []db.CourseRevision
+	staff     []db.CourseStaff
+
+	invitations   []db.UserInvitation
+	applications  []db.TeacherApplication
+	notifications []db.Notification
+
+	organizations []db.Organization
+	members       []db.OrganizationMember
+
+	certificates         []db.Certificate
+	certificateFiles     map[string][]byte
+	certificateTemplates []db.CertificateTemplate
+	jobs                 []db.Job
+
+	enrollments      []db.Enrollment
+	badgeClasses     []db.BadgeClass
+	badgeCredentials []db.BadgeCredential
+
+	gamificationProfiles []db.GamificationProfile
+	pointEvents          []db.PointEvent
+	userAchievements     []db.UserAchievement
+
+	lessonCompletions []db.LessonCompletion
+	lessonActivity    []db.LessonActivity
+	courseDailyStats  []db.CourseDailyStat
+	lessonStats       []db.LessonStat
+	learnerStats      []db.LearnerStat
+	analyticsRollups  []db.AnalyticsRollup
+}
+
+func (s *Store) snapshot() snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make(map[string][]byte, len(s.certificateFiles))
+	for id, content := range s.certificateFiles {
+		files[id] = content
+	}
+	return snapshot{
+		users:     append([]db.User(nil), s.users...),
+		courses:   append([]db.Course(nil), s.courses...),
+		lessons:   append([]db.Lesson(nil), s.lessons...),
+		revisions: append([]db.CourseRevision(nil), s.revisions...),
+		staff:     append([]db.CourseStaff(nil), s.staff...),
+
+		invitations:   append([]db.UserInvitation(nil), s.invitations...),
+		applications:  append([]db.TeacherApplication(nil), s.applications...),
+		notifications: append([]db.Notification(nil), s.notifications...),
+
+		organizations: append([]db.Organization(nil), s.organizations...),
+		members:       append([]db.OrganizationMember(nil), s.members...),
+
+		certificates:         append([]db.Certificate(nil), s.certificates...),
+		certificateFiles:     files,
+		certificateTemplates: append([]db.CertificateTemplate(nil), s.certificateTemplates...),
+		jobs:                 append([]db.Job(nil), s.jobs...),
+
+		enrollments:      append([]db.Enrollment(nil), s.enrollments...),
+		badgeClasses:     append([]db.BadgeClass(nil), s.badgeClasses...),
+		badgeCredentials: append([]db.BadgeCredential(nil), s.badgeCredentials...),
+
+		gamificationProfiles: append([]db.GamificationProfile(nil), s.gamificationProfiles...),
+		pointEvents:          append([]db.PointEvent(nil), s.pointEvents...),
+		userAchievements:     append([]db.UserAchievement(nil), s.userAchievements...),
+
+		lessonCompletions: append([]db.LessonCompletion(nil), s.lessonCompletions...),
+		lessonActivity:    append([]db.LessonActivity(nil), s.lessonActivity...),
+		courseDailyStats:  append([]db.CourseDailyStat(nil), s.courseDailyStats...),
+		lessonStats:       append([]db.LessonStat(nil), s.lessonStats...),
+		learnerStats:      append([]db.LearnerStat(nil), s.learnerStats...),
+		analyticsRollups:  append([]db.AnalyticsRollup(nil), s.analyticsRollups...),
+	}
+}
+
+func (s *Store) restore(snap snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users, s.courses, s.lessons, s.revisions, s.staff = snap.users, snap.courses, snap.lessons, snap.revisions, snap.staff
+	s.invitations, s.applications, s.notifications = snap.invitations, snap.applications, snap.notifications
+	s.organizations, s.members = snap.organizations, snap.members
+	s.certificates, s.certificateFiles, s.certificateTemplates, s.jobs = snap.certificates, snap.certificateFiles, snap.certificateTemplates, snap.jobs
+	s.enrollments, s.badgeClasses, s.badgeCredentials = snap.enrollments, snap.badgeClasses, snap.badgeCredentials
+	s.gamificationProfiles, s.pointEvents, s.userAchievements = snap.gamificationProfiles, snap.pointEvents, snap.userAchievements
+	s.lessonCompletions, s.lessonActivity = snap.lessonCompletions, snap.lessonActivity
+	s.courseDailyStats, s.lessonStats, s.learnerStats, s.analyticsRollups = snap.courseDailyStats, snap.lessonStats, snap.learnerStats, snap.analyticsRollups
+}
+
+// InTx restaure l'état d'avant l'appel si fn échoue. Les transactions ne sont pas isolées les
+// unes des autres : les tests de handlers n'en lancent pas en parallèle sur un même Store.
+func (s *Store) InTx(ctx context.Context, fn func(q db.Querier) error) error {
+	snap := s.snapshot()
+	if err := fn(s); err != nil {
+		s.restore(snap)
+		return err
+	}
+	return nil
+}
+
+func (s *Store) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.Email == arg.Email {
+			return db.CreateUserRow{}, repository.UniqueViolation("users_email_key")
+		}
+	}
+	u := db.User{
+		ID:        int32(len(s.users) + 1),
+		Name:      arg.Name,
+		Email:     arg.Email,
+		Password:  arg.Password,
+		Role:      arg.Role,
+		CreatedAt: sql.NullTime{Time: s.now(), Valid: true},
+	}
+	s.users = append(s.users, u)
+	return db.CreateUserRow{ID: u.ID, Name: u.Name, Email: u.Email, Role: u.Role, CreatedAt: u.CreatedAt}, nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return db.User{}, sql.ErrNoRows
+}
+
+func (s *Store) GetUserByID(ctx context.Context, id int32) (db.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return db.User{}, sql.ErrNoRows
+}
+
+func (s *Store) UpdateUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, u := range s.users {
+		if u.Email == arg.Email {
+			s.users[i].Password = arg.Password
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) UpdateUserRole(ctx context.Context, arg db.UpdateUserRoleParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, u := range s.users {
+		if u.Email == arg.Email {
+			s.users[i].Role = arg.Role
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) SetUserRole(ctx context.Context, arg db.SetUserRoleParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, u := range s.users {
+		if u.ID == arg.ID {
+			s.users[i].Role = arg.Role
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) ListAdminIDs(ctx context.Context) ([]int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []int32
+	for _, u := range s.users {
+		if u.Role == "admin" {
+			ids = append(ids, u.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *Store) CreateCourse(ctx context.Context, arg db.CreateCourseParams) (db.Course, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	course := db.Course{
+		ID:             int32(len(s.courses) + 1),
+		Title:          arg.Title,
+		Description:    arg.Description,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		AuthorID:       arg.AuthorID,
+		Version:        1,
+		OrganizationID: arg.OrganizationID,
+	}
+	s.courses = append(s.courses, course)
+	return course, nil
+}
+
+func (s *Store) GetCourse(ctx context.Context, id int32) (db.Course, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.courses {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return db.Course{}, sql.ErrNoRows
+}
+
+func (s *Store) ListCourses(ctx context.Context, organizationID int32) ([]db.Course, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.Course
+	for i := len(s.courses) - 1; i >= 0; i-- {
+		if s.courses[i].OrganizationID == organizationID {
+			items = append(items, s.courses[i])
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) GetCatalogVersion(ctx context.Context, organizationID int32) (db.GetCatalogVersionRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var row db.GetCatalogVersionRow
+	for _, c := range s.courses {
+		if c.OrganizationID != organizationID {
+			continue
+		}
+		row.Total++
+		row.VersionSum += int64(c.Version)
+		if c.ID > row.MaxID {
+			row.MaxID = c.ID
+		}
+		if c.RatingUpdatedAt.Valid && c.RatingUpdatedAt.Time.Unix() > row.RatingsStamp {
+			row.RatingsStamp = c.RatingUpdatedAt.Time.Unix()
+		}
+	}
+	return row, nil
+}
+
+func (s *Store) PublishCourseRevision(ctx context.Context, arg db.PublishCourseRevisionParams) (db.Course, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.courses {
+		if c.ID == arg.ID {
+			c.Title, c.Description, c.PublishedRevisionID = arg.Title, arg.Description, arg.PublishedRevisionID
+			c.Version++
+			c.UpdatedAt = s.now()
+			s.courses[i] = c
+			return c, nil
+		}
+	}
+	return db.Course{}, sql.ErrNoRows
+}
+
+func (s *Store) CreateLesson(ctx context.Context, courseID int32) (db.Lesson, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lesson := db.Lesson{ID: int32(len(s.lessons) + 1), CourseID: courseID, CreatedAt: s.now()}
+	s.lessons = append(s.lessons, lesson)
+	return lesson, nil
+}
+
+// CreateCourseRevision numérote les révisions par cours, comme la sous-requête MAX(number) + 1.
+func (s *Store) CreateCourseRevision(ctx context.Context, arg db.CreateCourseRevisionParams) (db.CourseRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rev := db.CourseRevision{
+		ID:          int32(len(s.revisions) + 1),
+		CourseID:    arg.CourseID,
+		Number:      1,
+		Title:       arg.Title,
+		Description: arg.Description,
+		Lessons:     arg.Lessons,
+		CreatedBy:   arg.CreatedBy,
+		CreatedAt:   s.now(),
+	}
+	for _, r := range s.revisions {
+		if r.CourseID == arg.CourseID && r.Number >= rev.Number {
+			rev.Number = r.Number + 1
+		}
+	}
+	s.revisions = append(s.revisions, rev)
+	return rev, nil
+}
+
+func (s *Store) GetCourseRevision(ctx context.Context, id int32) (db.CourseRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.revisions {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return db.CourseRevision{}, sql.ErrNoRows
+}
+
+func (s *Store) AddCourseStaff(ctx context.Context, arg db.AddCourseStaffParams) (db.CourseStaff, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.staff {
+		if m.CourseID == arg.CourseID && m.UserID == arg.UserID {
+			s.staff[i].Role = arg.Role
+			return s.staff[i], nil
+		}
+	}
+	m := db.CourseStaff{CourseID: arg.CourseID, UserID: arg.UserID, Role: arg.Role, AddedAt: s.now()}
+	s.staff = append(s.staff, m)
+	return m, nil
+}
+
+func (s *Store) GetCourseStaffRole(ctx context.Context, arg db.GetCourseStaffRoleParams) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.staff {
+		if m.CourseID == arg.CourseID && m.UserID == arg.UserID {
+			return m.Role, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (s *Store) ListCourseStaff(ctx context.Context, courseID int32) ([]db.ListCourseStaffRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListCourseStaffRow
+	for _, m := range s.staff {
+		if m.CourseID != courseID {
+			continue
+		}
+		row := db.ListCourseStaffRow{UserID: m.UserID, Role: m.Role, AddedAt: m.AddedAt}
+		for _, u := range s.users {
+			if u.ID == m.UserID {
+				row.Name, row.Email = u.Name, u.Email
+			}
+		}
+		items = append(items, row)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].AddedAt.Before(items[j].AddedAt) })
+	return items, nil
+}
+
+func (s *Store) UpdateCourseStaffRole(ctx context.Context, arg db.UpdateCourseStaffRoleParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.staff {
+		if m.CourseID == arg.CourseID && m.UserID == arg.UserID {
+			s.staff[i].Role = arg.Role
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) RemoveCourseStaff(ctx context.Context, arg db.RemoveCourseStaffParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.staff {
+		if m.CourseID == arg.CourseID && m.UserID == arg.UserID && m.Role != "owner" {
+			s.staff = append(s.staff[:i], s.staff[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
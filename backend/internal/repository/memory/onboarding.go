@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+func (s *Store) CreateUserInvitation(ctx context.Context, arg db.CreateUserInvitationParams) (db.UserInvitation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inv := db.UserInvitation{
+		ID:        int32(len(s.invitations) + 1),
+		Email:     arg.Email,
+		Role:      arg.Role,
+		TokenHash: arg.TokenHash,
+		InvitedBy: arg.InvitedBy,
+		CreatedAt: s.now(),
+		ExpiresAt: arg.ExpiresAt,
+	}
+	s.invitations = append(s.invitations, inv)
+	return inv, nil
+}
+
+func (s *Store) GetUserInvitationByTokenHash(ctx context.Context, tokenHash string) (db.UserInvitation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, inv := range s.invitations {
+		if inv.TokenHash == tokenHash {
+			return inv, nil
+		}
+	}
+	return db.UserInvitation{}, sql.ErrNoRows
+}
+
+func (s *Store) ListPendingUserInvitations(ctx context.Context) ([]db.UserInvitation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.UserInvitation
+	for i := len(s.invitations) - 1; i >= 0; i-- {
+		if inv := s.invitations[i]; !inv.AcceptedAt.Valid && inv.ExpiresAt.After(s.now()) {
+			items = append(items, inv)
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) AcceptUserInvitation(ctx context.Context, arg db.AcceptUserInvitationParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, inv := range s.invitations {
+		if inv.ID == arg.ID && !inv.AcceptedAt.Valid && inv.ExpiresAt.After(s.now()) {
+			s.invitations[i].AcceptedAt = sql.NullTime{Time: s.now(), Valid: true}
+			s.invitations[i].AcceptedBy = arg.AcceptedBy
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) DeleteUserInvitation(ctx context.Context, id int32) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, inv := range s.invitations {
+		if inv.ID == id && !inv.AcceptedAt.Valid {
+			s.invitations = append(s.invitations[:i], s.invitations[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// CreateTeacherApplication reproduit l'index unique partiel : une seule candidature en attente par compte.
+func (s *Store) CreateTeacherApplication(ctx context.Context, arg db.CreateTeacherApplicationParams) (db.TeacherApplication, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, app := range s.applications {
+		if app.UserID == arg.UserID && app.Status == "pending" {
+			return db.TeacherApplication{}, repository.UniqueViolation("idx_teacher_applications_pending")
+		}
+	}
+	app := db.TeacherApplication{
+		ID:         int32(len(s.applications) + 1),
+		UserID:     arg.UserID,
+		Motivation: arg.Motivation,
+		Status:     "pending",
+		CreatedAt:  s.now(),
+	}
+	s.applications = append(s.applications, app)
+	return app, nil
+}
+
+func (s *Store) ListUserTeacherApplications(ctx context.Context, userID int32) ([]db.TeacherApplication, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.TeacherApplication
+	for i := len(s.applications) - 1; i >= 0; i-- {
+		if s.applications[i].UserID == userID {
+			items = append(items, s.applications[i])
+		}
+	}
+	return items, nil
+}
+
+func (s *Store) ListTeacherApplicationsByStatus(ctx context.Context, status string) ([]db.ListTeacherApplicationsByStatusRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListTeacherApplicationsByStatusRow
+	for _, app := range s.applications {
+		if app.Status != status {
+			continue
+		}
+		row := db.ListTeacherApplicationsByStatusRow{
+			ID:         app.ID,
+			UserID:     app.UserID,
+			Motivation: app.Motivation,
+			Status:     app.Status,
+			ReviewedBy: app.ReviewedBy,
+			ReviewNote: app.ReviewNote,
+			CreatedAt:  app.CreatedAt,
+			ReviewedAt: app.ReviewedAt,
+		}
+		for _, u := range s.users {
+			if u.ID == app.UserID {
+				row.Name, row.Email = u.Name, u.Email
+			}
+		}
+		items = append(items, row)
+	}
+	return items, nil
+}
+
+func (s *Store) ReviewTeacherApplication(ctx context.Context, arg db.ReviewTeacherApplicationParams) (db.TeacherApplication, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, app := range s.applications {
+		if app.ID == arg.ID && app.Status == "pending" {
+			app.Status, app.ReviewedBy, app.ReviewNote = arg.Status, arg.ReviewedBy, arg.ReviewNote
+			app.ReviewedAt = sql.NullTime{Time: s.now(), Valid: true}
+			s.applications[i] = app
+			return app, nil
+		}
+	}
+	return db.TeacherApplication{}, sql.ErrNoRows
+}
+
+// Notifications : préférences par défaut, pas de webhook ; les notifications créées sont
+// conservées pour les assertions (Notifications), les événements et envois sont ignorés.
+
+func (s *Store) GetNotificationPreference(ctx context.Context, arg db.GetNotificationPreferenceParams) (db.NotificationPreference, error) {
+	return db.NotificationPreference{}, sql.ErrNoRows
+}
+
+func (s *Store) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := db.Notification{
+		ID:        int32(len(s.notifications) + 1),
+		UserID:    arg.UserID,
+		Type:      arg.Type,
+		Title:     arg.Title,
+		Body:      arg.Body,
+		Link:      arg.Link,
+		Data:      append(json.RawMessage(nil), arg.Data...),
+		InApp:     arg.InApp,
+		DedupeKey: arg.DedupeKey,
+		CreatedAt: s.now(),
+	}
+	s.notifications = append(s.notifications, n)
+	return n, nil
+}
+
+func (s *Store) CreateUserEvent(ctx context.Context, arg db.CreateUserEventParams) error {
+	return nil
+}
+
+func (s *Store) CreateNotificationDelivery(ctx context.Context, arg db.CreateNotificationDeliveryParams) error {
+	return nil
+}
+
+// Notifications renvoie les notifications créées pour userID, dans l'ordre.
+func (s *Store) Notifications(userID int32) []db.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.Notification
+	for _, n := range s.notifications {
+		if n.UserID == userID {
+			items = append(items, n)
+		}
+	}
+	return items
+}
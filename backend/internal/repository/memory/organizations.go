@@ -0,0 +1,183 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
+)
+
+func (s *Store) CreateOrganization(ctx context.Context, arg db.CreateOrganizationParams) (db.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.organizations {
+		if o.Slug == arg.Slug {
+			return db.Organization{}, repository.UniqueViolation("organizations_slug_key")
+		}
+	}
+	now := s.now()
+	org := db.Organization{
+		ID:           int32(len(s.organizations) + 1),
+		Slug:         arg.Slug,
+		Name:         arg.Name,
+		LogoUrl:      arg.LogoUrl,
+		PrimaryColor: arg.PrimaryColor,
+		AccentColor:  arg.AccentColor,
+		SupportEmail: arg.SupportEmail,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	s.organizations = append(s.organizations, org)
+	return org, nil
+}
+
+func (s *Store) GetOrganization(ctx context.Context, id int32) (db.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.organizations {
+		if o.ID == id {
+			return o, nil
+		}
+	}
+	return db.Organization{}, sql.ErrNoRows
+}
+
+func (s *Store) GetOrganizationBySlug(ctx context.Context, slug string) (db.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.organizations {
+		if o.Slug == slug {
+			return o, nil
+		}
+	}
+	return db.Organization{}, sql.ErrNoRows
+}
+
+func (s *Store) ListOrganizations(ctx context.Context) ([]db.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := append([]db.Organization(nil), s.organizations...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func (s *Store) UpdateOrganization(ctx context.Context, arg db.UpdateOrganizationParams) (db.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.organizations {
+		if o.Slug == arg.Slug && o.ID != arg.ID {
+			return db.Organization{}, repository.UniqueViolation("organizations_slug_key")
+		}
+	}
+	for i, o := range s.organizations {
+		if o.ID == arg.ID {
+			o.Slug, o.Name, o.LogoUrl = arg.Slug, arg.Name, arg.LogoUrl
+			o.PrimaryColor, o.AccentColor, o.SupportEmail = arg.PrimaryColor, arg.AccentColor, arg.SupportEmail
+			o.UpdatedAt = s.now()
+			s.organizations[i] = o
+			return o, nil
+		}
+	}
+	return db.Organization{}, sql.ErrNoRows
+}
+
+func (s *Store) AddOrganizationMember(ctx context.Context, arg db.AddOrganizationMemberParams) (db.OrganizationMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.members {
+		if m.OrganizationID == arg.OrganizationID && m.UserID == arg.UserID {
+			s.members[i].Role = arg.Role
+			return s.members[i], nil
+		}
+	}
+	m := db.OrganizationMember{OrganizationID: arg.OrganizationID, UserID: arg.UserID, Role: arg.Role, JoinedAt: s.now()}
+	s.members = append(s.members, m)
+	return m, nil
+}
+
+func (s *Store) EnsureOrganizationMember(ctx context.Context, arg db.EnsureOrganizationMemberParams) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.members {
+		if m.OrganizationID == arg.OrganizationID && m.UserID == arg.UserID {
+			return nil
+		}
+	}
+	s.members = append(s.members, db.OrganizationMember{OrganizationID: arg.OrganizationID, UserID: arg.UserID, Role: "member", JoinedAt: s.now()})
+	return nil
+}
+
+func (s *Store) GetOrganizationMemberRole(ctx context.Context, arg db.GetOrganizationMemberRoleParams) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.members {
+		if m.OrganizationID == arg.OrganizationID && m.UserID == arg.UserID {
+			return m.Role, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (s *Store) ListOrganizationMembers(ctx context.Context, organizationID int32) ([]db.ListOrganizationMembersRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListOrganizationMembersRow
+	for _, m := range s.members {
+		if m.OrganizationID != organizationID {
+			continue
+		}
+		row := db.ListOrganizationMembersRow{UserID: m.UserID, Role: m.Role, JoinedAt: m.JoinedAt}
+		for _, u := range s.users {
+			if u.ID == m.UserID {
+				row.Name, row.Email = u.Name, u.Email
+			}
+		}
+		items = append(items, row)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func (s *Store) UpdateOrganizationMemberRole(ctx context.Context, arg db.UpdateOrganizationMemberRoleParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.members {
+		if m.OrganizationID == arg.OrganizationID && m.UserID == arg.UserID {
+			s.members[i].Role = arg.Role
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) RemoveOrganizationMember(ctx context.Context, arg db.RemoveOrganizationMemberParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.members {
+		if m.OrganizationID == arg.OrganizationID && m.UserID == arg.UserID {
+			s.members = append(s.members[:i], s.members[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Store) ListUserOrganizations(ctx context.Context, userID int32) ([]db.ListUserOrganizationsRow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var items []db.ListUserOrganizationsRow
+	for _, m := range s.members {
+		if m.UserID != userID {
+			continue
+		}
+		for _, o := range s.organizations {
+			if o.ID == m.OrganizationID {
+				items = append(items, db.ListUserOrganizationsRow{ID: o.ID, Slug: o.Slug, Name: o.Name, Role: m.Role})
+			}
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
@@ -0,0 +1,74 @@
+// Package repository est le point d'accès aux données des handlers et des services d'arrière-plan :
+// les requêtes sqlc (db.Querier) et les transactions, derrière une interface que les tests
+// remplacent par l'implémentation en mémoire de repository/memory.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/tracing"
+)
+
+// Store regroupe toutes les requêtes de l'application et l'ouverture de transactions.
+type Store interface {
+	db.Querier
+	// InTx exécute fn dans une transaction : commit si fn renvoie nil, rollback sinon.
+	// L'erreur de fn est renvoyée telle quelle.
+	InTx(ctx context.Context, fn func(q db.Querier) error) error
+}
+
+// uniqueViolation est le code SQLSTATE d'une contrainte d'unicité violée.
+const uniqueViolation = "23505"
+
+// IsUniqueViolation signale un doublon refusé par la base (email déjà pris, etc.).
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// UniqueViolation construit l'erreur que renvoie Postgres pour un doublon ; utilisée par les fakes.
+func UniqueViolation(constraint string) error {
+	return &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           uniqueViolation,
+		Message:        "duplicate key value violates unique constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+type pgStore struct {
+	db.Querier
+	conn interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	}
+}
+
+// New renvoie le Store Postgres : chaque requête, y compris en transaction, ouvre un span.
+// Un contexte rattaché à un établissement (WithOrganization) passe par sa connexion réservée.
+func New(conn *sql.DB) Store {
+	tenant := organizationDBTX{pool: conn}
+	return &pgStore{Querier: db.New(tracing.DBTX(tenant)), conn: tenant}
+}
+
+// NewSystem renvoie le Store du rôle système (DB_SYSTEM_USER), qui échappe aux politiques RLS :
+// worker, diffusion temps réel et documents publics consultés quel que soit l'établissement.
+// L'établissement éventuel du contexte est ignoré.
+func NewSystem(conn *sql.DB) Store {
+	return &pgStore{Querier: db.New(tracing.DBTX(conn)), conn: conn}
+}
+
+func (s *pgStore) InTx(ctx context.Context, fn func(q db.Querier) error) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tracing.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
+)
+
+// Isolation des établissements : les politiques RLS des cours, des cohortes et de leurs tables
+// lisent le paramètre de session app.organization_id (migrations organizations et
+// tenant_isolation) et ne laissent rien voir sans lui. Une requête HTTP rattachée à un
+// établissement travaille sur une seule connexion du pool, réservée à la première requête SQL,
+// où ce paramètre est posé ; elle est remise à zéro puis rendue au pool en fin de requête.
+
+type organizationKey struct{}
+
+type organizationSession struct {
+	orgID int32
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// WithOrganization rattache ctx à un établissement : les requêtes et transactions du Store
+// Postgres faites avec ce contexte ne voient que ses cours et ses cohortes. release rend la
+// connexion au pool et doit être appelée une fois la requête HTTP terminée.
+func WithOrganization(ctx context.Context, orgID int32) (context.Context, func()) {
+	session := &organizationSession{orgID: orgID}
+	return context.WithValue(ctx, organizationKey{}, session), session.release
+}
+
+// WithoutOrganization détache ctx de son établissement. Les flux longs (SSE) l'utilisent pour
+// ne pas garder une connexion réservée pendant toute leur durée ; ils ne lisent que des
+// données propres à l'utilisateur.
+func WithoutOrganization(ctx context.Context) context.Context {
+	return context.WithValue(ctx, organizationKey{}, (*organizationSession)(nil))
+}
+
+func organizationFrom(ctx context.Context) *organizationSession {
+	session, _ := ctx.Value(organizationKey{}).(*organizationSession)
+	return session
+}
+
+// acquire réserve la connexion de la session et y pose app.organization_id.
+func (s *organizationSession) acquire(ctx context.Context, pool *sql.DB) (*sql.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		return s.conn, nil
+	}
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT set_config('app.organization_id', $1, false)", strconv.Itoa(int(s.orgID))); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+func (s *organizationSession) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return
+	}
+	// Contexte neuf : celui de la requête HTTP est souvent déjà annulé à ce stade.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := s.conn.ExecContext(ctx, "SELECT set_config('app.organization_id', '', false)"); err != nil {
+		// Jamais de connexion rendue au pool avec un établissement encore posé : elle est jetée.
+		slog.Warn("repository: remise à zéro de l'établissement", "error", err)
+		s.conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	s.conn.Close()
+	s.conn = nil
+}
+
+// organizationDBTX envoie les requêtes d'un contexte rattaché à un établissement sur la
+// connexion de sa session, les autres sur le pool.
+type organizationDBTX struct {
+	pool *sql.DB
+}
+
+func (t organizationDBTX) conn(ctx context.Context) (db.DBTX, error) {
+	session := organizationFrom(ctx)
+	if session == nil {
+		return t.pool, nil
+	}
+	return session.acquire(ctx, t.pool)
+}
+
+func (t organizationDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	conn, err := t.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.ExecContext(ctx, query, args...)
+}
+
+func (t organizationDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	conn, err := t.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.PrepareContext(ctx, query)
+}
+
+func (t organizationDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	conn, err := t.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.QueryContext(ctx, query, args...)
+}
+
+func (t organizationDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	conn, err := t.conn(ctx)
+	if err != nil {
+		// *sql.Row ne se construit pas avec une erreur : un contexte annulé la fait remonter au Scan.
+		slog.WarnContext(ctx, "repository: connexion de l'établissement", "error", err)
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		return t.pool.QueryRowContext(canceled, query, args...)
+	}
+	return conn.QueryRowContext(ctx, query, args...)
+}
+
+func (t organizationDBTX) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	session := organizationFrom(ctx)
+	if session == nil {
+		return t.pool.BeginTx(ctx, opts)
+	}
+	conn, err := session.acquire(ctx, t.pool)
+	if err != nil {
+		return nil, err
+	}
+	return conn.BeginTx(ctx, opts)
+}
@@ -0,0 +1,146 @@
+// Package server fait tourner l'API derrière un http.Server explicite : délais configurables,
+// TLS optionnel avec rechargement du certificat et arrêt gracieux sur signal.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config se lit dans l'environnement (voir ConfigFromEnv) ; les délais acceptent la syntaxe
+// time.ParseDuration (30s, 2m…).
+type Config struct {
+	Addr              string
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout couvre la lecture du corps : il doit laisser passer les pièces jointes.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout borne l'attente des requêtes en cours après SIGTERM.
+	ShutdownTimeout time.Duration
+	MaxHeaderBytes  int
+	MaxBodyBytes    int64
+	TLSCertFile     string
+	TLSKeyFile      string
+}
+
+// ConfigFromEnv :
+//
+//	HTTP_ADDR (défaut :$PORT ou :8080), HTTP_READ_HEADER_TIMEOUT (10s), HTTP_READ_TIMEOUT (60s),
+//	HTTP_WRITE_TIMEOUT (60s), HTTP_IDLE_TIMEOUT (120s), HTTP_SHUTDOWN_TIMEOUT (30s),
+//	HTTP_MAX_HEADER_BYTES (1 Mio), HTTP_MAX_BODY_BYTES (32 Mio), TLS_CERT_FILE et TLS_KEY_FILE.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Addr:              ":8080",
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       60 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		MaxBodyBytes:      32 << 20,
+		TLSCertFile:       os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:        os.Getenv("TLS_KEY_FILE"),
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		cfg.Addr = ":" + port
+	}
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+	durations := map[string]*time.Duration{
+		"HTTP_READ_HEADER_TIMEOUT": &cfg.ReadHeaderTimeout,
+		"HTTP_READ_TIMEOUT":        &cfg.ReadTimeout,
+		"HTTP_WRITE_TIMEOUT":       &cfg.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":        &cfg.IdleTimeout,
+		"HTTP_SHUTDOWN_TIMEOUT":    &cfg.ShutdownTimeout,
+	}
+	for name, target := range durations {
+		if raw := os.Getenv(name); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil || d < 0 {
+				return cfg, fmt.Errorf("%s invalide : %q", name, raw)
+			}
+			*target = d
+		}
+	}
+	if raw := os.Getenv("HTTP_MAX_HEADER_BYTES"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return cfg, fmt.Errorf("HTTP_MAX_HEADER_BYTES invalide : %q", raw)
+		}
+		cfg.MaxHeaderBytes = n
+	}
+	if raw := os.Getenv("HTTP_MAX_BODY_BYTES"); raw != "" {
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || n <= 0 {
+			return cfg, fmt.Errorf("HTTP_MAX_BODY_BYTES invalide : %q", raw)
+		}
+		cfg.MaxBodyBytes = n
+	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return cfg, errors.New("TLS_CERT_FILE et TLS_KEY_FILE vont ensemble")
+	}
+	return cfg, nil
+}
+
+func New(handler http.Handler, cfg Config) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// Run sert jusqu'à l'annulation de ctx, puis arrête d'accepter des connexions et attend la fin
+// des requêtes en cours (au plus ShutdownTimeout). Les fonctions enregistrées avec
+// srv.RegisterOnShutdown (fermeture des flux SSE…) sont appelées au début de l'arrêt.
+func Run(ctx context.Context, srv *http.Server, cfg Config) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if cfg.TLSCertFile == "" {
+			slog.Info("Serveur HTTP démarré", "addr", srv.Addr)
+			serveErr <- srv.ListenAndServe()
+			return
+		}
+		certs, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			serveErr <- err
+			return
+		}
+		go certs.watch(ctx)
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: certs.GetCertificate}
+		slog.Info("Serveur HTTPS démarré", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServeTLS("", "")
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Arrêt demandé, attente des requêtes en cours", "timeout", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		// Délai dépassé : les connexions restantes sont coupées.
+		srv.Close()
+		return fmt.Errorf("arrêt du serveur HTTP : %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
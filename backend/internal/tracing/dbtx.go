@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"online-learning-platform-backend/internal/db"
+)
+
+// tracedDBTX ouvre un span par requête, nommé d'après le commentaire « -- name: X » de sqlc.
+// Les arguments ne sont pas enregistrés : ils peuvent contenir des données personnelles.
+type tracedDBTX struct {
+	db.DBTX
+}
+
+// DBTX enveloppe une connexion ou une transaction pour tracer les requêtes qui la traversent.
+func DBTX(conn db.DBTX) db.DBTX {
+	return tracedDBTX{conn}
+}
+
+// WithTx remplace queries.WithTx : les requêtes de la transaction sont tracées elles aussi.
+// Les requêtes ne sont pas préparées (db.New), rien d'autre n'est à reprendre de *db.Queries.
+func WithTx(tx *sql.Tx) *db.Queries {
+	return db.New(DBTX(tx))
+}
+
+func (t tracedDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startSpan(ctx, query)
+	result, err := t.DBTX.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return result, err
+}
+
+func (t tracedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startSpan(ctx, query)
+	stmt, err := t.DBTX.PrepareContext(ctx, query)
+	endSpan(span, err)
+	return stmt, err
+}
+
+// QueryContext : le span couvre l'exécution, pas le parcours des lignes par l'appelant.
+func (t tracedDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startSpan(ctx, query)
+	rows, err := t.DBTX.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (t tracedDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startSpan(ctx, query)
+	row := t.DBTX.QueryRowContext(ctx, query, args...)
+	// sql.ErrNoRows n'apparaît qu'au Scan ; seules les vraies erreurs sont visibles ici.
+	endSpan(span, row.Err())
+	return row
+}
+
+func startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := queryName(query)
+	return otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", name),
+			attribute.String("db.query.text", query),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryName lit le nom sqlc (« -- name: GetCourse :one ») ; à défaut, le premier mot SQL.
+func queryName(query string) string {
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
@@ -0,0 +1,99 @@
+// Package tracing configure OpenTelemetry : un span par route gin (otelgin) et par requête sqlc
+// (enveloppe de db.DBTX), contexte propagé depuis l'en-tête traceparent.
+//
+// Configuration par variables d'environnement :
+//
+//	OTEL_TRACES_EXPORTER      otlp, stdout ou none (défaut : none, aucun export)
+//	OTEL_EXPORTER_OTLP_ENDPOINT  collecteur OTLP/HTTP (défaut du SDK : http://localhost:4318)
+//	OTEL_TRACES_SAMPLER_ARG   proportion de traces échantillonnées, entre 0 et 1 (défaut : 1)
+//	OTEL_SERVICE_NAME         nom du service (défaut : online-learning-platform-backend)
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultServiceName  = "online-learning-platform-backend"
+	instrumentationName = "online-learning-platform-backend/internal/tracing"
+)
+
+// Setup installe le TracerProvider et les propagateurs globaux. La fonction renvoyée vide les
+// spans en attente ; à appeler à l'arrêt.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch kind := os.Getenv("OTEL_TRACES_EXPORTER"); kind {
+	case "", "none":
+		// Les spans restent créés (identifiants de trace dans les logs, propagation) mais ne sortent pas.
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER inconnu : %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := 1.0
+	if raw := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); raw != "" {
+		ratio, err = strconv.ParseFloat(raw, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG doit être compris entre 0 et 1 : %q", raw)
+		}
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName())))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		// Un appel entrant échantillonné par l'appelant reste échantillonné, quelle que soit la proportion.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Middleware ouvre un span par requête, nommé d'après la route gin. Les sondes et /metrics,
+// appelées en boucle, ne sont pas tracées.
+func Middleware() gin.HandlerFunc {
+	return otelgin.Middleware(serviceName(), otelgin.WithFilter(func(r *http.Request) bool {
+		switch r.URL.Path {
+		case "/healthz", "/readyz", "/metrics", "/ping":
+			return false
+		}
+		return true
+	}))
+}
+
+func serviceName() string {
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		return name
+	}
+	return defaultServiceName
+}
@@ -1,30 +1,160 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
 	"net/http"
-	_ "github.com/lib/pq"
-	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
-	"online-learning-platform-backend/internal/db"
+	"sync"
+	"syscall"
+	"time"
+	"online-learning-platform-backend/internal/analytics"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/certificates"
+	"online-learning-platform-backend/internal/database"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/jobs"
+	"online-learning-platform-backend/internal/logging"
+	"online-learning-platform-backend/internal/metrics"
+	"online-learning-platform-backend/internal/migrate"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/internal/server"
+	"online-learning-platform-backend/internal/tracing"
+	"online-learning-platform-backend/internal/notifications"
+	"online-learning-platform-backend/middleware"
+	"online-learning-platform-backend/migrations"
 	"online-learning-platform-backend/routes"
 )
 
+func main() {
+	logging.Setup()
 
+	// « online-learning-platform migrate ... » : gestion du schéma, sans démarrer le serveur.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
 
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		slog.Error("Configuration du traçage invalide", "error", err)
+		os.Exit(1)
+	}
 
-func main() {
-	dbConn, err := sql.Open("postgres", "host=db port=5432 user=postgres password=postgres dbname=online_learning sslmode=disable")
+	serverConfig, err := server.ConfigFromEnv()
+	if err != nil {
+		slog.Error("Configuration du serveur HTTP invalide", "error", err)
+		os.Exit(1)
+	}
+
+	dbConfig, err := database.ConfigFromEnv()
+	if err != nil {
+		slog.Error("Configuration de la base de données invalide", "error", err)
+		os.Exit(1)
+	}
+	dbConn, err := database.Open(dbConfig)
 	if err != nil {
-		log.Fatalf("Erreur de connexion à la base de données : %v", err)
+		slog.Error("Erreur de connexion à la base de données", "error", err)
+		os.Exit(1)
 	}
-	defer dbConn.Close()
 
-	queries := db.New(dbConn)
+	// Rôle système (DB_SYSTEM_USER) : migrations, worker et diffusion temps réel, hors RLS.
+	systemConfig, err := database.SystemConfigFromEnv()
+	if err != nil {
+		slog.Error("Configuration de la base de données invalide", "error", err)
+		os.Exit(1)
+	}
+	systemConn, err := database.Open(systemConfig)
+	if err != nil {
+		slog.Error("Erreur de connexion à la base de données", "error", err)
+		os.Exit(1)
+	}
 
-	r := gin.Default()
+	// Handlers et tâches de fond passent par le repository ; chaque requête sqlc y ouvre un span.
+	// Les requêtes HTTP n'y voient que l'établissement résolu, le worker voit tout.
+	store := repository.New(dbConn)
+	systemStore := repository.NewSystem(systemConn)
+	metrics.RegisterDB(dbConn, "online_learning")
+	metrics.RegisterDB(systemConn, "online_learning_system")
+
+	// Refuse de servir tant que le schéma n'est pas à jour ; MIGRATE_ON_START=true le met à jour.
+	migrator, err := migrate.New(systemConn, migrations.FS)
+	if err != nil {
+		slog.Error("Migrations embarquées invalides", "error", err)
+		os.Exit(1)
+	}
+	if err := prepareSchema(systemConn, migrator, os.Getenv("MIGRATE_ON_START") == "true"); err != nil {
+		slog.Error("Schéma de base de données inutilisable", "error", err)
+		os.Exit(1)
+	}
+	// Isolation fermée : l'API ne démarre pas avec un rôle qui échapperait aux politiques RLS.
+	if err := database.CheckRoles(context.Background(), dbConn, systemConn); err != nil {
+		slog.Error("Rôles de base de données inadaptés", "error", err)
+		os.Exit(1)
+	}
+
+	// Clés de signature des badges Open Badges : la courante signe dans le worker, toutes vérifient.
+	badgeKeys, err := badges.LoadKeyring()
+	if err != nil {
+		slog.Error("Clé de signature des badges invalide", "error", err)
+		os.Exit(1)
+	}
+
+	// Barème et succès de la gamification (GAMIFICATION_RULES_FILE, règles embarquées à défaut).
+	gamificationRules, err := gamification.LoadRules()
+	if err != nil {
+		slog.Error("Règles de gamification invalides", "error", err)
+		os.Exit(1)
+	}
+
+	// SIGTERM (déploiement) ou SIGINT : arrêt gracieux, voir la fin de main pour l'ordre.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+
+	// Relaie les NOTIFY Postgres vers les flux SSE ouverts sur cette instance.
+	hub := events.NewHub(systemStore)
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		if err := hub.Run(backgroundCtx, systemConfig.URL); err != nil {
+			slog.Error("Diffusion temps réel indisponible", "error", err)
+		}
+	}()
+
+	// Tâches de fond : annonces programmées, e-mails et webhooks de notification, digests et rappels,
+	// agrégats des statistiques de cours.
+	// JOB_WORKERS=0 désactive le worker sur cette instance (par exemple pour le lancer à part).
+	workers := 4
+	if raw := os.Getenv("JOB_WORKERS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n >= 0 {
+			workers = n
+		}
+	}
+	if workers > 0 {
+		worker := jobs.NewWorker(systemStore, workers)
+		notifications.NewDispatcher(systemStore, notifications.MailerFromEnv()).Register(worker)
+		certificates.NewGenerator(systemStore, badgeKeys.Current).Register(worker)
+		badges.NewAwarder(systemStore, badgeKeys.Current).Register(worker)
+		gamification.NewEngine(systemStore, gamificationRules).Register(worker)
+		analytics.NewRollup(systemStore).Register(worker)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			worker.Run(backgroundCtx)
+		}()
+	}
+
+	// Logger et recovery de gin remplacés par leurs équivalents slog (request_id, champs masqués).
+	r := gin.New()
+	r.Use(middleware.RequestID(), tracing.Middleware(), middleware.AccessLog(), middleware.Recovery(), metrics.Middleware())
+	r.Use(middleware.MaxBodyBytes(serverConfig.MaxBodyBytes))
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173", "http://127.0.0.1:5173", "http://localhost:3000", "http://127.0.0.1:3000", "http://127.0.0.1:58908", "http://localhost:58908"},
 		AllowOriginFunc: func(origin string) bool {
@@ -33,20 +163,67 @@ func main() {
 				strings.HasPrefix(origin, "http://localhost:") || strings.HasPrefix(origin, "http://127.0.0.1:")
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match", "If-None-Match", "Last-Event-ID", "X-Organization", "X-Request-ID", "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "X-Request-ID"},
 		AllowCredentials: true,
 	}))
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "pong"})
 	})
+	routes.RegisterHealthRoutes(r, dbConn, migrator, os.Getenv("METRICS_TOKEN"))
 
-	routes.RegisterUserRoutes(r, queries, dbConn)
-	routes.RegisterAuthRoutes(r, queries, dbConn)
-	routes.RegisterCoursesRoutes(r, queries, dbConn)
+	// Les routes suivantes sont rattachées à un établissement (en-tête X-Organization).
+	routes.UseOrganization(r, store)
+	routes.RegisterOrganizationRoutes(r, store)
+	routes.RegisterUserRoutes(r, store)
+	routes.RegisterAuthRoutes(r, store)
+	routes.RegisterOnboardingRoutes(r, store)
+	routes.RegisterCoursesRoutes(r, store)
+	routes.RegisterCohortsRoutes(r, store)
+	routes.RegisterRevisionsRoutes(r, store)
+	routes.RegisterStaffRoutes(r, store)
+	routes.RegisterProgressRoutes(r, store)
+	routes.RegisterCertificatesRoutes(r, store, systemStore, badgeKeys)
+	routes.RegisterBadgesRoutes(r, store, systemStore, badgeKeys)
+	routes.RegisterGamificationRoutes(r, store, gamificationRules)
+	routes.RegisterAnalyticsRoutes(r, store)
+	routes.RegisterReviewsRoutes(r, store)
+	routes.RegisterForumRoutes(r, store)
+	routes.RegisterAnnouncementsRoutes(r, store)
+	routes.RegisterMessagingRoutes(r, store)
+	routes.RegisterEventsRoutes(r, store, hub)
+	routes.RegisterNotificationsRoutes(r, store)
+	routes.RegisterJobsRoutes(r, store)
 
-	routes.RegisterProtectedRoutes(r, dbConn)
+	routes.RegisterProtectedRoutes(r, store)
 
-	r.Run() // listen and serve on 0.0.0.0:8080
+	srv := server.New(r, serverConfig)
+	// Les flux SSE ne se terminent pas d'eux-mêmes : on les ferme dès le début de l'arrêt.
+	srv.RegisterOnShutdown(hub.Close)
+	if err := server.Run(ctx, srv, serverConfig); err != nil {
+		slog.Error("Serveur HTTP", "error", err)
+	}
+
+	// Ordre d'arrêt : plus de requêtes, puis les tâches de fond (celles en cours vont au bout ;
+	// au-delà du délai, leur bail expire et un autre réplica les reprend), puis les spans, puis la base.
+	stopBackground()
+	done := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(serverConfig.ShutdownTimeout):
+		slog.Warn("Tâches de fond encore en cours à l'arrêt")
+	}
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Warn("Export des derniers spans", "error", err)
+	}
+	dbConn.Close()
+	systemConn.Close()
+	slog.Info("Arrêt terminé")
 }
@@ -17,21 +17,41 @@ func AuthRequired() gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token manquant ou invalide"})
 			return
 		}
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token invalide"})
+		authenticate(c, strings.TrimPrefix(authHeader, "Bearer "))
+	}
+}
+
+// StreamAuthRequired accepte aussi le token en paramètre access_token : EventSource ne permet
+// pas d'envoyer d'en-tête Authorization depuis le navigateur.
+func StreamAuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if strings.HasPrefix(authHeader, "Bearer ") {
+			authenticate(c, strings.TrimPrefix(authHeader, "Bearer "))
 			return
 		}
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token claims invalides"})
+		if token := c.Query("access_token"); token != "" {
+			authenticate(c, token)
 			return
 		}
-		c.Set("user_id", claims["user_id"])
-		c.Set("role", claims["role"])
-		c.Next()
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token manquant ou invalide"})
+	}
+}
+
+func authenticate(c *gin.Context, tokenString string) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	})
+	if err != nil || !token.Valid {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token invalide"})
+		return
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token claims invalides"})
+		return
 	}
+	c.Set("user_id", claims["user_id"])
+	c.Set("role", claims["role"])
+	c.Next()
 }
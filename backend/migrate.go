@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"online-learning-platform-backend/internal/database"
+	"online-learning-platform-backend/internal/migrate"
+	"online-learning-platform-backend/migrations"
+)
+
+// schemaWaitTimeout borne l'attente de la base au démarrage (Postgres qui redémarre, réseau lent).
+const schemaWaitTimeout = 30 * time.Second
+
+const migrateUsage = "usage : online-learning-platform migrate <commande>\n\n" + migrate.Usage
+
+// prepareSchema attend la base (jusqu'à schemaWaitTimeout) puis vérifie le schéma, ou le met à
+// jour si apply. Un schéma en retard est une erreur : l'instance ne doit pas servir.
+func prepareSchema(dbConn *sql.DB, m *migrate.Migrator, apply bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), schemaWaitTimeout)
+	defer cancel()
+	for {
+		err := database.Ping(ctx, dbConn)
+		if err == nil {
+			break
+		}
+		slog.Warn("Base de données indisponible, nouvel essai", "error", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(2 * time.Second):
+		}
+	}
+	if apply {
+		applied, err := m.Up(ctx, "")
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			slog.Info("Schéma mis à jour", "applied", applied)
+		}
+	}
+	if err := m.Check(ctx); err != nil {
+		return err
+	}
+	warnModified(ctx, m)
+	return nil
+}
+
+// warnModified signale les scripts deploy modifiés après leur déploiement : la base ne les a pas rejoués.
+func warnModified(ctx context.Context, m *migrate.Migrator) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range statuses {
+		if s.Modified {
+			slog.Warn("Script de migration modifié depuis son déploiement", "change", s.Name)
+		}
+	}
+}
+
+// runMigrate implémente la sous-commande migrate et renvoie le code de sortie.
+func runMigrate(args []string) int {
+	cmd, err := migrate.ParseCommand(args)
+	if err != nil {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	cfg, err := database.SystemConfigFromEnv()
+	if err != nil {
+		slog.Error("Configuration de la base de données invalide", "error", err)
+		return 1
+	}
+	dbConn, err := database.Open(cfg)
+	if err != nil {
+		slog.Error("Erreur de connexion à la base de données", "error", err)
+		return 1
+	}
+	defer dbConn.Close()
+	m, err := migrate.New(dbConn, migrations.FS)
+	if err != nil {
+		slog.Error("Migrations embarquées invalides", "error", err)
+		return 1
+	}
+	if err := cmd.Run(context.Background(), m, os.Stdout); err != nil {
+		slog.Error("Échec de la migration", "error", err)
+		return 1
+	}
+	return 0
+}
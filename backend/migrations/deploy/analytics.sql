@@ -0,0 +1,67 @@
+-- Deploy online-learning-platform:analytics to pg
+-- requires: gamification
+
+BEGIN;
+
+-- Temps passé sur une leçon, envoyé par tranches par la page de la leçon.
+CREATE TABLE IF NOT EXISTS lesson_activity (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    seconds INTEGER NOT NULL CHECK (seconds > 0),
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_activity_recorded_at ON lesson_activity(recorded_at);
+CREATE INDEX IF NOT EXISTS idx_lesson_activity_lesson ON lesson_activity(lesson_id);
+CREATE INDEX IF NOT EXISTS idx_lesson_activity_course_user ON lesson_activity(course_id, user_id);
+
+-- Le recalcul incrémental part des lignes récentes des tables brutes.
+CREATE INDEX IF NOT EXISTS idx_lesson_completions_completed_at ON lesson_completions(completed_at);
+CREATE INDEX IF NOT EXISTS idx_lesson_completions_lesson ON lesson_completions(lesson_id);
+CREATE INDEX IF NOT EXISTS idx_enrollments_enrolled_at ON enrollments(enrolled_at);
+CREATE INDEX IF NOT EXISTS idx_enrollments_completed_at ON enrollments(completed_at);
+
+-- Agrégats lus par les tableaux de bord enseignants, tenus à jour par la tâche analytics.rollup :
+-- seules les clés (cours et jour, leçon, apprenant) touchées depuis le dernier passage sont
+-- recalculées, entièrement, à partir des tables brutes.
+CREATE TABLE IF NOT EXISTS course_daily_stats (
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    enrollments INTEGER NOT NULL DEFAULT 0,
+    completions INTEGER NOT NULL DEFAULT 0,
+    active_learners INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (course_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS lesson_stats (
+    lesson_id INTEGER PRIMARY KEY REFERENCES lessons(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    completions INTEGER NOT NULL DEFAULT 0,
+    learners INTEGER NOT NULL DEFAULT 0,
+    time_spent_seconds BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_stats_course ON lesson_stats(course_id);
+
+CREATE TABLE IF NOT EXISTS learner_stats (
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    enrolled_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    lessons_completed INTEGER NOT NULL DEFAULT 0,
+    time_spent_seconds BIGINT NOT NULL DEFAULT 0,
+    last_active_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (course_id, user_id)
+);
+
+-- Jusqu'où chaque recalcul a lu les tables brutes.
+CREATE TABLE IF NOT EXISTS analytics_rollups (
+    name TEXT PRIMARY KEY,
+    processed_until TIMESTAMP NOT NULL
+);
+
+COMMIT;
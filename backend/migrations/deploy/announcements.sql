@@ -0,0 +1,32 @@
+-- Deploy online-learning-platform:announcements to pg
+-- requires: notifications
+
+BEGIN;
+
+-- publish_at fixe la date de parution ; published_at est posé quand les destinataires ont été prévenus.
+CREATE TABLE IF NOT EXISTS announcements (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    cohort_id INTEGER REFERENCES cohorts(id) ON DELETE CASCADE,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    body_html TEXT NOT NULL,
+    send_email BOOLEAN NOT NULL DEFAULT FALSE,
+    publish_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcements_course ON announcements(course_id, publish_at DESC);
+CREATE INDEX IF NOT EXISTS idx_announcements_due ON announcements(publish_at) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS announcement_reads (
+    announcement_id INTEGER NOT NULL REFERENCES announcements(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (announcement_id, user_id)
+);
+
+COMMIT;
@@ -0,0 +1,45 @@
+-- Deploy online-learning-platform:badges to pg
+-- requires: certificates
+
+BEGIN;
+
+-- Badge (Achievement Open Badges 3.0) décerné à la fin d'un cours. Pas de politique RLS : la
+-- vérification publique d'un badge doit aboutir quel que soit l'établissement de la requête,
+-- l'API d'administration filtre elle-même par établissement.
+CREATE TABLE IF NOT EXISTS badge_classes (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    course_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    criteria TEXT NOT NULL,
+    image_url TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (course_id, organization_id) REFERENCES courses(id, organization_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_badge_classes_organization_id ON badge_classes(organization_id);
+CREATE INDEX IF NOT EXISTS idx_badge_classes_course_id ON badge_classes(course_id) WHERE active;
+
+-- Credential signé (OpenBadgeCredential) : le document JSON-LD tel que signé, et sa forme
+-- VC-JWT. key_id désigne la clé de la plateforme qui l'a signé.
+CREATE TABLE IF NOT EXISTS badge_credentials (
+    id TEXT PRIMARY KEY,
+    badge_class_id INTEGER NOT NULL REFERENCES badge_classes(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    credential JSONB NOT NULL,
+    jwt TEXT NOT NULL,
+    key_id TEXT NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP,
+    revoked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revocation_reason TEXT,
+    UNIQUE (badge_class_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_badge_credentials_user_id ON badge_credentials(user_id, issued_at);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Deploy online-learning-platform:certificate_manifests to pg
+-- requires: analytics
+
+BEGIN;
+
+-- Manifeste signé (JWS RS256) par la clé de la plateforme à la génération du PDF : il atteste
+-- l'identifiant, le titulaire, le cours et l'empreinte du PDF. key_id désigne la clé signataire.
+ALTER TABLE certificates ADD COLUMN manifest TEXT;
+ALTER TABLE certificates ADD COLUMN key_id TEXT;
+
+COMMIT;
@@ -0,0 +1,49 @@
+-- Deploy online-learning-platform:certificates to pg
+-- requires: organizations
+
+BEGIN;
+
+-- Modèle de certificat d'un cours, personnalisable par son équipe. body est un modèle
+-- text/template ({{.Name}}, {{.Course}}, {{.Date}}, {{.Instructor}}, {{.ID}}) ; sans ligne
+-- ici, le modèle par défaut de internal/certificates s'applique.
+CREATE TABLE IF NOT EXISTS certificate_templates (
+    course_id INTEGER PRIMARY KEY REFERENCES courses(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    signatory_name TEXT,
+    signatory_title TEXT,
+    accent_color TEXT CHECK (accent_color ~ '^#[0-9a-fA-F]{6}$'),
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Un certificat par inscrit et par cours. Nom, titre du cours et enseignant sont figés à la
+-- délivrance ; le PDF est produit en tâche de fond (status pending puis issued).
+CREATE TABLE IF NOT EXISTS certificates (
+    id TEXT PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    recipient_name TEXT NOT NULL,
+    course_title TEXT NOT NULL,
+    instructor_name TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'issued')),
+    pdf_sha256 TEXT,
+    issued_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revocation_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_certificates_course_id ON certificates(course_id, created_at);
+
+-- Le PDF à part : les listes de certificats ne le chargent pas.
+CREATE TABLE IF NOT EXISTS certificate_files (
+    certificate_id TEXT PRIMARY KEY REFERENCES certificates(id) ON DELETE CASCADE,
+    content BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
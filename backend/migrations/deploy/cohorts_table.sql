@@ -0,0 +1,31 @@
+-- Deploy online-learning-platform:cohorts_table to pg
+-- requires: courses_table
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cohorts (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    instructor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    enrollment_code TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_cohorts_course_id ON cohorts(course_id);
+
+CREATE TABLE IF NOT EXISTS enrollments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    cohort_id INTEGER REFERENCES cohorts(id) ON DELETE SET NULL,
+    enrolled_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_enrollments_cohort_id ON enrollments(cohort_id);
+
+COMMIT;
@@ -0,0 +1,52 @@
+-- Deploy online-learning-platform:courses_versioning to pg
+-- requires: cohorts_table
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lessons (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lessons_course_id ON lessons(course_id);
+
+-- Révisions publiées : jamais modifiées, seulement ajoutées.
+CREATE TABLE IF NOT EXISTS course_revisions (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    lessons JSONB NOT NULL DEFAULT '[]',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (course_id, number)
+);
+
+CREATE OR REPLACE FUNCTION course_revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'course_revisions est en lecture seule';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER course_revisions_no_update
+    BEFORE UPDATE ON course_revisions
+    FOR EACH ROW EXECUTE FUNCTION course_revisions_immutable();
+
+-- Brouillon de travail d'un cours, un seul par cours.
+CREATE TABLE IF NOT EXISTS course_drafts (
+    course_id INTEGER PRIMARY KEY REFERENCES courses(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    description TEXT,
+    lessons JSONB NOT NULL DEFAULT '[]',
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE courses ADD COLUMN published_revision_id INTEGER REFERENCES course_revisions(id);
+
+ALTER TABLE enrollments ADD COLUMN started_revision_id INTEGER REFERENCES course_revisions(id);
+ALTER TABLE enrollments ADD COLUMN pinned_revision_id INTEGER REFERENCES course_revisions(id);
+
+COMMIT;
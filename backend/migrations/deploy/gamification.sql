@@ -0,0 +1,48 @@
+-- Deploy online-learning-platform:gamification to pg
+-- requires: badges
+
+BEGIN;
+
+-- Profil de jeu d'un utilisateur : total de points, série de jours d'apprentissage (comptés dans
+-- son fuseau horaire) et choix d'apparaître ou non dans les classements.
+CREATE TABLE IF NOT EXISTS gamification_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    points INTEGER NOT NULL DEFAULT 0,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    longest_streak INTEGER NOT NULL DEFAULT 0,
+    last_active_on DATE,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Événements qui rapportent des points. source_key rend l'enregistrement idempotent (une leçon
+-- terminée deux fois ne compte qu'une fois) ; le worker fixe points selon le barème en vigueur
+-- et processed_at en l'appliquant au profil.
+CREATE TABLE IF NOT EXISTS point_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    course_id INTEGER REFERENCES courses(id) ON DELETE SET NULL,
+    event TEXT NOT NULL,
+    points INTEGER NOT NULL DEFAULT 0,
+    source_key TEXT NOT NULL UNIQUE,
+    occurred_at TIMESTAMP NOT NULL,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_events_user_event ON point_events(user_id, event);
+CREATE INDEX IF NOT EXISTS idx_point_events_course ON point_events(course_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_point_events_occurred_at ON point_events(occurred_at);
+
+-- Succès obtenus ; les règles elles-mêmes vivent dans la configuration (internal/gamification).
+CREATE TABLE IF NOT EXISTS user_achievements (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    achievement_key TEXT NOT NULL,
+    earned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_achievements_earned_at ON user_achievements(earned_at);
+
+COMMIT;
@@ -0,0 +1,44 @@
+-- Deploy online-learning-platform:jobs to pg
+-- requires: announcements
+
+BEGIN;
+
+-- File de tâches de fond. Une tâche "running" dont le bail (locked_until) a expiré appartient à
+-- un worker tombé et redevient disponible ; au-delà de max_attempts elle passe en "dead".
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'dead')),
+    priority INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_by TEXT,
+    locked_until TIMESTAMP,
+    last_error TEXT,
+    unique_key TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_ready ON jobs(priority DESC, run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_kind_status ON jobs(kind, status);
+
+-- Déduplication : une seule tâche en attente ou en cours par clé.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_unique_pending ON jobs(unique_key)
+    WHERE unique_key IS NOT NULL AND status IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS job_schedules (
+    name TEXT PRIMARY KEY,
+    cron_expr TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP
+);
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Deploy online-learning-platform:onboarding to pg
+-- requires: jobs
+
+BEGIN;
+
+-- Invitations émises par un admin : seul moyen d'obtenir un rôle enseignant ou admin sans
+-- candidature. Le jeton n'est jamais stocké, seulement son empreinte ; usage unique.
+CREATE TABLE IF NOT EXISTS user_invitations (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('teacher', 'admin')),
+    token_hash TEXT UNIQUE NOT NULL,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_by INTEGER REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Candidatures au rôle enseignant, examinées par un admin. Une seule en attente par compte.
+CREATE TABLE IF NOT EXISTS teacher_applications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    motivation TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teacher_applications_pending ON teacher_applications(user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_teacher_applications_status ON teacher_applications(status, created_at);
+
+COMMIT;
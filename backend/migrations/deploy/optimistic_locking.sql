@@ -0,0 +1,9 @@
+-- Deploy online-learning-platform:optimistic_locking to pg
+-- requires: courses_versioning
+
+BEGIN;
+
+ALTER TABLE courses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE course_drafts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,78 @@
+-- Deploy online-learning-platform:organizations to pg
+-- requires: onboarding
+
+BEGIN;
+
+-- Établissements hébergés sur la plateforme. Le slug identifie l'établissement dans les
+-- requêtes (en-tête X-Organization) ; les couleurs et le logo habillent le frontend.
+CREATE TABLE IF NOT EXISTS organizations (
+    id SERIAL PRIMARY KEY,
+    slug TEXT UNIQUE NOT NULL CHECK (slug ~ '^[a-z0-9][a-z0-9-]{1,62}$'),
+    name TEXT NOT NULL,
+    logo_url TEXT,
+    primary_color TEXT CHECK (primary_color ~ '^#[0-9a-fA-F]{6}$'),
+    accent_color TEXT CHECK (accent_color ~ '^#[0-9a-fA-F]{6}$'),
+    support_email TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Un compte peut appartenir à plusieurs établissements, avec un rôle propre à chacun. Le rôle
+-- global users.role = 'admin' reste celui du super-admin, qui gère tous les établissements.
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'instructor', 'admin')),
+    joined_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+
+-- Les données existantes rejoignent l'établissement par défaut.
+INSERT INTO organizations (slug, name) VALUES ('default', 'Établissement par défaut')
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT o.id, u.id, CASE u.role WHEN 'admin' THEN 'admin' WHEN 'teacher' THEN 'instructor' ELSE 'member' END
+FROM users u
+CROSS JOIN organizations o
+WHERE o.slug = 'default'
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id) ON DELETE RESTRICT;
+UPDATE courses SET organization_id = (SELECT id FROM organizations WHERE slug = 'default') WHERE organization_id IS NULL;
+ALTER TABLE courses ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_courses_organization_id ON courses(organization_id, created_at);
+-- Cible de la clé étrangère composite des cohortes.
+ALTER TABLE courses ADD CONSTRAINT courses_id_organization_key UNIQUE (id, organization_id);
+
+-- Une cohorte appartient toujours à l'établissement de son cours : la clé composite l'impose,
+-- y compris si le cours change d'établissement.
+ALTER TABLE cohorts ADD COLUMN IF NOT EXISTS organization_id INTEGER;
+UPDATE cohorts ch SET organization_id = c.organization_id FROM courses c WHERE c.id = ch.course_id;
+ALTER TABLE cohorts ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE cohorts ADD CONSTRAINT cohorts_course_organization_fkey
+    FOREIGN KEY (course_id, organization_id) REFERENCES courses(id, organization_id) ON UPDATE CASCADE ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_cohorts_organization_id ON cohorts(organization_id);
+
+-- Isolation par row-level security. L'application pose app.organization_id sur la connexion
+-- qui sert une requête HTTP (repository.WithOrganization) ; sans ce paramètre (tâches de fond,
+-- CLI d'administration), toutes les lignes restent visibles. FORCE applique les politiques au
+-- propriétaire des tables ; un superutilisateur ou un rôle BYPASSRLS y échappe toujours.
+CREATE OR REPLACE FUNCTION current_organization_id() RETURNS INTEGER
+LANGUAGE sql STABLE AS $$
+    SELECT NULLIF(current_setting('app.organization_id', true), '')::integer
+$$;
+
+ALTER TABLE courses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE courses FORCE ROW LEVEL SECURITY;
+CREATE POLICY courses_tenant_isolation ON courses
+    USING (current_organization_id() IS NULL OR organization_id = current_organization_id());
+
+ALTER TABLE cohorts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE cohorts FORCE ROW LEVEL SECURITY;
+CREATE POLICY cohorts_tenant_isolation ON cohorts
+    USING (current_organization_id() IS NULL OR organization_id = current_organization_id());
+
+COMMIT;
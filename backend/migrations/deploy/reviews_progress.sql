@@ -0,0 +1,73 @@
+-- Deploy online-learning-platform:reviews_progress to pg
+-- requires: staff_roles
+
+BEGIN;
+
+-- Progression : une ligne par leçon terminée.
+CREATE TABLE IF NOT EXISTS lesson_completions (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    completed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, lesson_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_completions_user_course ON lesson_completions(user_id, course_id);
+
+ALTER TABLE enrollments ADD COLUMN completed_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS course_reviews (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reply_body TEXT,
+    replied_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    replied_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (course_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS course_review_reports (
+    review_id INTEGER NOT NULL REFERENCES course_reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- Agrégats dénormalisés pour que le catalogue n'ait pas à recalculer les moyennes.
+ALTER TABLE courses ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE courses ADD COLUMN rating_sum INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE courses ADD COLUMN rating_updated_at TIMESTAMP;
+
+CREATE OR REPLACE FUNCTION refresh_course_rating() RETURNS trigger AS $$
+DECLARE
+    target INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD.course_id;
+    ELSE
+        target := NEW.course_id;
+    END IF;
+    UPDATE courses
+    SET rating_count = s.cnt, rating_sum = s.total, rating_updated_at = NOW()
+    FROM (
+        SELECT COUNT(*) AS cnt, COALESCE(SUM(rating), 0) AS total
+        FROM course_reviews
+        WHERE course_id = target AND NOT hidden
+    ) s
+    WHERE id = target;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER course_reviews_refresh_rating
+    AFTER INSERT OR UPDATE OF rating, hidden OR DELETE ON course_reviews
+    FOR EACH ROW EXECUTE FUNCTION refresh_course_rating();
+
+COMMIT;
@@ -0,0 +1,36 @@
+-- Deploy online-learning-platform:staff_roles to pg
+-- requires: optimistic_locking
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS course_staff (
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'co_instructor', 'ta', 'grader')),
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (course_id, user_id)
+);
+
+-- Un seul propriétaire par cours.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_course_staff_owner ON course_staff(course_id) WHERE role = 'owner';
+CREATE INDEX IF NOT EXISTS idx_course_staff_user_id ON course_staff(user_id);
+
+INSERT INTO course_staff (course_id, user_id, role)
+SELECT id, author_id, 'owner' FROM courses WHERE author_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS course_staff_invitations (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('co_instructor', 'ta', 'grader')),
+    token_hash TEXT UNIQUE NOT NULL,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_staff_invitations_course_id ON course_staff_invitations(course_id);
+
+COMMIT;
@@ -0,0 +1,101 @@
+-- Deploy online-learning-platform:tenant_isolation to pg
+-- requires: certificate_manifests
+
+BEGIN;
+
+-- Isolation fermée par défaut : sans app.organization_id, les politiques ne laissent voir aucune
+-- ligne. Deux rôles se partagent la base :
+--   - olp_app, celui des requêtes HTTP : ni superutilisateur ni BYPASSRLS, il ne voit que
+--     l'établissement posé sur sa connexion (repository.WithOrganization) ;
+--   - le rôle système (DB_SYSTEM_USER), propriétaire du schéma : migrations, worker et CLI. Il
+--     est superutilisateur ou BYPASSRLS, seule façon explicite d'échapper aux politiques.
+-- Créer olp_app demande CREATEROLE ; docker-compose le crée à l'initialisation de la base.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'olp_app') THEN
+        CREATE ROLE olp_app NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT USAGE ON SCHEMA public TO olp_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO olp_app;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO olp_app;
+-- Tables des migrations suivantes, créées par le même rôle.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO olp_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO olp_app;
+
+ALTER POLICY courses_tenant_isolation ON courses
+    USING (organization_id = current_organization_id());
+ALTER POLICY cohorts_tenant_isolation ON cohorts
+    USING (organization_id = current_organization_id());
+
+-- Vrai si le cours appartient à l'établissement de la connexion. La lecture de courses passe
+-- elle-même par sa politique.
+CREATE OR REPLACE FUNCTION course_in_current_organization(course INTEGER) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    SELECT EXISTS (SELECT 1 FROM courses WHERE id = course AND organization_id = current_organization_id())
+$$;
+
+-- Tables rattachées à un cours par course_id.
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'enrollments', 'lessons', 'course_revisions', 'course_drafts', 'course_staff',
+        'course_staff_invitations', 'lesson_completions', 'course_reviews', 'forum_threads',
+        'forum_bans', 'announcements', 'certificate_templates', 'certificates', 'lesson_activity',
+        'course_daily_stats', 'lesson_stats', 'learner_stats'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('CREATE POLICY %I ON %I USING (course_in_current_organization(course_id))', t || '_tenant_isolation', t);
+    END LOOP;
+END
+$$;
+
+-- Tables rattachées à un cours par leur parent, dont la politique filtre déjà les lignes.
+ALTER TABLE course_review_reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE course_review_reports FORCE ROW LEVEL SECURITY;
+CREATE POLICY course_review_reports_tenant_isolation ON course_review_reports
+    USING (EXISTS (SELECT 1 FROM course_reviews r WHERE r.id = review_id));
+
+ALTER TABLE forum_posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE forum_posts FORCE ROW LEVEL SECURITY;
+CREATE POLICY forum_posts_tenant_isolation ON forum_posts
+    USING (EXISTS (SELECT 1 FROM forum_threads t WHERE t.id = thread_id));
+
+ALTER TABLE forum_post_votes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE forum_post_votes FORCE ROW LEVEL SECURITY;
+CREATE POLICY forum_post_votes_tenant_isolation ON forum_post_votes
+    USING (EXISTS (SELECT 1 FROM forum_posts p WHERE p.id = post_id));
+
+ALTER TABLE announcement_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE announcement_reads FORCE ROW LEVEL SECURITY;
+CREATE POLICY announcement_reads_tenant_isolation ON announcement_reads
+    USING (EXISTS (SELECT 1 FROM announcements a WHERE a.id = announcement_id));
+
+ALTER TABLE certificate_files ENABLE ROW LEVEL SECURITY;
+ALTER TABLE certificate_files FORCE ROW LEVEL SECURITY;
+CREATE POLICY certificate_files_tenant_isolation ON certificate_files
+    USING (EXISTS (SELECT 1 FROM certificates c WHERE c.id = certificate_id));
+
+-- Les badges rejoignent l'isolation : leurs documents publics sont lus avec le rôle système.
+ALTER TABLE badge_classes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE badge_classes FORCE ROW LEVEL SECURITY;
+CREATE POLICY badge_classes_tenant_isolation ON badge_classes
+    USING (organization_id = current_organization_id());
+
+ALTER TABLE badge_credentials ENABLE ROW LEVEL SECURITY;
+ALTER TABLE badge_credentials FORCE ROW LEVEL SECURITY;
+CREATE POLICY badge_credentials_tenant_isolation ON badge_credentials
+    USING (EXISTS (SELECT 1 FROM badge_classes b WHERE b.id = badge_class_id));
+
+-- Points hors cours (course_id nul) : propres à l'utilisateur, visibles partout.
+ALTER TABLE point_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE point_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY point_events_tenant_isolation ON point_events
+    USING (course_id IS NULL OR course_in_current_organization(course_id));
+
+COMMIT;
@@ -0,0 +1,31 @@
+-- Deploy online-learning-platform:user_events to pg
+-- requires: messaging
+
+BEGIN;
+
+-- Journal des événements poussés aux utilisateurs, conservé quelques jours pour rejouer après une reconnexion.
+CREATE TABLE IF NOT EXISTS user_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_events_user ON user_events(user_id, id);
+CREATE INDEX IF NOT EXISTS idx_user_events_created_at ON user_events(created_at);
+
+-- Chaque réplica écoute ce canal ; la notification ne porte que "<id>:<user_id>", l'événement est relu en base.
+CREATE OR REPLACE FUNCTION notify_user_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('user_events', NEW.id::text || ':' || NEW.user_id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS user_events_notify ON user_events;
+CREATE TRIGGER user_events_notify
+    AFTER INSERT ON user_events
+    FOR EACH ROW EXECUTE FUNCTION notify_user_event();
+
+COMMIT;
@@ -0,0 +1,8 @@
+// Package migrations embarque le schéma de la base : le plan (sqitch.plan, format sqitch) et,
+// pour chaque migration, ses scripts deploy/, revert/ et verify/. Voir internal/migrate.
+package migrations
+
+import "embed"
+
+//go:embed sqitch.plan deploy/*.sql revert/*.sql verify/*.sql
+var FS embed.FS
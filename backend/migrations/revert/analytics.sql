@@ -0,0 +1,17 @@
+-- Revert online-learning-platform:analytics from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS analytics_rollups;
+DROP TABLE IF EXISTS learner_stats;
+DROP TABLE IF EXISTS lesson_stats;
+DROP TABLE IF EXISTS course_daily_stats;
+
+DROP INDEX IF EXISTS idx_enrollments_completed_at;
+DROP INDEX IF EXISTS idx_enrollments_enrolled_at;
+DROP INDEX IF EXISTS idx_lesson_completions_lesson;
+DROP INDEX IF EXISTS idx_lesson_completions_completed_at;
+
+DROP TABLE IF EXISTS lesson_activity;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:announcements from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS announcement_reads;
+DROP TABLE IF EXISTS announcements;
+
+COMMIT;
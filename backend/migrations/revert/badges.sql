@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:badges from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS badge_credentials;
+DROP TABLE IF EXISTS badge_classes;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:certificate_manifests from pg
+
+BEGIN;
+
+ALTER TABLE certificates DROP COLUMN IF EXISTS key_id;
+ALTER TABLE certificates DROP COLUMN IF EXISTS manifest;
+
+COMMIT;
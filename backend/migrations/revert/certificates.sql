@@ -0,0 +1,9 @@
+-- Revert online-learning-platform:certificates from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS certificate_files;
+DROP TABLE IF EXISTS certificates;
+DROP TABLE IF EXISTS certificate_templates;
+
+COMMIT;
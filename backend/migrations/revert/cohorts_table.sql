@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:cohorts_table from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS enrollments;
+DROP TABLE IF EXISTS cohorts;
+
+COMMIT;
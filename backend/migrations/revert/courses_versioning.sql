@@ -0,0 +1,14 @@
+-- Revert online-learning-platform:courses_versioning from pg
+
+BEGIN;
+
+ALTER TABLE enrollments DROP COLUMN IF EXISTS pinned_revision_id;
+ALTER TABLE enrollments DROP COLUMN IF EXISTS started_revision_id;
+ALTER TABLE courses DROP COLUMN IF EXISTS published_revision_id;
+
+DROP TABLE IF EXISTS course_drafts;
+DROP TABLE IF EXISTS course_revisions;
+DROP FUNCTION IF EXISTS course_revisions_immutable();
+DROP TABLE IF EXISTS lessons;
+
+COMMIT;
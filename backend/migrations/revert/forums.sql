@@ -0,0 +1,11 @@
+-- Revert online-learning-platform:forums from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS forum_bans;
+DROP TABLE IF EXISTS forum_post_votes;
+ALTER TABLE IF EXISTS forum_threads DROP CONSTRAINT IF EXISTS forum_threads_accepted_post_fk;
+DROP TABLE IF EXISTS forum_posts;
+DROP TABLE IF EXISTS forum_threads;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Revert online-learning-platform:gamification from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS point_events;
+DROP TABLE IF EXISTS gamification_profiles;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:jobs from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS job_schedules;
+DROP TABLE IF EXISTS jobs;
+
+COMMIT;
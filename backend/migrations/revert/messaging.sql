@@ -0,0 +1,10 @@
+-- Revert online-learning-platform:messaging from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS message_attachments;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversation_participants;
+DROP TABLE IF EXISTS conversations;
+
+COMMIT;
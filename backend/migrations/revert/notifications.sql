@@ -0,0 +1,10 @@
+-- Revert online-learning-platform:notifications from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TABLE IF EXISTS notification_webhooks;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+
+COMMIT;
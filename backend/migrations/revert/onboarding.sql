@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:onboarding from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS teacher_applications;
+DROP TABLE IF EXISTS user_invitations;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:optimistic_locking from pg
+
+BEGIN;
+
+ALTER TABLE course_drafts DROP COLUMN IF EXISTS version;
+ALTER TABLE courses DROP COLUMN IF EXISTS version;
+
+COMMIT;
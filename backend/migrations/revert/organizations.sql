@@ -0,0 +1,21 @@
+-- Revert online-learning-platform:organizations from pg
+
+BEGIN;
+
+DROP POLICY IF EXISTS cohorts_tenant_isolation ON cohorts;
+ALTER TABLE cohorts NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE cohorts DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS courses_tenant_isolation ON courses;
+ALTER TABLE courses NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE courses DISABLE ROW LEVEL SECURITY;
+DROP FUNCTION IF EXISTS current_organization_id();
+
+ALTER TABLE cohorts DROP CONSTRAINT IF EXISTS cohorts_course_organization_fkey;
+ALTER TABLE cohorts DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_id_organization_key;
+ALTER TABLE courses DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Revert online-learning-platform:reviews_progress from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS course_review_reports;
+DROP TABLE IF EXISTS course_reviews;
+DROP FUNCTION IF EXISTS refresh_course_rating();
+
+ALTER TABLE courses DROP COLUMN IF EXISTS rating_updated_at;
+ALTER TABLE courses DROP COLUMN IF EXISTS rating_sum;
+ALTER TABLE courses DROP COLUMN IF EXISTS rating_count;
+
+ALTER TABLE enrollments DROP COLUMN IF EXISTS completed_at;
+DROP TABLE IF EXISTS lesson_completions;
+
+COMMIT;
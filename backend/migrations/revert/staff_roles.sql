@@ -0,0 +1,8 @@
+-- Revert online-learning-platform:staff_roles from pg
+
+BEGIN;
+
+DROP TABLE IF EXISTS course_staff_invitations;
+DROP TABLE IF EXISTS course_staff;
+
+COMMIT;
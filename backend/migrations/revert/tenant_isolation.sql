@@ -0,0 +1,37 @@
+-- Revert online-learning-platform:tenant_isolation from pg
+
+BEGIN;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'enrollments', 'lessons', 'course_revisions', 'course_drafts', 'course_staff',
+        'course_staff_invitations', 'lesson_completions', 'course_reviews', 'forum_threads',
+        'forum_bans', 'announcements', 'certificate_templates', 'certificates', 'lesson_activity',
+        'course_daily_stats', 'lesson_stats', 'learner_stats', 'course_review_reports', 'forum_posts',
+        'forum_post_votes', 'announcement_reads', 'certificate_files', 'badge_classes',
+        'badge_credentials', 'point_events'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS %I ON %I', t || '_tenant_isolation', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+    END LOOP;
+END
+$$;
+
+DROP FUNCTION IF EXISTS course_in_current_organization(INTEGER);
+
+ALTER POLICY courses_tenant_isolation ON courses
+    USING (current_organization_id() IS NULL OR organization_id = current_organization_id());
+ALTER POLICY cohorts_tenant_isolation ON cohorts
+    USING (current_organization_id() IS NULL OR organization_id = current_organization_id());
+
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM olp_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM olp_app;
+REVOKE USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public FROM olp_app;
+REVOKE SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public FROM olp_app;
+REVOKE USAGE ON SCHEMA public FROM olp_app;
+
+COMMIT;
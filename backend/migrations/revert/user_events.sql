@@ -0,0 +1,9 @@
+-- Revert online-learning-platform:user_events from pg
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS user_events_notify ON user_events;
+DROP FUNCTION IF EXISTS notify_user_event();
+DROP TABLE IF EXISTS user_events;
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- Verify online-learning-platform:analytics on pg
+
+BEGIN;
+
+SELECT id, user_id, course_id, lesson_id, seconds, recorded_at
+FROM lesson_activity
+WHERE FALSE;
+
+SELECT course_id, day, enrollments, completions, active_learners
+FROM course_daily_stats
+WHERE FALSE;
+
+SELECT lesson_id, course_id, completions, learners, time_spent_seconds, updated_at
+FROM lesson_stats
+WHERE FALSE;
+
+SELECT course_id, user_id, enrolled_at, completed_at, lessons_completed, time_spent_seconds, last_active_at, updated_at
+FROM learner_stats
+WHERE FALSE;
+
+SELECT name, processed_until
+FROM analytics_rollups
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,12 @@
+-- Verify online-learning-platform:announcements on pg
+
+BEGIN;
+
+SELECT id, course_id, cohort_id, author_id, title, body, body_html, send_email,
+       publish_at, published_at, created_at, updated_at
+FROM announcements
+WHERE FALSE;
+
+SELECT announcement_id, user_id, read_at FROM announcement_reads WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,15 @@
+-- Verify online-learning-platform:badges on pg
+
+BEGIN;
+
+SELECT id, organization_id, course_id, name, description, criteria, image_url, active, created_by,
+       created_at, updated_at
+FROM badge_classes
+WHERE FALSE;
+
+SELECT id, badge_class_id, user_id, credential, jwt, key_id, issued_at, revoked_at, revoked_by,
+       revocation_reason
+FROM badge_credentials
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify online-learning-platform:certificate_manifests on pg
+
+BEGIN;
+
+SELECT manifest, key_id FROM certificates WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,18 @@
+-- Verify online-learning-platform:certificates on pg
+
+BEGIN;
+
+SELECT course_id, title, body, signatory_name, signatory_title, accent_color, updated_by, updated_at
+FROM certificate_templates
+WHERE FALSE;
+
+SELECT id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status,
+       pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at
+FROM certificates
+WHERE FALSE;
+
+SELECT certificate_id, content, created_at
+FROM certificate_files
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,13 @@
+-- Verify online-learning-platform:cohorts_table on pg
+
+BEGIN;
+
+SELECT id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at
+FROM cohorts
+WHERE FALSE;
+
+SELECT id, user_id, course_id, cohort_id, enrolled_at
+FROM enrollments
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify online-learning-platform:courses_table on pg
+
+BEGIN;
+
+SELECT id, title, description, created_at, updated_at, author_id FROM courses WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,19 @@
+-- Verify online-learning-platform:courses_versioning on pg
+
+BEGIN;
+
+SELECT id, course_id, created_at FROM lessons WHERE FALSE;
+
+SELECT id, course_id, number, title, description, lessons, created_by, created_at
+FROM course_revisions
+WHERE FALSE;
+
+SELECT course_id, title, description, lessons, updated_by, updated_at
+FROM course_drafts
+WHERE FALSE;
+
+SELECT published_revision_id FROM courses WHERE FALSE;
+SELECT started_revision_id, pinned_revision_id FROM enrollments WHERE FALSE;
+SELECT has_function_privilege('course_revisions_immutable()', 'execute');
+
+ROLLBACK;
@@ -0,0 +1,17 @@
+-- Verify online-learning-platform:forums on pg
+
+BEGIN;
+
+SELECT id, course_id, lesson_id, cohort_id, author_id, title, body, body_html,
+       pinned, locked, hidden, accepted_post_id, created_at, last_activity_at
+FROM forum_threads
+WHERE FALSE;
+
+SELECT id, thread_id, parent_id, author_id, body, body_html, hidden, deleted_at, created_at, updated_at
+FROM forum_posts
+WHERE FALSE;
+
+SELECT post_id, user_id, created_at FROM forum_post_votes WHERE FALSE;
+SELECT course_id, user_id, banned_by, reason, created_at FROM forum_bans WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,17 @@
+-- Verify online-learning-platform:gamification on pg
+
+BEGIN;
+
+SELECT user_id, points, current_streak, longest_streak, last_active_on, timezone, leaderboard_opt_out, updated_at
+FROM gamification_profiles
+WHERE FALSE;
+
+SELECT id, user_id, course_id, event, points, source_key, occurred_at, processed_at, created_at
+FROM point_events
+WHERE FALSE;
+
+SELECT user_id, achievement_key, earned_at
+FROM user_achievements
+WHERE FALSE;
+
+ROLLBACK;
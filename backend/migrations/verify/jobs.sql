@@ -0,0 +1,12 @@
+-- Verify online-learning-platform:jobs on pg
+
+BEGIN;
+
+SELECT id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until,
+       last_error, unique_key, created_at, updated_at, finished_at
+FROM jobs
+WHERE FALSE;
+
+SELECT name, cron_expr, kind, payload, enabled, next_run_at, last_run_at FROM job_schedules WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,10 @@
+-- Verify online-learning-platform:messaging on pg
+
+BEGIN;
+
+SELECT id, subject, is_group, created_by, created_at, last_message_at FROM conversations WHERE FALSE;
+SELECT conversation_id, user_id, joined_at, last_read_message_id, last_read_at FROM conversation_participants WHERE FALSE;
+SELECT id, conversation_id, sender_id, body, created_at FROM messages WHERE FALSE;
+SELECT id, message_id, filename, content_type, size_bytes, data, created_at FROM message_attachments WHERE FALSE;
+
+ROLLBACK;
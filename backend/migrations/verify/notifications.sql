@@ -0,0 +1,16 @@
+-- Verify online-learning-platform:notifications on pg
+
+BEGIN;
+
+SELECT id, user_id, type, title, body, link, data, in_app, dedupe_key, read_at, created_at
+FROM notifications
+WHERE FALSE;
+
+SELECT user_id, event_type, in_app, email, webhook, digest, updated_at FROM notification_preferences WHERE FALSE;
+SELECT user_id, url, secret, created_at FROM notification_webhooks WHERE FALSE;
+
+SELECT id, notification_id, user_id, channel, digest, status, attempts, last_error, next_attempt_at, created_at, sent_at
+FROM notification_deliveries
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,13 @@
+-- Verify online-learning-platform:onboarding on pg
+
+BEGIN;
+
+SELECT id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at, accepted_by
+FROM user_invitations
+WHERE FALSE;
+
+SELECT id, user_id, motivation, status, reviewed_by, review_note, created_at, reviewed_at
+FROM teacher_applications
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,8 @@
+-- Verify online-learning-platform:optimistic_locking on pg
+
+BEGIN;
+
+SELECT version FROM courses WHERE FALSE;
+SELECT version FROM course_drafts WHERE FALSE;
+
+ROLLBACK;
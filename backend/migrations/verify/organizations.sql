@@ -0,0 +1,18 @@
+-- Verify online-learning-platform:organizations on pg
+
+BEGIN;
+
+SELECT id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at
+FROM organizations
+WHERE FALSE;
+
+SELECT organization_id, user_id, role, joined_at
+FROM organization_members
+WHERE FALSE;
+
+SELECT organization_id FROM courses WHERE FALSE;
+SELECT organization_id FROM cohorts WHERE FALSE;
+
+SELECT current_organization_id();
+
+ROLLBACK;
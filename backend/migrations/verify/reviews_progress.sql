@@ -0,0 +1,16 @@
+-- Verify online-learning-platform:reviews_progress on pg
+
+BEGIN;
+
+SELECT user_id, lesson_id, course_id, completed_at FROM lesson_completions WHERE FALSE;
+SELECT completed_at FROM enrollments WHERE FALSE;
+
+SELECT id, course_id, user_id, rating, body, hidden, moderated_by, reply_body, replied_by, replied_at, created_at, updated_at
+FROM course_reviews
+WHERE FALSE;
+
+SELECT review_id, user_id, reason, created_at FROM course_review_reports WHERE FALSE;
+SELECT rating_count, rating_sum, rating_updated_at FROM courses WHERE FALSE;
+SELECT has_function_privilege('refresh_course_rating()', 'execute');
+
+ROLLBACK;
@@ -0,0 +1,11 @@
+-- Verify online-learning-platform:staff_roles on pg
+
+BEGIN;
+
+SELECT course_id, user_id, role, added_at FROM course_staff WHERE FALSE;
+
+SELECT id, course_id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at
+FROM course_staff_invitations
+WHERE FALSE;
+
+ROLLBACK;
@@ -0,0 +1,13 @@
+-- Verify online-learning-platform:tenant_isolation on pg
+
+BEGIN;
+
+SELECT has_function_privilege('course_in_current_organization(integer)', 'execute');
+
+SELECT 1 / COUNT(*) FROM pg_policies WHERE tablename = 'enrollments' AND policyname = 'enrollments_tenant_isolation';
+SELECT 1 / COUNT(*) FROM pg_policies WHERE tablename = 'point_events' AND policyname = 'point_events_tenant_isolation';
+SELECT 1 / COUNT(*) FROM pg_roles WHERE rolname = 'olp_app';
+-- Fermée par défaut : plus de clause « current_organization_id() IS NULL ».
+SELECT 1 / (1 - COUNT(*)) FROM pg_policies WHERE tablename = 'courses' AND qual LIKE '%IS NULL%';
+
+ROLLBACK;
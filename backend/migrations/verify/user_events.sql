@@ -0,0 +1,8 @@
+-- Verify online-learning-platform:user_events on pg
+
+BEGIN;
+
+SELECT id, user_id, type, payload, created_at FROM user_events WHERE FALSE;
+SELECT has_function_privilege('notify_user_event()', 'execute');
+
+ROLLBACK;
@@ -0,0 +1,7 @@
+-- Verify online-learning-platform:users_table on pg
+
+BEGIN;
+
+SELECT id, name, email, password, role, created_at FROM users WHERE FALSE;
+
+ROLLBACK;
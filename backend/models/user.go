@@ -1,13 +1,26 @@
 package models
 
 import (
-	"gorm.io/gorm"
+	"time"
+
+	"online-learning-platform-backend/internal/db"
 )
 
+// User est la représentation publique d'une ligne de la table users. Elle est construite à partir
+// du type généré par sqlc (FromDB) pour ne pas diverger du schéma, sans jamais exposer le hash du
+// mot de passe.
 type User struct {
-	gorm.Model
-	Name     string `json:"name"`
-	Email    string `json:"email" gorm:"uniqueIndex"`
-	Password string `json:"-"`
-	Role     string `json:"role"`
+	ID        int32      `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func FromDB(u db.User) User {
+	user := User{ID: u.ID, Name: u.Name, Email: u.Email, Role: u.Role}
+	if u.CreatedAt.Valid {
+		user.CreatedAt = &u.CreatedAt.Time
+	}
+	return user
 }
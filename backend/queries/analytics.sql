@@ -0,0 +1,121 @@
+-- name: RecordLessonActivity :execrows
+INSERT INTO lesson_activity (user_id, course_id, lesson_id, seconds)
+SELECT $1, $2, $3, LEAST($4, COALESCE(FLOOR(EXTRACT(EPOCH FROM NOW() - last.recorded_at))::int, $4))
+FROM (SELECT MAX(recorded_at) AS recorded_at FROM lesson_activity WHERE user_id = $1 AND lesson_id = $3) last
+WHERE last.recorded_at IS NULL
+   OR last.recorded_at <= NOW() - make_interval(secs => GREATEST($4 - $5, 1));
+
+-- name: GetAnalyticsWatermark :one
+SELECT processed_until
+FROM analytics_rollups
+WHERE name = $1;
+
+-- name: SetAnalyticsWatermark :exec
+INSERT INTO analytics_rollups (name, processed_until)
+VALUES ($1, $2)
+ON CONFLICT (name) DO UPDATE SET processed_until = EXCLUDED.processed_until;
+
+-- name: RefreshCourseDailyStats :execrows
+WITH activity AS (
+    SELECT course_id, user_id, completed_at AS at FROM lesson_completions
+    UNION ALL
+    SELECT course_id, user_id, recorded_at FROM lesson_activity
+), dirty AS (
+    SELECT course_id, enrolled_at::date AS day FROM enrollments WHERE enrolled_at >= $1
+    UNION
+    SELECT course_id, completed_at::date FROM enrollments WHERE completed_at >= $1
+    UNION
+    SELECT course_id, at::date FROM activity WHERE at >= $1
+)
+INSERT INTO course_daily_stats (course_id, day, enrollments, completions, active_learners)
+SELECT d.course_id, d.day,
+       (SELECT COUNT(*) FROM enrollments e
+        WHERE e.course_id = d.course_id AND e.enrolled_at >= d.day AND e.enrolled_at < d.day + 1),
+       (SELECT COUNT(*) FROM enrollments e
+        WHERE e.course_id = d.course_id AND e.completed_at >= d.day AND e.completed_at < d.day + 1),
+       (SELECT COUNT(DISTINCT a.user_id) FROM activity a
+        WHERE a.course_id = d.course_id AND a.at >= d.day AND a.at < d.day + 1)
+FROM dirty d
+ON CONFLICT (course_id, day) DO UPDATE
+SET enrollments = EXCLUDED.enrollments, completions = EXCLUDED.completions, active_learners = EXCLUDED.active_learners;
+
+-- name: RefreshLessonStats :execrows
+WITH dirty AS (
+    SELECT lesson_id FROM lesson_completions WHERE completed_at >= $1
+    UNION
+    SELECT lesson_id FROM lesson_activity WHERE recorded_at >= $1
+    UNION
+    -- Désinscriptions : un apprenant encore agrégé sans inscription (avant PruneLearnerStats).
+    SELECT l.id FROM lessons l
+    JOIN learner_stats ls ON ls.course_id = l.course_id
+    WHERE NOT EXISTS (SELECT 1 FROM enrollments e WHERE e.course_id = ls.course_id AND e.user_id = ls.user_id)
+)
+INSERT INTO lesson_stats (lesson_id, course_id, completions, learners, time_spent_seconds, updated_at)
+SELECT l.id, l.course_id,
+       (SELECT COUNT(*) FROM lesson_completions lc
+        JOIN enrollments e ON e.user_id = lc.user_id AND e.course_id = lc.course_id
+        WHERE lc.lesson_id = l.id),
+       (SELECT COUNT(DISTINCT la.user_id) FROM lesson_activity la WHERE la.lesson_id = l.id),
+       (SELECT COALESCE(SUM(la.seconds), 0) FROM lesson_activity la WHERE la.lesson_id = l.id),
+       NOW()
+FROM lessons l
+JOIN dirty d ON d.lesson_id = l.id
+ON CONFLICT (lesson_id) DO UPDATE
+SET completions = EXCLUDED.completions, learners = EXCLUDED.learners,
+    time_spent_seconds = EXCLUDED.time_spent_seconds, updated_at = NOW();
+
+-- name: PruneLearnerStats :execrows
+DELETE FROM learner_stats ls
+WHERE NOT EXISTS (SELECT 1 FROM enrollments e WHERE e.course_id = ls.course_id AND e.user_id = ls.user_id);
+
+-- name: RefreshLearnerStats :execrows
+WITH dirty AS (
+    SELECT course_id, user_id FROM enrollments WHERE enrolled_at >= $1 OR completed_at >= $1
+    UNION
+    SELECT course_id, user_id FROM lesson_completions WHERE completed_at >= $1
+    UNION
+    SELECT course_id, user_id FROM lesson_activity WHERE recorded_at >= $1
+)
+INSERT INTO learner_stats (course_id, user_id, enrolled_at, completed_at, lessons_completed, time_spent_seconds, last_active_at, updated_at)
+SELECT e.course_id, e.user_id, e.enrolled_at, e.completed_at,
+       (SELECT COUNT(*) FROM lesson_completions lc WHERE lc.course_id = e.course_id AND lc.user_id = e.user_id),
+       (SELECT COALESCE(SUM(la.seconds), 0) FROM lesson_activity la WHERE la.course_id = e.course_id AND la.user_id = e.user_id),
+       GREATEST(
+           (SELECT MAX(lc.completed_at) FROM lesson_completions lc WHERE lc.course_id = e.course_id AND lc.user_id = e.user_id),
+           (SELECT MAX(la.recorded_at) FROM lesson_activity la WHERE la.course_id = e.course_id AND la.user_id = e.user_id)
+       ),
+       NOW()
+FROM dirty d
+JOIN enrollments e ON e.course_id = d.course_id AND e.user_id = d.user_id
+ON CONFLICT (course_id, user_id) DO UPDATE
+SET completed_at = EXCLUDED.completed_at, lessons_completed = EXCLUDED.lessons_completed,
+    time_spent_seconds = EXCLUDED.time_spent_seconds, last_active_at = EXCLUDED.last_active_at, updated_at = NOW();
+
+-- name: ListCourseDailyStats :many
+SELECT course_id, day, enrollments, completions, active_learners
+FROM course_daily_stats
+WHERE course_id = $1 AND day >= $2
+ORDER BY day;
+
+-- name: GetCourseAnalyticsSummary :one
+SELECT COUNT(*) AS enrolled,
+       COUNT(completed_at) AS completed,
+       COUNT(*) FILTER (WHERE last_active_at >= sqlc.arg(week_start)) AS active_week,
+       COUNT(*) FILTER (WHERE last_active_at >= sqlc.arg(month_start)) AS active_month,
+       COALESCE(SUM(lessons_completed), 0)::bigint AS lessons_completed,
+       COALESCE(SUM(time_spent_seconds), 0)::bigint AS time_spent_seconds
+FROM learner_stats
+WHERE course_id = sqlc.arg(course_id);
+
+-- name: ListLessonStats :many
+SELECT lesson_id, course_id, completions, learners, time_spent_seconds, updated_at
+FROM lesson_stats
+WHERE course_id = $1;
+
+-- name: ListAtRiskLearners :many
+SELECT ls.user_id, u.name, u.email, ls.enrolled_at, ls.lessons_completed, ls.time_spent_seconds, ls.last_active_at
+FROM learner_stats ls
+JOIN users u ON u.id = ls.user_id
+WHERE ls.course_id = $1 AND ls.completed_at IS NULL AND COALESCE(ls.last_active_at, ls.enrolled_at) < $2
+ORDER BY COALESCE(ls.last_active_at, ls.enrolled_at), ls.user_id
+LIMIT $3;
@@ -0,0 +1,78 @@
+-- name: CreateAnnouncement :one
+INSERT INTO announcements (course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at;
+
+-- name: GetAnnouncement :one
+SELECT id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at
+FROM announcements
+WHERE id = $1;
+
+-- name: UpdateAnnouncement :one
+UPDATE announcements
+SET cohort_id = $2, title = $3, body = $4, body_html = $5, send_email = $6, publish_at = $7, updated_at = NOW()
+WHERE id = $1 AND published_at IS NULL
+RETURNING id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at;
+
+-- name: UpdatePublishedAnnouncement :one
+UPDATE announcements
+SET title = $2, body = $3, body_html = $4, updated_at = NOW()
+WHERE id = $1 AND published_at IS NOT NULL
+RETURNING id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at;
+
+-- name: DeleteAnnouncement :exec
+DELETE FROM announcements
+WHERE id = $1;
+
+-- name: ListCourseAnnouncementsForStaff :many
+SELECT a.id, a.course_id, a.cohort_id, a.author_id, a.title, a.body, a.body_html, a.send_email, a.publish_at, a.published_at, a.created_at, a.updated_at,
+       (SELECT COUNT(*) FROM announcement_reads r WHERE r.announcement_id = a.id) AS read_count,
+       (SELECT COUNT(*) FROM enrollments e
+        WHERE e.course_id = a.course_id AND (a.cohort_id IS NULL OR e.cohort_id = a.cohort_id)) AS recipient_count
+FROM announcements a
+WHERE a.course_id = $1
+ORDER BY a.publish_at DESC, a.id DESC;
+
+-- name: ListCourseAnnouncementsForStudent :many
+SELECT a.id, a.course_id, a.cohort_id, a.author_id, a.title, a.body, a.body_html, a.send_email, a.publish_at, a.published_at, a.created_at, a.updated_at, r.read_at
+FROM announcements a
+LEFT JOIN announcement_reads r ON r.announcement_id = a.id AND r.user_id = $2
+WHERE a.course_id = $1 AND a.published_at IS NOT NULL
+  AND (a.cohort_id IS NULL OR a.cohort_id = $3)
+ORDER BY a.publish_at DESC, a.id DESC;
+
+-- name: MarkAnnouncementRead :exec
+INSERT INTO announcement_reads (announcement_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (announcement_id, user_id) DO NOTHING;
+
+-- name: ListAnnouncementReads :many
+SELECT r.user_id, u.name, u.email, r.read_at
+FROM announcement_reads r
+JOIN users u ON u.id = r.user_id
+WHERE r.announcement_id = $1
+ORDER BY r.read_at;
+
+-- name: ListAnnouncementRecipients :many
+SELECT user_id
+FROM enrollments
+WHERE course_id = $1 AND ($2::int = 0 OR cohort_id = $2)
+ORDER BY user_id;
+
+-- name: MarkAnnouncementPublished :one
+UPDATE announcements
+SET published_at = NOW()
+WHERE id = $1 AND published_at IS NULL
+RETURNING id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at;
+
+-- name: ClaimDueAnnouncement :one
+UPDATE announcements
+SET published_at = NOW()
+WHERE id = (
+    SELECT id FROM announcements
+    WHERE published_at IS NULL AND publish_at <= NOW()
+    ORDER BY publish_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, course_id, cohort_id, author_id, title, body, body_html, send_email, publish_at, published_at, created_at, updated_at;
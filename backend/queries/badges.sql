@@ -0,0 +1,58 @@
+-- name: CreateBadgeClass :one
+INSERT INTO badge_classes (organization_id, course_id, name, description, criteria, image_url, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, organization_id, course_id, name, description, criteria, image_url, active, created_by, created_at, updated_at;
+
+-- name: GetBadgeClass :one
+SELECT id, organization_id, course_id, name, description, criteria, image_url, active, created_by, created_at, updated_at
+FROM badge_classes
+WHERE id = $1;
+
+-- name: ListBadgeClasses :many
+SELECT id, organization_id, course_id, name, description, criteria, image_url, active, created_by, created_at, updated_at
+FROM badge_classes
+WHERE organization_id = $1
+ORDER BY name, id;
+
+-- name: ListActiveCourseBadgeClasses :many
+SELECT id, organization_id, course_id, name, description, criteria, image_url, active, created_by, created_at, updated_at
+FROM badge_classes
+WHERE course_id = $1 AND active
+ORDER BY id;
+
+-- name: UpdateBadgeClass :one
+UPDATE badge_classes
+SET name = $2, description = $3, criteria = $4, image_url = $5, active = $6, updated_at = NOW()
+WHERE id = $1
+RETURNING id, organization_id, course_id, name, description, criteria, image_url, active, created_by, created_at, updated_at;
+
+-- name: ListCourseCompletions :many
+SELECT user_id, completed_at::timestamp AS completed_at
+FROM enrollments
+WHERE course_id = $1 AND completed_at IS NOT NULL
+ORDER BY completed_at;
+
+-- name: CreateBadgeCredential :one
+INSERT INTO badge_credentials (id, badge_class_id, user_id, credential, jwt, key_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (badge_class_id, user_id) DO NOTHING
+RETURNING id, badge_class_id, user_id, credential, jwt, key_id, issued_at, revoked_at, revoked_by, revocation_reason;
+
+-- name: GetBadgeCredential :one
+SELECT id, badge_class_id, user_id, credential, jwt, key_id, issued_at, revoked_at, revoked_by, revocation_reason
+FROM badge_credentials
+WHERE id = $1;
+
+-- name: ListUserBadgeCredentials :many
+SELECT bc.id, bc.badge_class_id, bc.jwt, bc.issued_at, bc.revoked_at,
+       b.name AS badge_name, b.description AS badge_description, b.image_url AS badge_image_url, b.course_id
+FROM badge_credentials bc
+JOIN badge_classes b ON b.id = bc.badge_class_id
+WHERE bc.user_id = $1
+ORDER BY bc.issued_at DESC;
+
+-- name: RevokeBadgeCredential :one
+UPDATE badge_credentials
+SET revoked_at = NOW(), revoked_by = $2, revocation_reason = $3
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING id, badge_class_id, user_id, credential, jwt, key_id, issued_at, revoked_at, revoked_by, revocation_reason;
@@ -0,0 +1,63 @@
+-- name: CreateCertificate :one
+INSERT INTO certificates (id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at)
+SELECT $1, u.id, c.id, u.name, c.title,
+       COALESCE((SELECT o.name FROM course_staff s JOIN users o ON o.id = s.user_id
+                 WHERE s.course_id = c.id AND s.role = 'owner' LIMIT 1), ''),
+       $4
+FROM users u, courses c
+WHERE u.id = $2 AND c.id = $3
+ON CONFLICT (user_id, course_id) DO NOTHING
+RETURNING id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status, pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at, manifest, key_id;
+
+-- name: GetCertificate :one
+SELECT id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status, pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at, manifest, key_id
+FROM certificates
+WHERE id = $1;
+
+-- name: ListUserCertificates :many
+SELECT id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status, pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at, manifest, key_id
+FROM certificates
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListCourseCertificates :many
+SELECT id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status, pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at, manifest, key_id
+FROM certificates
+WHERE course_id = $1
+ORDER BY created_at DESC;
+
+-- name: SaveCertificateFile :exec
+INSERT INTO certificate_files (certificate_id, content)
+VALUES ($1, $2)
+ON CONFLICT (certificate_id) DO UPDATE SET content = EXCLUDED.content, created_at = NOW();
+
+-- name: GetCertificateFile :one
+SELECT content FROM certificate_files WHERE certificate_id = $1;
+
+-- name: MarkCertificateIssued :execrows
+UPDATE certificates
+SET status = 'issued', pdf_sha256 = $2, manifest = $3, key_id = $4, issued_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: RevokeCertificate :one
+UPDATE certificates
+SET revoked_at = NOW(), revoked_by = $2, revocation_reason = $3
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING id, user_id, course_id, recipient_name, course_title, instructor_name, completed_at, status, pdf_sha256, issued_at, revoked_at, revoked_by, revocation_reason, created_at, manifest, key_id;
+
+-- name: GetCertificateTemplate :one
+SELECT course_id, title, body, signatory_name, signatory_title, accent_color, updated_by, updated_at
+FROM certificate_templates
+WHERE course_id = $1;
+
+-- name: UpsertCertificateTemplate :one
+INSERT INTO certificate_templates (course_id, title, body, signatory_name, signatory_title, accent_color, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (course_id) DO UPDATE
+SET title = EXCLUDED.title, body = EXCLUDED.body, signatory_name = EXCLUDED.signatory_name,
+    signatory_title = EXCLUDED.signatory_title, accent_color = EXCLUDED.accent_color,
+    updated_by = EXCLUDED.updated_by, updated_at = NOW()
+RETURNING course_id, title, body, signatory_name, signatory_title, accent_color, updated_by, updated_at;
+
+-- name: DeleteCertificateTemplate :execrows
+DELETE FROM certificate_templates WHERE course_id = $1;
@@ -0,0 +1,43 @@
+-- name: CreateCohort :one
+INSERT INTO cohorts (course_id, name, start_date, end_date, instructor_id, enrollment_code, organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, (SELECT organization_id FROM courses WHERE id = $1))
+RETURNING id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at, organization_id;
+
+-- name: GetCohort :one
+SELECT id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at, organization_id
+FROM cohorts
+WHERE id = $1;
+
+-- name: GetCohortByCode :one
+SELECT id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at, organization_id
+FROM cohorts
+WHERE enrollment_code = $1 AND organization_id = $2;
+
+-- name: ListCohortsByCourse :many
+SELECT id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at, organization_id
+FROM cohorts
+WHERE course_id = $1
+ORDER BY start_date;
+
+-- name: UpdateCohort :one
+UPDATE cohorts
+SET name = $2, start_date = $3, end_date = $4, instructor_id = $5
+WHERE id = $1
+RETURNING id, course_id, name, start_date, end_date, instructor_id, enrollment_code, created_at, organization_id;
+
+-- name: EnrollInCohort :one
+INSERT INTO enrollments (user_id, course_id, cohort_id, started_revision_id)
+VALUES ($1, $2, $3, (SELECT published_revision_id FROM courses WHERE id = $2))
+ON CONFLICT (user_id, course_id) DO UPDATE SET cohort_id = EXCLUDED.cohort_id
+RETURNING id, user_id, course_id, cohort_id, enrolled_at, started_revision_id, pinned_revision_id, completed_at;
+
+-- name: ListCohortRoster :many
+SELECT u.id, u.name, u.email, e.enrolled_at
+FROM enrollments e
+JOIN users u ON u.id = e.user_id
+WHERE e.cohort_id = $1
+ORDER BY u.name;
+
+-- name: RemoveFromCohort :execrows
+DELETE FROM enrollments
+WHERE cohort_id = $1 AND user_id = $2;
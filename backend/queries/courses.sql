@@ -1,9 +1,35 @@
 -- name: ListCourses :many
-SELECT id, title, description, created_at, updated_at, author_id
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
 FROM courses
+WHERE organization_id = $1
 ORDER BY created_at DESC;
 
 -- name: CreateCourse :one
-INSERT INTO courses (title, description, author_id)
-VALUES ($1, $2, $3)
-RETURNING id, title, description, created_at, updated_at, author_id;
+INSERT INTO courses (title, description, author_id, organization_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id;
+
+-- name: GetCourse :one
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
+FROM courses
+WHERE id = $1;
+
+-- name: PublishCourseRevision :one
+UPDATE courses
+SET title = $2, description = $3, published_revision_id = $4, version = version + 1, updated_at = NOW()
+WHERE id = $1
+RETURNING id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id;
+
+-- name: GetCatalogVersion :one
+SELECT COUNT(*) AS total,
+       COALESCE(SUM(version), 0)::bigint AS version_sum,
+       COALESCE(MAX(id), 0)::int AS max_id,
+       COALESCE(EXTRACT(EPOCH FROM MAX(rating_updated_at)), 0)::bigint AS ratings_stamp
+FROM courses
+WHERE organization_id = $1;
+
+-- name: GetCourseForUpdate :one
+SELECT id, title, description, created_at, updated_at, author_id, published_revision_id, version, rating_count, rating_sum, rating_updated_at, organization_id
+FROM courses
+WHERE id = $1
+FOR UPDATE;
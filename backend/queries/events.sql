@@ -0,0 +1,30 @@
+-- name: CreateUserEvent :exec
+INSERT INTO user_events (user_id, type, payload)
+VALUES ($1, $2, $3);
+
+-- name: GetUserEvent :one
+SELECT id, user_id, type, payload, created_at
+FROM user_events
+WHERE id = $1;
+
+-- name: ListUserEventsAfter :many
+SELECT id, user_id, type, payload, created_at
+FROM user_events
+WHERE user_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- name: ListAllUserEventsAfter :many
+SELECT id, user_id, type, payload, created_at
+FROM user_events
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: GetLatestUserEventID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS id
+FROM user_events;
+
+-- name: DeleteUserEventsBefore :execrows
+DELETE FROM user_events
+WHERE created_at < $1;
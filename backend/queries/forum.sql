@@ -0,0 +1,96 @@
+-- name: CreateForumThread :one
+INSERT INTO forum_threads (course_id, lesson_id, cohort_id, author_id, title, body, body_html)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, course_id, lesson_id, cohort_id, author_id, title, body, body_html, pinned, locked, hidden, accepted_post_id, created_at, last_activity_at;
+
+-- name: GetForumThread :one
+SELECT id, course_id, lesson_id, cohort_id, author_id, title, body, body_html, pinned, locked, hidden, accepted_post_id, created_at, last_activity_at
+FROM forum_threads
+WHERE id = $1;
+
+-- name: ListForumThreads :many
+SELECT t.id, t.lesson_id, t.cohort_id, t.author_id, u.name AS author_name, t.title, t.pinned, t.locked, t.hidden,
+       t.accepted_post_id, t.created_at, t.last_activity_at,
+       (SELECT COUNT(*) FROM forum_posts p WHERE p.thread_id = t.id AND p.deleted_at IS NULL) AS reply_count
+FROM forum_threads t
+JOIN users u ON u.id = t.author_id
+WHERE t.course_id = sqlc.arg(course_id)
+  AND (sqlc.arg(lesson_id)::int = 0 OR t.lesson_id = sqlc.arg(lesson_id))
+  AND (sqlc.arg(cohort_id)::int = 0 OR t.cohort_id IS NULL OR t.cohort_id = sqlc.arg(cohort_id))
+  AND (NOT t.hidden OR sqlc.arg(include_hidden)::bool)
+ORDER BY t.pinned DESC, t.last_activity_at DESC;
+
+-- name: UpdateForumThreadFlags :one
+UPDATE forum_threads
+SET pinned = $2, locked = $3, hidden = $4
+WHERE id = $1
+RETURNING id, course_id, lesson_id, cohort_id, author_id, title, body, body_html, pinned, locked, hidden, accepted_post_id, created_at, last_activity_at;
+
+-- name: SetForumThreadAnswer :one
+UPDATE forum_threads
+SET accepted_post_id = $2
+WHERE id = $1
+RETURNING id, course_id, lesson_id, cohort_id, author_id, title, body, body_html, pinned, locked, hidden, accepted_post_id, created_at, last_activity_at;
+
+-- name: TouchForumThread :exec
+UPDATE forum_threads SET last_activity_at = NOW() WHERE id = $1;
+
+-- name: DeleteForumThread :exec
+DELETE FROM forum_threads WHERE id = $1;
+
+-- name: CreateForumPost :one
+INSERT INTO forum_posts (thread_id, parent_id, author_id, body, body_html)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, thread_id, parent_id, author_id, body, body_html, hidden, deleted_at, created_at, updated_at;
+
+-- name: GetForumPost :one
+SELECT id, thread_id, parent_id, author_id, body, body_html, hidden, deleted_at, created_at, updated_at
+FROM forum_posts
+WHERE id = $1;
+
+-- name: ListForumPosts :many
+SELECT p.id, p.parent_id, p.author_id, u.name AS author_name, p.body, p.body_html, p.hidden, p.deleted_at,
+       p.created_at, p.updated_at,
+       (SELECT COUNT(*) FROM forum_post_votes v WHERE v.post_id = p.id) AS upvotes,
+       EXISTS (SELECT 1 FROM forum_post_votes v WHERE v.post_id = p.id AND v.user_id = $2) AS voted
+FROM forum_posts p
+JOIN users u ON u.id = p.author_id
+WHERE p.thread_id = $1
+ORDER BY p.created_at;
+
+-- name: SetForumPostHidden :one
+UPDATE forum_posts
+SET hidden = $2
+WHERE id = $1
+RETURNING id, thread_id, parent_id, author_id, body, body_html, hidden, deleted_at, created_at, updated_at;
+
+-- name: SoftDeleteForumPost :exec
+UPDATE forum_posts
+SET body = '', body_html = '', deleted_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpvoteForumPost :exec
+INSERT INTO forum_post_votes (post_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveForumPostUpvote :exec
+DELETE FROM forum_post_votes WHERE post_id = $1 AND user_id = $2;
+
+-- name: BanFromForum :one
+INSERT INTO forum_bans (course_id, user_id, banned_by, reason)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (course_id, user_id) DO UPDATE SET banned_by = EXCLUDED.banned_by, reason = EXCLUDED.reason
+RETURNING course_id, user_id, banned_by, reason, created_at;
+
+-- name: UnbanFromForum :execrows
+DELETE FROM forum_bans WHERE course_id = $1 AND user_id = $2;
+
+-- name: IsBannedFromForum :one
+SELECT EXISTS (SELECT 1 FROM forum_bans WHERE course_id = $1 AND user_id = $2);
+
+-- name: ListForumBans :many
+SELECT course_id, user_id, banned_by, reason, created_at
+FROM forum_bans
+WHERE course_id = $1
+ORDER BY created_at DESC;
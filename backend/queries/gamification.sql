@@ -0,0 +1,90 @@
+-- name: RecordPointEvent :one
+INSERT INTO point_events (user_id, course_id, event, source_key, occurred_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (source_key) DO NOTHING
+RETURNING id;
+
+-- name: GetPointEvent :one
+SELECT id, user_id, course_id, event, points, source_key, occurred_at, processed_at, created_at
+FROM point_events
+WHERE id = $1;
+
+-- name: MarkPointEventProcessed :execrows
+UPDATE point_events
+SET points = $2, processed_at = NOW()
+WHERE id = $1 AND processed_at IS NULL;
+
+-- name: CountUserPointEvents :one
+SELECT COUNT(*)
+FROM point_events
+WHERE user_id = $1 AND event = $2 AND processed_at IS NOT NULL;
+
+-- name: EnsureGamificationProfile :exec
+INSERT INTO gamification_profiles (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: GetGamificationProfile :one
+SELECT user_id, points, current_streak, longest_streak, last_active_on, timezone, leaderboard_opt_out, updated_at
+FROM gamification_profiles
+WHERE user_id = $1;
+
+-- name: LockGamificationProfile :one
+SELECT user_id, points, current_streak, longest_streak, last_active_on, timezone, leaderboard_opt_out, updated_at
+FROM gamification_profiles
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: UpdateGamificationProgress :exec
+UPDATE gamification_profiles
+SET points = $2, current_streak = $3, longest_streak = $4, last_active_on = $5, updated_at = NOW()
+WHERE user_id = $1;
+
+-- name: UpdateGamificationSettings :one
+INSERT INTO gamification_profiles (user_id, timezone, leaderboard_opt_out)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET timezone = EXCLUDED.timezone, leaderboard_opt_out = EXCLUDED.leaderboard_opt_out, updated_at = NOW()
+RETURNING user_id, points, current_streak, longest_streak, last_active_on, timezone, leaderboard_opt_out, updated_at;
+
+-- name: AwardAchievement :execrows
+INSERT INTO user_achievements (user_id, achievement_key)
+VALUES ($1, $2)
+ON CONFLICT (user_id, achievement_key) DO NOTHING;
+
+-- name: ListUserAchievements :many
+SELECT user_id, achievement_key, earned_at
+FROM user_achievements
+WHERE user_id = $1
+ORDER BY earned_at;
+
+-- name: CourseLeaderboard :many
+SELECT u.id AS user_id, u.name, SUM(pe.points)::bigint AS points
+FROM point_events pe
+JOIN users u ON u.id = pe.user_id
+LEFT JOIN gamification_profiles gp ON gp.user_id = pe.user_id
+WHERE pe.course_id = $1 AND pe.occurred_at >= $2 AND NOT COALESCE(gp.leaderboard_opt_out, FALSE)
+GROUP BY u.id, u.name
+ORDER BY points DESC, u.id
+LIMIT $3;
+
+-- name: OrganizationLeaderboard :many
+SELECT u.id AS user_id, u.name, SUM(pe.points)::bigint AS points
+FROM point_events pe
+JOIN courses c ON c.id = pe.course_id
+JOIN users u ON u.id = pe.user_id
+LEFT JOIN gamification_profiles gp ON gp.user_id = pe.user_id
+WHERE c.organization_id = $1 AND pe.occurred_at >= $2 AND NOT COALESCE(gp.leaderboard_opt_out, FALSE)
+GROUP BY u.id, u.name
+ORDER BY points DESC, u.id
+LIMIT $3;
+
+-- name: ListRecentAchievements :many
+SELECT ua.user_id, u.name, ua.achievement_key, ua.earned_at
+FROM user_achievements ua
+JOIN users u ON u.id = ua.user_id
+JOIN organization_members m ON m.user_id = ua.user_id AND m.organization_id = $1
+LEFT JOIN gamification_profiles gp ON gp.user_id = ua.user_id
+WHERE NOT COALESCE(gp.leaderboard_opt_out, FALSE)
+ORDER BY ua.earned_at DESC
+LIMIT $2;
@@ -0,0 +1,107 @@
+-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, priority, max_attempts, run_at, unique_key)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('queued', 'running') DO NOTHING
+RETURNING id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until, last_error, unique_key, created_at, updated_at, finished_at;
+
+-- name: ClaimJob :one
+WITH abandoned AS (
+    -- Bail expiré au dernier essai permis : le worker est mort en route, la tâche part en dead-letter.
+    UPDATE jobs
+    SET status = 'dead', locked_by = NULL, locked_until = NULL,
+        last_error = 'bail expiré sans fin de traitement', finished_at = NOW(), updated_at = NOW()
+    WHERE status = 'running' AND locked_until < NOW() AND attempts >= max_attempts
+)
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, locked_by = $1, locked_until = $2, updated_at = NOW()
+WHERE id = (
+    SELECT id FROM jobs
+    WHERE (status = 'queued' AND run_at <= NOW())
+       OR (status = 'running' AND locked_until < NOW() AND attempts < max_attempts)
+    ORDER BY priority DESC, run_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until, last_error, unique_key, created_at, updated_at, finished_at;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded', locked_by = NULL, locked_until = NULL,
+    last_error = NULL, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND locked_by = $2;
+
+-- name: RetryJobLater :exec
+UPDATE jobs
+SET status = 'queued', locked_by = NULL, locked_until = NULL,
+    last_error = $3, run_at = $4, updated_at = NOW()
+WHERE id = $1 AND locked_by = $2;
+
+-- name: KillJob :exec
+UPDATE jobs
+SET status = 'dead', locked_by = NULL, locked_until = NULL,
+    last_error = $3, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND locked_by = $2;
+
+-- name: GetJob :one
+SELECT id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until, last_error, unique_key, created_at, updated_at, finished_at
+FROM jobs
+WHERE id = $1;
+
+-- name: ListJobs :many
+SELECT id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until, last_error, unique_key, created_at, updated_at, finished_at
+FROM jobs
+WHERE ($1::text = '' OR status = $1) AND ($2::text = '' OR kind = $2)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountJobsByStatus :many
+SELECT kind, status, COUNT(*) AS count
+FROM jobs
+GROUP BY kind, status
+ORDER BY kind, status;
+
+-- name: RequeueDeadJob :one
+UPDATE jobs
+SET status = 'queued', attempts = 0, run_at = NOW(), last_error = NULL, finished_at = NULL, updated_at = NOW()
+WHERE id = $1 AND status = 'dead'
+RETURNING id, kind, payload, status, priority, attempts, max_attempts, run_at, locked_by, locked_until, last_error, unique_key, created_at, updated_at, finished_at;
+
+-- name: DeleteJob :execrows
+DELETE FROM jobs
+WHERE id = $1 AND status IN ('dead', 'succeeded');
+
+-- name: PurgeFinishedJobs :execrows
+DELETE FROM jobs
+WHERE status = 'succeeded' AND finished_at < $1;
+
+-- name: UpsertJobSchedule :exec
+INSERT INTO job_schedules (name, cron_expr, kind, payload, next_run_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name) DO UPDATE
+SET kind = EXCLUDED.kind, payload = EXCLUDED.payload,
+    next_run_at = CASE WHEN job_schedules.cron_expr = EXCLUDED.cron_expr
+                       THEN job_schedules.next_run_at ELSE EXCLUDED.next_run_at END,
+    cron_expr = EXCLUDED.cron_expr;
+
+-- name: ClaimDueJobSchedule :one
+SELECT name, cron_expr, kind, payload, enabled, next_run_at, last_run_at
+FROM job_schedules
+WHERE enabled AND next_run_at <= NOW()
+ORDER BY next_run_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: AdvanceJobSchedule :exec
+UPDATE job_schedules
+SET last_run_at = NOW(), next_run_at = $2
+WHERE name = $1;
+
+-- name: ListJobSchedules :many
+SELECT name, cron_expr, kind, payload, enabled, next_run_at, last_run_at
+FROM job_schedules
+ORDER BY name;
+
+-- name: SetJobScheduleEnabled :execrows
+UPDATE job_schedules
+SET enabled = $2
+WHERE name = $1;
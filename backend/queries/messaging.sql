@@ -0,0 +1,124 @@
+-- name: CreateConversation :one
+INSERT INTO conversations (subject, is_group, created_by)
+VALUES ($1, $2, $3)
+RETURNING id, subject, is_group, created_by, created_at, last_message_at;
+
+-- name: GetConversation :one
+SELECT id, subject, is_group, created_by, created_at, last_message_at
+FROM conversations
+WHERE id = $1;
+
+-- name: FindDirectConversation :one
+SELECT c.id
+FROM conversations c
+JOIN conversation_participants a ON a.conversation_id = c.id AND a.user_id = $1
+JOIN conversation_participants b ON b.conversation_id = c.id AND b.user_id = $2
+WHERE NOT c.is_group
+ORDER BY c.id
+LIMIT 1;
+
+-- name: TouchConversation :exec
+UPDATE conversations
+SET last_message_at = NOW()
+WHERE id = $1;
+
+-- name: AddConversationParticipant :exec
+INSERT INTO conversation_participants (conversation_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (conversation_id, user_id) DO NOTHING;
+
+-- name: GetConversationParticipant :one
+SELECT conversation_id, user_id, joined_at, last_read_message_id, last_read_at
+FROM conversation_participants
+WHERE conversation_id = $1 AND user_id = $2;
+
+-- name: ListConversationParticipants :many
+SELECT p.user_id, u.name, u.role, p.joined_at, p.last_read_message_id, p.last_read_at
+FROM conversation_participants p
+JOIN users u ON u.id = p.user_id
+WHERE p.conversation_id = $1
+ORDER BY p.joined_at, p.user_id;
+
+-- name: MarkConversationRead :exec
+UPDATE conversation_participants
+SET last_read_message_id = (SELECT MAX(id) FROM messages WHERE conversation_id = $1),
+    last_read_at = NOW()
+WHERE conversation_id = $1 AND user_id = $2;
+
+-- name: ListInbox :many
+SELECT c.id, c.subject, c.is_group, c.last_message_at,
+       lm.id AS last_message_id, lm.sender_id AS last_sender_id, COALESCE(lm.body, '') AS last_message_body,
+       (SELECT COUNT(*) FROM messages m
+        WHERE m.conversation_id = c.id AND m.sender_id <> p.user_id
+          AND m.id > COALESCE(p.last_read_message_id, 0)) AS unread_count,
+       (SELECT string_agg(u.name, ', ' ORDER BY u.name)
+        FROM conversation_participants o JOIN users u ON u.id = o.user_id
+        WHERE o.conversation_id = c.id AND o.user_id <> p.user_id)::text AS participant_names
+FROM conversation_participants p
+JOIN conversations c ON c.id = p.conversation_id
+LEFT JOIN LATERAL (
+    SELECT id, sender_id, body FROM messages
+    WHERE conversation_id = c.id
+    ORDER BY id DESC
+    LIMIT 1
+) lm ON TRUE
+WHERE p.user_id = $1
+ORDER BY c.last_message_at DESC, c.id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountInbox :one
+SELECT COUNT(*)
+FROM conversation_participants
+WHERE user_id = $1;
+
+-- name: CountUnreadMessages :one
+SELECT COUNT(*)
+FROM conversation_participants p
+JOIN messages m ON m.conversation_id = p.conversation_id
+WHERE p.user_id = $1 AND m.sender_id <> p.user_id
+  AND m.id > COALESCE(p.last_read_message_id, 0);
+
+-- name: CreateMessage :one
+INSERT INTO messages (conversation_id, sender_id, body)
+VALUES ($1, $2, $3)
+RETURNING id, conversation_id, sender_id, body, created_at;
+
+-- name: ListMessages :many
+SELECT id, conversation_id, sender_id, body, created_at
+FROM messages
+WHERE conversation_id = $1 AND ($2::int = 0 OR id < $2)
+ORDER BY id DESC
+LIMIT $3;
+
+-- name: CreateMessageAttachment :one
+INSERT INTO message_attachments (message_id, filename, content_type, size_bytes, data)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, message_id, filename, content_type, size_bytes, created_at;
+
+-- name: ListMessageAttachments :many
+SELECT a.id, a.message_id, a.filename, a.content_type, a.size_bytes, a.created_at
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE m.conversation_id = $1 AND a.message_id BETWEEN $2 AND $3
+ORDER BY a.id;
+
+-- name: GetMessageAttachment :one
+SELECT a.id, a.message_id, m.conversation_id, a.filename, a.content_type, a.size_bytes, a.data
+FROM message_attachments a
+JOIN messages m ON m.id = a.message_id
+WHERE a.id = $1;
+
+-- name: CanMessageUser :one
+SELECT EXISTS (
+    -- Membre de l'équipe d'un cours : ses inscrits et ses collègues.
+    SELECT 1 FROM course_staff s
+    WHERE s.user_id = $1 AND (
+        EXISTS (SELECT 1 FROM enrollments e WHERE e.course_id = s.course_id AND e.user_id = $2)
+        OR EXISTS (SELECT 1 FROM course_staff s2 WHERE s2.course_id = s.course_id AND s2.user_id = $2)
+    )
+    UNION ALL
+    -- Inscrit : l'équipe des cours suivis.
+    SELECT 1 FROM enrollments e
+    JOIN course_staff s ON s.course_id = e.course_id
+    WHERE e.user_id = $1 AND s.user_id = $2
+);
@@ -0,0 +1,109 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, type, title, body, link, data, in_app, dedupe_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (dedupe_key) DO NOTHING
+RETURNING id, user_id, type, title, body, link, data, in_app, dedupe_key, read_at, created_at;
+
+-- name: ListNotifications :many
+SELECT id, user_id, type, title, body, link, data, in_app, dedupe_key, read_at, created_at
+FROM notifications
+WHERE user_id = $1 AND in_app AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountNotifications :one
+SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE read_at IS NULL) AS unread
+FROM notifications
+WHERE user_id = $1 AND in_app;
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: ListNotificationPreferences :many
+SELECT user_id, event_type, in_app, email, webhook, digest, updated_at
+FROM notification_preferences
+WHERE user_id = $1
+ORDER BY event_type;
+
+-- name: GetNotificationPreference :one
+SELECT user_id, event_type, in_app, email, webhook, digest, updated_at
+FROM notification_preferences
+WHERE user_id = $1 AND event_type = $2;
+
+-- name: UpsertNotificationPreference :exec
+INSERT INTO notification_preferences (user_id, event_type, in_app, email, webhook, digest)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, event_type) DO UPDATE
+SET in_app = EXCLUDED.in_app, email = EXCLUDED.email, webhook = EXCLUDED.webhook,
+    digest = EXCLUDED.digest, updated_at = NOW();
+
+-- name: GetNotificationWebhook :one
+SELECT user_id, url, secret, created_at
+FROM notification_webhooks
+WHERE user_id = $1;
+
+-- name: UpsertNotificationWebhook :one
+INSERT INTO notification_webhooks (user_id, url, secret)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET url = EXCLUDED.url, secret = EXCLUDED.secret, created_at = NOW()
+RETURNING user_id, url, secret, created_at;
+
+-- name: DeleteNotificationWebhook :execrows
+DELETE FROM notification_webhooks
+WHERE user_id = $1;
+
+-- name: CreateNotificationDelivery :exec
+INSERT INTO notification_deliveries (notification_id, user_id, channel, digest)
+VALUES ($1, $2, $3, $4);
+
+-- name: ClaimNotificationDeliveries :many
+WITH claimed AS (
+    UPDATE notification_deliveries
+    SET status = 'sending', next_attempt_at = NOW() + INTERVAL '10 minutes'
+    WHERE id IN (
+        SELECT id FROM notification_deliveries
+        WHERE status IN ('pending', 'sending') AND digest = $1 AND next_attempt_at <= NOW()
+        ORDER BY id
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, notification_id, user_id, channel, attempts
+)
+SELECT c.id, c.user_id, c.channel, c.attempts, n.type, n.title, n.body, n.link, n.data, n.created_at, u.email, u.name
+FROM claimed c
+JOIN notifications n ON n.id = c.notification_id
+JOIN users u ON u.id = c.user_id
+ORDER BY c.user_id, c.id;
+
+-- name: MarkNotificationDeliverySent :exec
+UPDATE notification_deliveries
+SET status = 'sent', sent_at = NOW(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkNotificationDeliveryFailed :exec
+UPDATE notification_deliveries
+SET status = CASE WHEN $4::boolean THEN 'failed' ELSE 'pending' END,
+    attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1;
+
+-- name: ListCourseStudentIDs :many
+SELECT user_id
+FROM enrollments
+WHERE course_id = $1
+ORDER BY user_id;
+
+-- name: ListDeadlineReminders :many
+SELECT e.user_id, ch.id AS cohort_id, ch.name AS cohort_name, ch.end_date, co.id AS course_id, co.title AS course_title
+FROM cohorts ch
+JOIN enrollments e ON e.cohort_id = ch.id
+JOIN courses co ON co.id = ch.course_id
+WHERE ch.end_date = CURRENT_DATE + $1::int AND e.completed_at IS NULL
+ORDER BY ch.id, e.user_id;
@@ -0,0 +1,52 @@
+-- name: CreateUserInvitation :one
+INSERT INTO user_invitations (email, role, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at, accepted_by;
+
+-- name: GetUserInvitationByTokenHash :one
+SELECT id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at, accepted_by
+FROM user_invitations
+WHERE token_hash = $1;
+
+-- name: ListPendingUserInvitations :many
+SELECT id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at, accepted_by
+FROM user_invitations
+WHERE accepted_at IS NULL AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+-- name: AcceptUserInvitation :execrows
+UPDATE user_invitations
+SET accepted_at = NOW(), accepted_by = $2
+WHERE id = $1 AND accepted_at IS NULL AND expires_at > NOW();
+
+-- name: DeleteUserInvitation :execrows
+DELETE FROM user_invitations
+WHERE id = $1 AND accepted_at IS NULL;
+
+-- name: DeleteExpiredUserInvitations :execrows
+DELETE FROM user_invitations
+WHERE accepted_at IS NULL AND expires_at < NOW();
+
+-- name: CreateTeacherApplication :one
+INSERT INTO teacher_applications (user_id, motivation)
+VALUES ($1, $2)
+RETURNING id, user_id, motivation, status, reviewed_by, review_note, created_at, reviewed_at;
+
+-- name: ListUserTeacherApplications :many
+SELECT id, user_id, motivation, status, reviewed_by, review_note, created_at, reviewed_at
+FROM teacher_applications
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListTeacherApplicationsByStatus :many
+SELECT a.id, a.user_id, u.name, u.email, a.motivation, a.status, a.reviewed_by, a.review_note, a.created_at, a.reviewed_at
+FROM teacher_applications a
+JOIN users u ON u.id = a.user_id
+WHERE a.status = $1
+ORDER BY a.created_at;
+
+-- name: ReviewTeacherApplication :one
+UPDATE teacher_applications
+SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, user_id, motivation, status, reviewed_by, review_note, created_at, reviewed_at;
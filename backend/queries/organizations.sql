@@ -0,0 +1,62 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (slug, name, logo_url, primary_color, accent_color, support_email)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at;
+
+-- name: GetOrganization :one
+SELECT id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at
+FROM organizations
+WHERE id = $1;
+
+-- name: GetOrganizationBySlug :one
+SELECT id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at
+FROM organizations
+WHERE slug = $1;
+
+-- name: ListOrganizations :many
+SELECT id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at
+FROM organizations
+ORDER BY name;
+
+-- name: UpdateOrganization :one
+UPDATE organizations
+SET slug = $2, name = $3, logo_url = $4, primary_color = $5, accent_color = $6, support_email = $7, updated_at = NOW()
+WHERE id = $1
+RETURNING id, slug, name, logo_url, primary_color, accent_color, support_email, created_at, updated_at;
+
+-- name: AddOrganizationMember :one
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING organization_id, user_id, role, joined_at;
+
+-- name: EnsureOrganizationMember :exec
+INSERT INTO organization_members (organization_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (organization_id, user_id) DO NOTHING;
+
+-- name: GetOrganizationMemberRole :one
+SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT m.user_id, u.name, u.email, m.role, m.joined_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1
+ORDER BY u.name;
+
+-- name: UpdateOrganizationMemberRole :execrows
+UPDATE organization_members
+SET role = $3
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: RemoveOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListUserOrganizations :many
+SELECT o.id, o.slug, o.name, m.role
+FROM organization_members m
+JOIN organizations o ON o.id = m.organization_id
+WHERE m.user_id = $1
+ORDER BY o.name;
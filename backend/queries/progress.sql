@@ -0,0 +1,26 @@
+-- name: GetLesson :one
+SELECT id, course_id, created_at
+FROM lessons
+WHERE id = $1;
+
+-- name: CompleteLesson :exec
+INSERT INTO lesson_completions (user_id, lesson_id, course_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, lesson_id) DO NOTHING;
+
+-- name: GetEnrollmentProgress :one
+SELECT e.id, e.completed_at, COALESCE(e.pinned_revision_id, c.published_revision_id) AS revision_id,
+       (SELECT COUNT(*) FROM lesson_completions lc
+        JOIN course_revisions r ON r.id = COALESCE(e.pinned_revision_id, c.published_revision_id)
+        WHERE lc.user_id = e.user_id AND lc.course_id = e.course_id
+          AND r.lessons @> jsonb_build_array(jsonb_build_object('id', lc.lesson_id))) AS completed_lessons,
+       COALESCE((SELECT jsonb_array_length(r.lessons) FROM course_revisions r
+                 WHERE r.id = COALESCE(e.pinned_revision_id, c.published_revision_id)), 0)::int AS total_lessons
+FROM enrollments e
+JOIN courses c ON c.id = e.course_id
+WHERE e.user_id = $1 AND e.course_id = $2;
+
+-- name: MarkEnrollmentCompleted :exec
+UPDATE enrollments
+SET completed_at = NOW()
+WHERE id = $1 AND completed_at IS NULL;
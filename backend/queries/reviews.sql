@@ -0,0 +1,43 @@
+-- name: UpsertCourseReview :one
+INSERT INTO course_reviews (course_id, user_id, rating, body)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (course_id, user_id) DO UPDATE
+SET rating = EXCLUDED.rating, body = EXCLUDED.body, updated_at = NOW()
+RETURNING id, course_id, user_id, rating, body, hidden, moderated_by, reply_body, replied_by, replied_at, created_at, updated_at;
+
+-- name: GetCourseReview :one
+SELECT id, course_id, user_id, rating, body, hidden, moderated_by, reply_body, replied_by, replied_at, created_at, updated_at
+FROM course_reviews
+WHERE id = $1;
+
+-- name: ListVisibleCourseReviews :many
+SELECT r.id, r.user_id, u.name AS author_name, r.rating, r.body, r.reply_body, r.replied_at, r.created_at, r.updated_at
+FROM course_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.course_id = $1 AND NOT r.hidden
+ORDER BY r.updated_at DESC;
+
+-- name: ReplyToCourseReview :one
+UPDATE course_reviews
+SET reply_body = $2, replied_by = $3, replied_at = NOW()
+WHERE id = $1
+RETURNING id, course_id, user_id, rating, body, hidden, moderated_by, reply_body, replied_by, replied_at, created_at, updated_at;
+
+-- name: ReportCourseReview :exec
+INSERT INTO course_review_reports (review_id, user_id, reason)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id, user_id) DO NOTHING;
+
+-- name: SetCourseReviewHidden :one
+UPDATE course_reviews
+SET hidden = $2, moderated_by = $3
+WHERE id = $1
+RETURNING id, course_id, user_id, rating, body, hidden, moderated_by, reply_body, replied_by, replied_at, created_at, updated_at;
+
+-- name: ListReportedCourseReviews :many
+SELECT r.id, r.course_id, r.user_id, r.rating, r.body, r.hidden,
+       COUNT(rr.user_id) AS report_count, MAX(rr.created_at)::timestamp AS last_reported_at
+FROM course_reviews r
+JOIN course_review_reports rr ON rr.review_id = r.id
+GROUP BY r.id
+ORDER BY report_count DESC, last_reported_at DESC;
@@ -0,0 +1,65 @@
+-- name: CreateLesson :one
+INSERT INTO lessons (course_id)
+VALUES ($1)
+RETURNING id, course_id, created_at;
+
+-- name: ListLessonIDsByCourse :many
+SELECT id FROM lessons WHERE course_id = $1;
+
+-- name: GetCourseDraft :one
+SELECT course_id, title, description, lessons, updated_by, updated_at, version
+FROM course_drafts
+WHERE course_id = $1;
+
+-- name: CreateCourseDraft :one
+INSERT INTO course_drafts (course_id, title, description, lessons, updated_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (course_id) DO NOTHING
+RETURNING course_id, title, description, lessons, updated_by, updated_at, version;
+
+-- name: UpdateCourseDraft :one
+UPDATE course_drafts
+SET title = $2, description = $3, lessons = $4, updated_by = $5, updated_at = NOW(), version = version + 1
+WHERE course_id = $1 AND version = $6
+RETURNING course_id, title, description, lessons, updated_by, updated_at, version;
+
+-- name: DeleteCourseDraft :execrows
+DELETE FROM course_drafts WHERE course_id = $1 AND version = $2;
+
+-- name: CreateCourseRevision :one
+INSERT INTO course_revisions (course_id, number, title, description, lessons, created_by)
+VALUES ($1, (SELECT COALESCE(MAX(number), 0) + 1 FROM course_revisions WHERE course_id = $1), $2, $3, $4, $5)
+RETURNING id, course_id, number, title, description, lessons, created_by, created_at;
+
+-- name: GetCourseRevision :one
+SELECT id, course_id, number, title, description, lessons, created_by, created_at
+FROM course_revisions
+WHERE id = $1;
+
+-- name: GetCourseRevisionByNumber :one
+SELECT id, course_id, number, title, description, lessons, created_by, created_at
+FROM course_revisions
+WHERE course_id = $1 AND number = $2;
+
+-- name: ListCourseRevisions :many
+SELECT id, number, title, created_by, created_at
+FROM course_revisions
+WHERE course_id = $1
+ORDER BY number DESC;
+
+-- name: GetEnrollment :one
+SELECT id, user_id, course_id, cohort_id, enrolled_at, started_revision_id, pinned_revision_id, completed_at
+FROM enrollments
+WHERE user_id = $1 AND course_id = $2;
+
+-- name: PinEnrollment :one
+UPDATE enrollments
+SET pinned_revision_id = started_revision_id
+WHERE user_id = $1 AND course_id = $2
+RETURNING id, user_id, course_id, cohort_id, enrolled_at, started_revision_id, pinned_revision_id, completed_at;
+
+-- name: UnpinEnrollment :one
+UPDATE enrollments
+SET pinned_revision_id = NULL
+WHERE user_id = $1 AND course_id = $2
+RETURNING id, user_id, course_id, cohort_id, enrolled_at, started_revision_id, pinned_revision_id, completed_at;
@@ -0,0 +1,54 @@
+-- name: AddCourseStaff :one
+INSERT INTO course_staff (course_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (course_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING course_id, user_id, role, added_at;
+
+-- name: GetCourseStaffRole :one
+SELECT role FROM course_staff WHERE course_id = $1 AND user_id = $2;
+
+-- name: ListCourseStaff :many
+SELECT s.user_id, u.name, u.email, s.role, s.added_at
+FROM course_staff s
+JOIN users u ON u.id = s.user_id
+WHERE s.course_id = $1
+ORDER BY s.added_at;
+
+-- name: UpdateCourseStaffRole :execrows
+UPDATE course_staff
+SET role = $3
+WHERE course_id = $1 AND user_id = $2;
+
+-- name: RemoveCourseStaff :execrows
+DELETE FROM course_staff
+WHERE course_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: SetCourseAuthor :exec
+UPDATE courses
+SET author_id = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateStaffInvitation :one
+INSERT INTO course_staff_invitations (course_id, email, role, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, course_id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at;
+
+-- name: GetStaffInvitationByTokenHash :one
+SELECT id, course_id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at
+FROM course_staff_invitations
+WHERE token_hash = $1;
+
+-- name: ListPendingStaffInvitations :many
+SELECT id, course_id, email, role, token_hash, invited_by, created_at, expires_at, accepted_at
+FROM course_staff_invitations
+WHERE course_id = $1 AND accepted_at IS NULL AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+-- name: AcceptStaffInvitation :execrows
+UPDATE course_staff_invitations
+SET accepted_at = NOW()
+WHERE id = $1 AND accepted_at IS NULL AND expires_at > NOW();
+
+-- name: DeleteExpiredStaffInvitations :execrows
+DELETE FROM course_staff_invitations
+WHERE accepted_at IS NULL AND expires_at < NOW();
@@ -5,3 +5,18 @@ RETURNING id, name, email, role, created_at;
 
 -- name: GetUserByEmail :one
 SELECT id, name, email, password, role, created_at FROM users WHERE email = $1;
+
+-- name: GetUserByID :one
+SELECT id, name, email, password, role, created_at FROM users WHERE id = $1;
+
+-- name: UpdateUserPassword :execrows
+UPDATE users SET password = $2 WHERE email = $1;
+
+-- name: UpdateUserRole :execrows
+UPDATE users SET role = $2 WHERE email = $1;
+
+-- name: SetUserRole :execrows
+UPDATE users SET role = $2 WHERE id = $1;
+
+-- name: ListAdminIDs :many
+SELECT id FROM users WHERE role = 'admin' ORDER BY id;
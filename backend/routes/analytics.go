@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterAnalyticsRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/courses/:id/analytics")
+	group.Use(middleware.AuthRequired())
+	group.GET("", handlers.CourseAnalyticsHandler(queries))
+	group.GET("/lessons", handlers.CourseLessonAnalyticsHandler(queries))
+	group.GET("/at-risk", handlers.AtRiskLearnersHandler(queries))
+}
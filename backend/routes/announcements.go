@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterAnnouncementsRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/courses/:id/announcements")
+	group.Use(middleware.AuthRequired())
+	group.GET("", handlers.ListCourseAnnouncementsHandler(queries))
+	group.POST("", handlers.CreateAnnouncementHandler(queries))
+	group.PUT("/:announcementId", handlers.UpdateAnnouncementHandler(queries))
+	group.DELETE("/:announcementId", handlers.DeleteAnnouncementHandler(queries))
+	group.POST("/:announcementId/read", handlers.MarkAnnouncementReadHandler(queries))
+	group.GET("/:announcementId/reads", handlers.ListAnnouncementReadsHandler(queries))
+}
@@ -1,12 +1,11 @@
 package routes
 
 import (
-	"database/sql"
 	"github.com/gin-gonic/gin"
 	"online-learning-platform-backend/handlers"
-	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
 )
 
-func RegisterAuthRoutes(r *gin.Engine, queries *db.Queries, dbConn *sql.DB) {
-	r.POST("/login", handlers.LoginHandler(queries, dbConn))
+func RegisterAuthRoutes(r *gin.Engine, queries repository.Store) {
+	r.POST("/login", handlers.LoginHandler(queries))
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+// public sert les documents publics : un badge se vérifie quel que soit l'établissement de la
+// requête, hors des politiques RLS (repository.NewSystem).
+func RegisterBadgesRoutes(r *gin.Engine, queries, public repository.Store, keys *badges.Keyring) {
+	// Documents Open Badges publics : émetteurs, badges, clés et credentials hébergés.
+	r.GET("/badges/issuers/:id", handlers.GetBadgeIssuerHandler(public))
+	r.GET("/badges/classes/:id", handlers.GetAchievementHandler(public))
+	r.GET("/badges/keys", handlers.ListBadgeKeysHandler(keys))
+	r.GET("/badges/keys/:kid", handlers.GetBadgeKeyHandler(keys))
+	r.GET("/badges/credentials/:id", handlers.GetBadgeCredentialHandler(public))
+	r.GET("/badges/credentials/:id/verify", handlers.VerifyBadgeCredentialHandler(public, keys))
+	r.POST("/badges/credentials/:id/revoke", middleware.AuthRequired(), handlers.RevokeBadgeCredentialHandler(queries))
+	r.GET("/me/badges", middleware.AuthRequired(), handlers.ListMyBadgesHandler(queries))
+
+	// Classes de badges de l'établissement de la requête : ses admins et le super-admin.
+	classes := r.Group("/organization/badge-classes")
+	classes.Use(middleware.AuthRequired())
+	classes.GET("", handlers.ListBadgeClassesHandler(queries))
+	classes.POST("", handlers.CreateBadgeClassHandler(queries))
+	classes.PUT("/:id", handlers.UpdateBadgeClassHandler(queries))
+}
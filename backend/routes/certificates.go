@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/badges"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+// public sert les lectures publiques : un certificat se vérifie quel que soit l'établissement de
+// la requête, hors des politiques RLS (repository.NewSystem).
+func RegisterCertificatesRoutes(r *gin.Engine, queries, public repository.Store, keys *badges.Keyring) {
+	// Vérification et téléchargement publics : le lien du QR code s'ouvre sans compte.
+	r.GET("/certificates/:id/verify", handlers.VerifyCertificateHandler(public, keys))
+	r.GET("/certificates/:id/pdf", handlers.DownloadCertificateHandler(public))
+	r.POST("/certificates/:id/revoke", middleware.AuthRequired(), handlers.RevokeCertificateHandler(queries))
+	r.GET("/me/certificates", middleware.AuthRequired(), handlers.ListMyCertificatesHandler(queries))
+
+	course := r.Group("/courses/:id")
+	course.Use(middleware.AuthRequired())
+	course.GET("/certificates", handlers.ListCourseCertificatesHandler(queries))
+	course.GET("/certificate-template", handlers.GetCertificateTemplateHandler(queries))
+	course.PUT("/certificate-template", handlers.SaveCertificateTemplateHandler(queries))
+	course.DELETE("/certificate-template", handlers.ResetCertificateTemplateHandler(queries))
+	course.POST("/certificate-template/preview", handlers.PreviewCertificateTemplateHandler(queries))
+}
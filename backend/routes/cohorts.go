@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterCohortsRoutes(r *gin.Engine, queries repository.Store) {
+	r.GET("/courses/:id/cohorts", middleware.AuthRequired(), handlers.ListCohortsHandler(queries))
+	r.POST("/courses/:id/cohorts", middleware.AuthRequired(), handlers.CreateCohortHandler(queries))
+
+	group := r.Group("/cohorts")
+	group.Use(middleware.AuthRequired())
+	group.POST("/join", handlers.JoinCohortHandler(queries))
+	group.PUT("/:id", handlers.UpdateCohortHandler(queries))
+	group.GET("/:id/roster", handlers.GetCohortRosterHandler(queries))
+	group.DELETE("/:id/members/:userId", handlers.RemoveCohortMemberHandler(queries))
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/events"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterEventsRoutes(r *gin.Engine, queries repository.Store, hub *events.Hub) {
+	r.GET("/events/stream", middleware.StreamAuthRequired(), handlers.StreamEventsHandler(queries, hub))
+	r.GET("/events", middleware.AuthRequired(), handlers.ListMyEventsHandler(queries))
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterForumRoutes(r *gin.Engine, queries repository.Store) {
+	course := r.Group("/courses/:id/forum")
+	course.Use(middleware.AuthRequired())
+	course.GET("/threads", handlers.ListForumThreadsHandler(queries))
+	course.POST("/threads", handlers.CreateForumThreadHandler(queries))
+	course.GET("/bans", handlers.ListForumBansHandler(queries))
+	course.POST("/bans", handlers.BanFromForumHandler(queries))
+	course.DELETE("/bans/:userId", handlers.UnbanFromForumHandler(queries))
+
+	forum := r.Group("/forum")
+	forum.Use(middleware.AuthRequired())
+	forum.GET("/threads/:id", handlers.GetForumThreadHandler(queries))
+	forum.DELETE("/threads/:id", handlers.DeleteForumThreadHandler(queries))
+	forum.POST("/threads/:id/posts", handlers.CreateForumPostHandler(queries))
+	forum.PUT("/threads/:id/answer", handlers.MarkForumAnswerHandler(queries))
+	forum.PUT("/threads/:id/moderation", handlers.ModerateForumThreadHandler(queries))
+	forum.DELETE("/posts/:id", handlers.DeleteForumPostHandler(queries))
+	forum.PUT("/posts/:id/moderation", handlers.ModerateForumPostHandler(queries))
+	forum.POST("/posts/:id/upvote", handlers.UpvoteForumPostHandler(queries))
+	forum.DELETE("/posts/:id/upvote", handlers.UpvoteForumPostHandler(queries))
+}
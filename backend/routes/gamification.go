@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/gamification"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterGamificationRoutes(r *gin.Engine, queries repository.Store, rules *gamification.Rules) {
+	r.GET("/me/gamification", middleware.AuthRequired(), handlers.GetMyGamificationHandler(queries, rules))
+	r.PUT("/me/gamification", middleware.AuthRequired(), handlers.UpdateMyGamificationHandler(queries, rules))
+
+	r.GET("/courses/:id/leaderboard", middleware.AuthRequired(), handlers.CourseLeaderboardHandler(queries))
+	r.GET("/leaderboard", middleware.AuthRequired(), handlers.OrganizationLeaderboardHandler(queries))
+	r.GET("/gamification/highlights", middleware.AuthRequired(), handlers.GamificationHighlightsHandler(queries, rules))
+}
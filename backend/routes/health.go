@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"database/sql"
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/metrics"
+	"online-learning-platform-backend/internal/migrate"
+)
+
+// metricsToken protège /metrics (METRICS_TOKEN) ; vide, la route répond 404.
+func RegisterHealthRoutes(r *gin.Engine, dbConn *sql.DB, migrator *migrate.Migrator, metricsToken string) {
+	r.GET("/healthz", handlers.HealthzHandler())
+	r.GET("/readyz", handlers.ReadyzHandler(dbConn, migrator))
+	r.GET("/metrics", metrics.Handler(metricsToken))
+}
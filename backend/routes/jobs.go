@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterJobsRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/admin/jobs")
+	group.Use(middleware.AuthRequired(), middleware.RequireRole("admin"))
+	group.GET("", handlers.ListJobsHandler(queries))
+	group.GET("/stats", handlers.JobStatsHandler(queries))
+	group.GET("/schedules", handlers.ListJobSchedulesHandler(queries))
+	group.PUT("/schedules/:name", handlers.SetJobScheduleEnabledHandler(queries))
+	group.GET("/:id", handlers.GetJobHandler(queries))
+	group.POST("/:id/retry", handlers.RetryJobHandler(queries))
+	group.DELETE("/:id", handlers.DeleteJobHandler(queries))
+}
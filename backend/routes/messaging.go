@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterMessagingRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/protected")
+	group.Use(middleware.AuthRequired())
+	group.GET("/inbox", handlers.ListInboxHandler(queries))
+	group.GET("/inbox/unread", handlers.UnreadMessagesCountHandler(queries))
+	group.POST("/conversations", handlers.CreateConversationHandler(queries))
+	group.GET("/conversations/:id", handlers.GetConversationHandler(queries))
+	group.GET("/conversations/:id/messages", handlers.ListMessagesHandler(queries))
+	group.POST("/conversations/:id/messages", handlers.SendMessageHandler(queries))
+	group.POST("/conversations/:id/read", handlers.MarkConversationReadHandler(queries))
+	group.GET("/attachments/:id", handlers.DownloadAttachmentHandler(queries))
+}
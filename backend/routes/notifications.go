@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterNotificationsRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/notifications")
+	group.Use(middleware.AuthRequired())
+	group.GET("", handlers.ListNotificationsHandler(queries))
+	group.POST("/read-all", handlers.MarkAllNotificationsReadHandler(queries))
+	group.POST("/:id/read", handlers.MarkNotificationReadHandler(queries))
+	group.GET("/preferences", handlers.GetNotificationPreferencesHandler(queries))
+	group.PUT("/preferences", handlers.UpdateNotificationPreferencesHandler(queries))
+	group.PUT("/webhook", handlers.SetNotificationWebhookHandler(queries))
+	group.DELETE("/webhook", handlers.DeleteNotificationWebhookHandler(queries))
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterOnboardingRoutes(r *gin.Engine, queries repository.Store) {
+	r.GET("/invitations/:token", handlers.GetUserInvitationHandler(queries))
+	r.POST("/invitations/register", handlers.RegisterWithInvitationHandler(queries))
+	r.POST("/invitations/accept", middleware.AuthRequired(), handlers.AcceptUserInvitationHandler(queries))
+
+	applications := r.Group("/teacher-applications")
+	applications.Use(middleware.AuthRequired())
+	applications.POST("", handlers.ApplyForTeacherHandler(queries))
+	applications.GET("/me", handlers.ListMyTeacherApplicationsHandler(queries))
+
+	admin := r.Group("/admin")
+	admin.Use(middleware.AuthRequired(), middleware.RequireRole("admin"))
+	admin.GET("/invitations", handlers.ListUserInvitationsHandler(queries))
+	admin.POST("/invitations", handlers.CreateUserInvitationHandler(queries))
+	admin.DELETE("/invitations/:id", handlers.RevokeUserInvitationHandler(queries))
+	admin.GET("/teacher-applications", handlers.ListTeacherApplicationsHandler(queries))
+	admin.POST("/teacher-applications/:id/approve", handlers.ReviewTeacherApplicationHandler(queries, true))
+	admin.POST("/teacher-applications/:id/reject", handlers.ReviewTeacherApplicationHandler(queries, false))
+}
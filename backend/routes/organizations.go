@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+// UseOrganization rattache chaque requête à son établissement (en-tête X-Organization). gin
+// n'applique un middleware qu'aux routes enregistrées après lui : à appeler en premier.
+func UseOrganization(r *gin.Engine, queries repository.Store) {
+	r.Use(handlers.ResolveOrganization(queries))
+}
+
+func RegisterOrganizationRoutes(r *gin.Engine, queries repository.Store) {
+	r.GET("/organization", handlers.GetCurrentOrganizationHandler())
+	r.GET("/me/organizations", middleware.AuthRequired(), handlers.ListMyOrganizationsHandler(queries))
+
+	// Administration de l'établissement de la requête : ses admins et le super-admin.
+	org := r.Group("/organization")
+	org.Use(middleware.AuthRequired())
+	org.PUT("", handlers.UpdateCurrentOrganizationHandler(queries))
+	org.GET("/members", handlers.ListOrganizationMembersHandler(queries))
+	org.POST("/members", handlers.AddOrganizationMemberHandler(queries))
+	org.PUT("/members/:userId", handlers.UpdateOrganizationMemberHandler(queries))
+	org.DELETE("/members/:userId", handlers.RemoveOrganizationMemberHandler(queries))
+
+	// Tous les établissements : super-admin uniquement.
+	admin := r.Group("/admin/organizations")
+	admin.Use(middleware.AuthRequired(), middleware.RequireRole("admin"))
+	admin.GET("", handlers.ListOrganizationsHandler(queries))
+	admin.POST("", handlers.CreateOrganizationHandler(queries))
+	admin.PUT("/:id", handlers.UpdateOrganizationHandler(queries))
+}
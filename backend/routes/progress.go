@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterProgressRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/courses/:id")
+	group.Use(middleware.AuthRequired())
+	group.GET("/progress", handlers.GetCourseProgressHandler(queries))
+	group.POST("/lessons/:lessonId/complete", handlers.CompleteLessonHandler(queries))
+	group.POST("/lessons/:lessonId/activity", handlers.RecordLessonActivityHandler(queries))
+}
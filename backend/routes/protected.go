@@ -1,12 +1,13 @@
 package routes
 
 import (
-	"database/sql"
 	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/internal/repository"
 	"online-learning-platform-backend/middleware"
+	"time"
 )
 
-func RegisterProtectedRoutes(r *gin.Engine, dbConn *sql.DB) {
+func RegisterProtectedRoutes(r *gin.Engine, queries repository.Store) {
 	group := r.Group("/protected")
 	group.Use(middleware.AuthRequired())
 	group.GET("/me", func(c *gin.Context) {
@@ -20,9 +21,10 @@ func RegisterProtectedRoutes(r *gin.Engine, dbConn *sql.DB) {
 
 		// Aller chercher l'utilisateur en base pour created_at
 		var createdAt string
-		if dbConn != nil && userID > 0 {
-			row := dbConn.QueryRow("SELECT created_at FROM users WHERE id = $1", userID)
-			_ = row.Scan(&createdAt)
+		if userID > 0 {
+			if user, err := queries.GetUserByID(c.Request.Context(), int32(userID)); err == nil && user.CreatedAt.Valid {
+				createdAt = user.CreatedAt.Time.Format(time.RFC3339Nano)
+			}
 		}
 
 		c.JSON(200, gin.H{
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterReviewsRoutes(r *gin.Engine, queries repository.Store) {
+	r.GET("/courses/:id/reviews", handlers.ListCourseReviewsHandler(queries))
+	r.PUT("/courses/:id/reviews/mine", middleware.AuthRequired(), handlers.SaveMyCourseReviewHandler(queries))
+
+	group := r.Group("/reviews")
+	group.Use(middleware.AuthRequired())
+	group.POST("/:id/reply", handlers.ReplyCourseReviewHandler(queries))
+	group.POST("/:id/report", handlers.ReportCourseReviewHandler(queries))
+	group.PUT("/:id/visibility", middleware.RequireRole("admin"), handlers.SetReviewVisibilityHandler(queries))
+
+	r.GET("/admin/reviews/reported", middleware.AuthRequired(), middleware.RequireRole("admin"), handlers.ListReportedReviewsHandler(queries))
+}
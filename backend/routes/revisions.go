@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterRevisionsRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/courses/:id")
+	group.Use(middleware.AuthRequired())
+	group.GET("/draft", handlers.GetCourseDraftHandler(queries))
+	group.PUT("/draft", handlers.SaveCourseDraftHandler(queries))
+	group.POST("/publish", handlers.PublishCourseDraftHandler(queries))
+	group.GET("/revisions", handlers.ListCourseRevisionsHandler(queries))
+	group.GET("/revisions/:number", handlers.GetCourseRevisionHandler(queries))
+	group.GET("/revisions/:number/diff", handlers.DiffCourseRevisionsHandler(queries))
+	group.POST("/revisions/:number/rollback", handlers.RollbackCourseRevisionHandler(queries))
+	group.GET("/content", handlers.GetCourseContentHandler(queries))
+	group.PUT("/pin", handlers.PinCourseVersionHandler(queries))
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"online-learning-platform-backend/handlers"
+	"online-learning-platform-backend/internal/repository"
+	"online-learning-platform-backend/middleware"
+)
+
+func RegisterStaffRoutes(r *gin.Engine, queries repository.Store) {
+	group := r.Group("/courses/:id/staff")
+	group.Use(middleware.AuthRequired())
+	group.GET("", handlers.ListCourseStaffHandler(queries))
+	group.PUT("/:userId", handlers.UpdateCourseStaffRoleHandler(queries))
+	group.DELETE("/:userId", handlers.RemoveCourseStaffHandler(queries))
+	group.POST("/transfer", handlers.TransferCourseOwnershipHandler(queries))
+	group.GET("/invitations", handlers.ListStaffInvitationsHandler(queries))
+	group.POST("/invitations", handlers.InviteCourseStaffHandler(queries))
+
+	r.POST("/staff-invitations/accept", middleware.AuthRequired(), handlers.AcceptStaffInvitationHandler(queries))
+}
@@ -1,12 +1,11 @@
 package routes
 
 import (
-	"database/sql"
 	"github.com/gin-gonic/gin"
 	"online-learning-platform-backend/handlers"
-	"online-learning-platform-backend/internal/db"
+	"online-learning-platform-backend/internal/repository"
 )
 
-func RegisterUserRoutes(r *gin.Engine, queries *db.Queries, dbConn *sql.DB) {
-	r.POST("/register", handlers.RegisterUserHandler(queries, dbConn))
+func RegisterUserRoutes(r *gin.Engine, queries repository.Store) {
+	r.POST("/register", handlers.RegisterUserHandler(queries))
 }
@@ -3,28 +3,34 @@ package main
 import (
 	"context"
 	"fmt"
-	"online-learning-platform-backend/config"
+	"online-learning-platform-backend/internal/database"
 	"online-learning-platform-backend/internal/db"
 )
 
 func main() {
-	config.ConnectDatabase()
-	
-	// Convertir GORM DB en *sql.DB
-	sqlDB, err := config.DB.DB()
+	cfg, err := database.ConfigFromEnv()
 	if err != nil {
-		fmt.Printf("Erreur conversion DB: %v\n", err)
+		fmt.Printf("Erreur configuration DB: %v\n", err)
+		return
+	}
+	sqlDB, err := database.Open(cfg)
+	if err != nil {
+		fmt.Printf("Erreur ouverture DB: %v\n", err)
 		return
 	}
 	defer sqlDB.Close()
-	
+
 	// Test simple query
 	ctx := context.Background()
+	if err := database.Ping(ctx, sqlDB); err != nil {
+		fmt.Printf("Erreur connexion DB: %v\n", err)
+		return
+	}
 	queries := db.New(sqlDB)
-	_, err = queries.ListCourses(ctx)
+	_, err = queries.ListOrganizations(ctx)
 	if err != nil {
-		fmt.Printf("Erreur ListCourses: %v\n", err)
+		fmt.Printf("Erreur ListOrganizations: %v\n", err)
 		return
 	}
-	fmt.Println("Connexion DB et requête ListCourses OK")
+	fmt.Println("Connexion DB et requête ListOrganizations OK")
 }
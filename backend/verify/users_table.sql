@@ -1,7 +0,0 @@
--- Verify online-learning-platform:users_table on pg
-
-BEGIN;
-
--- XXX Add verifications here.
-
-ROLLBACK;
@@ -0,0 +1,7 @@
+-- Rôles de la base, créés au premier démarrage du volume (docker-entrypoint-initdb.d) :
+--   olp_system, propriétaire du schéma : migrations, worker et CLI, hors des politiques RLS ;
+--   olp_app, celui des requêtes HTTP, soumis à l'isolation par établissement.
+CREATE ROLE olp_system LOGIN BYPASSRLS PASSWORD 'olp_system';
+CREATE ROLE olp_app LOGIN PASSWORD 'olp_app';
+ALTER DATABASE online_learning OWNER TO olp_system;
+ALTER SCHEMA public OWNER TO olp_system;
@@ -3,6 +3,7 @@ import { Sidebar, SidebarHeader, SidebarContent, SidebarItem } from '@/component
 import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card';
 import { MetricCard } from '@/components/ui/metric-card';
 import { Button } from '@/components/ui/button';
+import { config } from '@/config';
 
 // Icônes SVG
 const DashboardIcon = ({ className }) => (
@@ -43,6 +44,30 @@ const MessageIcon = ({ className }) => (
 
 export default function Dashboard({ user, token }) {
   const [activeTab, setActiveTab] = useState('dashboard');
+  const [inbox, setInbox] = useState({ items: [], unread_total: 0 });
+  const [highlights, setHighlights] = useState({ top_learners: [], recent_achievements: [] });
+
+  // Boîte de réception : les 3 conversations les plus récentes
+  useEffect(() => {
+    if (!token) return;
+    fetch(`${config.apiBaseUrl}/protected/inbox?page_size=3`, {
+      headers: { Authorization: `Bearer ${token}` }
+    })
+      .then(res => (res.ok ? res.json() : Promise.reject(res)))
+      .then(data => setInbox(data))
+      .catch(() => setInbox({ items: [], unread_total: 0 }));
+  }, [token]);
+
+  // Meilleurs apprenants de la semaine et derniers succès débloqués
+  useEffect(() => {
+    if (!token) return;
+    fetch(`${config.apiBaseUrl}/gamification/highlights`, {
+      headers: { Authorization: `Bearer ${token}` }
+    })
+      .then(res => (res.ok ? res.json() : Promise.reject(res)))
+      .then(data => setHighlights(data))
+      .catch(() => setHighlights({ top_learners: [], recent_achievements: [] }));
+  }, [token]);
   
   // Redirection si pas connecté ou pas admin/teacher
   if (!token || !user) {
@@ -68,11 +93,18 @@ export default function Dashboard({ user, token }) {
     classes: { value: '23', trend: 'up', trendValue: '+2%' }
   };
 
-  // Données simulées pour les étudiants récents
+  // Étudiants : podium de la semaine puis derniers succès (hors utilisateurs retirés des classements)
   const recentStudents = [
-    { name: 'Sébastien Rey', time: '2 days', action: 'Inscrit en GM1' },
-    { name: 'Eliza Roberts', time: '3 days', action: 'Meilleure l\'excellent' },
-    { name: 'Alexander Jean', time: '3 days', action: 'A fini sa phase' }
+    ...highlights.top_learners.slice(0, 3).map(learner => ({
+      name: learner.name,
+      time: `${learner.points} pts`,
+      action: learner.rank === 1 ? 'Meilleur score de la semaine' : `${learner.rank}e de la semaine`
+    })),
+    ...highlights.recent_achievements.slice(0, 3).map(achievement => ({
+      name: achievement.user_name,
+      time: new Date(achievement.earned_at).toLocaleDateString('fr-FR', { dateStyle: 'short' }),
+      action: `Succès : ${achievement.name}`
+    }))
   ];
 
   // Données simulées pour les activités récentes
@@ -83,11 +115,13 @@ export default function Dashboard({ user, token }) {
   ];
 
   // Données pour les messages
-  const messages = [
-    { name: 'Sebastien Rey', time: 'Il y a 2h', message: 'Tu devrais avoir tes les..', avatar: '👨‍🎓' },
-    { name: 'Eliza Roberts', time: '2:04 PM', message: 'Des couleurs utiles plus...', avatar: '👩‍🎓' },
-    { name: 'Alexander Jean', time: 'À l\'in sr asse', message: '', avatar: '👨‍🎓' }
-  ];
+  const messages = inbox.items.map(conversation => ({
+    name: conversation.subject || conversation.participants,
+    time: new Date(conversation.last_message_at).toLocaleString('fr-FR', { dateStyle: 'short', timeStyle: 'short' }),
+    message: conversation.last_message_body,
+    unread: conversation.unread_count,
+    avatar: conversation.is_group ? '👥' : '💬'
+  }));
 
   return (
     <div className="min-h-screen bg-gray-50 flex">
@@ -259,9 +293,14 @@ export default function Dashboard({ user, token }) {
               <Card>
                 <CardHeader className="flex flex-row items-center justify-between">
                   <CardTitle>Messages</CardTitle>
-                  <span className="text-sm text-blue-600">Voir T..</span>
+                  <span className="text-sm text-blue-600">
+                    {inbox.unread_total > 0 ? `${inbox.unread_total} non lu(s)` : 'Voir T..'}
+                  </span>
                 </CardHeader>
                 <CardContent className="space-y-4">
+                  {messages.length === 0 && (
+                    <p className="text-sm text-gray-500">Aucune conversation</p>
+                  )}
                   {messages.map((message, index) => (
                     <div key={index} className="flex items-center space-x-3">
                       <div className="w-10 h-10 bg-blue-100 rounded-full flex items-center justify-center">
@@ -269,7 +308,7 @@ export default function Dashboard({ user, token }) {
                       </div>
                       <div className="flex-1 min-w-0">
                         <div className="flex items-center justify-between">
-                          <p className="text-sm font-medium text-gray-900 truncate">{message.name}</p>
+                          <p className={`text-sm text-gray-900 truncate ${message.unread > 0 ? 'font-bold' : 'font-medium'}`}>{message.name}</p>
                           <span className="text-xs text-gray-500">{message.time}</span>
                         </div>
                         <p className="text-sm text-gray-600 truncate">{message.message}</p>
@@ -285,6 +324,9 @@ export default function Dashboard({ user, token }) {
                   <CardTitle>Étudiants</CardTitle>
                 </CardHeader>
                 <CardContent className="space-y-4">
+                  {recentStudents.length === 0 && (
+                    <p className="text-sm text-gray-500">Aucun point gagné cette semaine</p>
+                  )}
                   {recentStudents.map((student, index) => (
                     <div key={index} className="flex items-center space-x-3">
                       <div className="w-8 h-8 bg-blue-500 rounded-full flex items-center justify-center">
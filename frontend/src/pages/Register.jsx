@@ -259,7 +259,7 @@ export default function Register({ onRegister }) {
                       className="w-full h-12 px-4 border border-gray-200 rounded-xl focus:outline-none focus:ring-4 focus:ring-blue-100 focus:border-blue-500 bg-white text-sm text-gray-900 transition-all duration-200"
                     >
                       <option value="student">Apprenant</option>
-                      <option value="teacher">Formateur</option>
+                      <option value="teacher">Formateur (candidature validée par un administrateur)</option>
                     </select>
                   </div>
                 </div>
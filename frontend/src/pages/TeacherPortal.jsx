@@ -1,7 +1,8 @@
-import React, { useState } from 'react';
+import React, { useState, useEffect } from 'react';
 import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card';
 import { Button } from '@/components/ui/button';
 import { Input } from '@/components/ui/Input';
+import { config } from '@/config';
 
 // Icônes SVG
 const BookIcon = ({ className }) => (
@@ -14,6 +15,39 @@ export default function TeacherPortal({ user, token }) {
   const [activeTab, setActiveTab] = useState('notes');
   const [newGrade, setNewGrade] = useState('');
   const [selectedStudent, setSelectedStudent] = useState('');
+  const [courses, setCourses] = useState([]);
+  const [analyticsCourse, setAnalyticsCourse] = useState('');
+  const [analytics, setAnalytics] = useState(null);
+  const [analyticsError, setAnalyticsError] = useState('');
+
+  // Cours de l'établissement, pour choisir celui dont on consulte les statistiques
+  useEffect(() => {
+    if (activeTab !== 'analytique') return;
+    fetch(`${config.apiBaseUrl}/courses`)
+      .then(res => (res.ok ? res.json() : Promise.reject(res)))
+      .then(data => setCourses(data || []))
+      .catch(() => setCourses([]));
+  }, [activeTab]);
+
+  // Statistiques du cours choisi : synthèse, entonnoir des leçons et apprenants en difficulté
+  useEffect(() => {
+    if (!analyticsCourse || !token) return;
+    const headers = { Authorization: `Bearer ${token}` };
+    const base = `${config.apiBaseUrl}/courses/${analyticsCourse}/analytics`;
+    setAnalyticsError('');
+    Promise.all([base, `${base}/lessons`, `${base}/at-risk`].map(url =>
+      fetch(url, { headers }).then(res => (res.ok ? res.json() : Promise.reject(res)))
+    ))
+      .then(([overview, lessons, atRisk]) => setAnalytics({ overview, lessons: lessons.lessons, atRisk: atRisk.learners }))
+      .catch(() => {
+        setAnalytics(null);
+        setAnalyticsError("Statistiques indisponibles pour ce cours (réservées à l'équipe pédagogique)");
+      });
+  }, [analyticsCourse, token]);
+
+  const formatDuration = seconds => `${Math.round(seconds / 60)} min`;
+  const formatPercent = value => `${Math.round(value * 100)} %`;
+  const riskLabels = { not_started: 'Jamais commencé', inactive: 'Inactif', behind: 'En retard' };
 
   // Données simulées
   const classInfo = {
@@ -43,7 +77,8 @@ export default function TeacherPortal({ user, token }) {
     { id: 'notes', label: 'Notes', icon: '📝' },
     { id: 'devoirs', label: 'Devoirs', icon: '📚' },
     { id: 'presences', label: 'Présences', icon: '✅' },
-    { id: 'bulletins', label: 'Bulletins', icon: '📄' }
+    { id: 'bulletins', label: 'Bulletins', icon: '📄' },
+    { id: 'analytique', label: 'Analytique', icon: '📊' }
   ];
 
   const handleAddGrade = () => {
@@ -284,6 +319,114 @@ export default function TeacherPortal({ user, token }) {
             </CardContent>
           </Card>
         )}
+
+        {activeTab === 'analytique' && (
+          <div className="space-y-6">
+            <Card>
+              <CardContent className="p-6">
+                <select
+                  value={analyticsCourse}
+                  onChange={(e) => setAnalyticsCourse(e.target.value)}
+                  className="w-full p-2 border border-gray-300 rounded-lg"
+                >
+                  <option value="">Choisir un cours</option>
+                  {courses.map(course => (
+                    <option key={course.id} value={course.id}>{course.title}</option>
+                  ))}
+                </select>
+                {analyticsError && <p className="text-sm text-red-600 mt-3">{analyticsError}</p>}
+                {analytics && !analytics.overview.computed_at && (
+                  <p className="text-sm text-gray-500 mt-3">Premier calcul des statistiques en cours.</p>
+                )}
+              </CardContent>
+            </Card>
+
+            {analytics && (
+              <>
+                <div className="grid grid-cols-2 lg:grid-cols-5 gap-4">
+                  {[
+                    ['Inscrits', analytics.overview.summary.enrolled],
+                    ['Taux de complétion', formatPercent(analytics.overview.summary.completion_rate)],
+                    ['Actifs (7 j)', analytics.overview.summary.active_learners_7d],
+                    ['Actifs (30 j)', analytics.overview.summary.active_learners_30d],
+                    ['Temps moyen', formatDuration(analytics.overview.summary.avg_time_seconds)]
+                  ].map(([label, value]) => (
+                    <Card key={label}>
+                      <CardContent className="p-4 text-center">
+                        <div className="text-2xl font-bold text-gray-900">{value}</div>
+                        <p className="text-sm text-gray-600">{label}</p>
+                      </CardContent>
+                    </Card>
+                  ))}
+                </div>
+
+                <Card>
+                  <CardHeader>
+                    <CardTitle>Inscriptions (30 derniers jours)</CardTitle>
+                  </CardHeader>
+                  <CardContent>
+                    <div className="flex items-end h-32 space-x-1">
+                      {analytics.overview.daily.map(day => {
+                        const peak = Math.max(1, ...analytics.overview.daily.map(d => d.enrollments));
+                        return (
+                          <div
+                            key={day.day}
+                            title={`${day.day} : ${day.enrollments} inscription(s), ${day.active_learners} actif(s)`}
+                            className="flex-1 bg-blue-500 rounded-t"
+                            style={{ height: `${(day.enrollments / peak) * 100}%` }}
+                          />
+                        );
+                      })}
+                    </div>
+                  </CardContent>
+                </Card>
+
+                <Card>
+                  <CardHeader>
+                    <CardTitle>Progression par leçon</CardTitle>
+                  </CardHeader>
+                  <CardContent className="space-y-3">
+                    {analytics.lessons.length === 0 && <p className="text-sm text-gray-500">Aucune leçon publiée</p>}
+                    {analytics.lessons.map(lesson => (
+                      <div key={lesson.lesson_id}>
+                        <div className="flex items-center justify-between text-sm">
+                          <span className="font-medium text-gray-900">{lesson.position}. {lesson.title}</span>
+                          <span className="text-gray-600">
+                            {formatPercent(lesson.completion_rate)} · perte {formatPercent(lesson.drop_off)} · {formatDuration(lesson.avg_time_seconds)}
+                          </span>
+                        </div>
+                        <div className="w-full bg-gray-200 rounded-full h-2 mt-1">
+                          <div className="bg-green-500 h-2 rounded-full" style={{ width: `${Math.round(lesson.completion_rate * 100)}%` }} />
+                        </div>
+                      </div>
+                    ))}
+                  </CardContent>
+                </Card>
+
+                <Card>
+                  <CardHeader>
+                    <CardTitle>Apprenants en difficulté</CardTitle>
+                  </CardHeader>
+                  <CardContent className="space-y-3">
+                    {analytics.atRisk.length === 0 && <p className="text-sm text-gray-500">Aucun apprenant signalé</p>}
+                    {analytics.atRisk.map(learner => (
+                      <div key={learner.user_id} className="flex items-center justify-between">
+                        <div>
+                          <p className="text-sm font-medium text-gray-900">{learner.name}</p>
+                          <p className="text-xs text-gray-500">
+                            {learner.lessons_completed} leçon(s) · dernière activité{' '}
+                            {learner.last_active_at ? new Date(learner.last_active_at).toLocaleDateString('fr-FR') : 'jamais'}
+                          </p>
+                        </div>
+                        <span className="text-xs text-red-600">{learner.reasons.map(r => riskLabels[r] || r).join(', ')}</span>
+                      </div>
+                    ))}
+                  </CardContent>
+                </Card>
+              </>
+            )}
+          </div>
+        )}
       </div>
     </div>
   );